@@ -1,5 +1,6 @@
 package console
 
 type Console interface {
-	Start()
+	// Start runs the command line, ExitCode maps the returned error to the exit code of the process
+	Start() error
 }
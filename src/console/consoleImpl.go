@@ -1,122 +1,767 @@
 package console
 
 import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"mars-rover-navigation/src/model"
+	"mars-rover-navigation/src/modules/batch"
+	"mars-rover-navigation/src/modules/command"
+	"mars-rover-navigation/src/modules/environment"
 	"mars-rover-navigation/src/modules/game"
+	"mars-rover-navigation/src/modules/generator"
+	"mars-rover-navigation/src/modules/mission"
+	"mars-rover-navigation/src/modules/obstacle"
+	"mars-rover-navigation/src/modules/output"
+	"mars-rover-navigation/src/modules/planner"
+	"mars-rover-navigation/src/modules/renderer"
+	"mars-rover-navigation/src/modules/repl"
+	"mars-rover-navigation/src/server"
+	"net/http"
+	"os"
+	"os/signal"
+	"runtime"
+	"slices"
+	"strconv"
 	"strings"
+	"syscall"
 
 	"github.com/labstack/gommon/log"
+	"gopkg.in/yaml.v3"
 )
 
 type Modules struct {
-	// TODO:
+	MissionLoader   mission.Loader
+	ObstacleParser  obstacle.Parser
+	CommandCompiler command.Compiler
 }
 
 type consoleImpl struct {
 	modules Modules
+	// interactive is set by --interactive, commands are then optional
+	interactive bool
 }
 
 func Provide() *consoleImpl {
-	// TODO: implement modules initialization
 	return &consoleImpl{
-		modules: Modules{},
+		modules: Modules{
+			MissionLoader:   mission.NewLoader(),
+			ObstacleParser:  obstacle.NewParser(),
+			CommandCompiler: command.NewCompiler(),
+		},
 	}
 }
 
-func (s *consoleImpl) Start() {
-	gridSize, obstacles, commands, err := s.processFlags()
+func (s *consoleImpl) Start() error {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "serve":
+			return s.serve(os.Args[2:])
+		case "plan":
+			return s.plan(os.Args[2:])
+		case "batch":
+			return s.batch(os.Args[2:])
+		case "generate":
+			return s.generate(os.Args[2:])
+		case "explore":
+			return s.explore(os.Args[2:])
+		}
+	}
+
+	// Output flags are bound before processFlags parses the command line
+	outputs := s.bindOutputFlags(flag.CommandLine)
+	m, err := s.processFlags()
+	if err != nil {
+		var inputErr *InputError
+		if errors.As(err, &inputErr) && inputErr.Rejected != nil {
+			// The log line names the problems, the record lists them like the game lists its own
+			formatter, formatErr := output.NewFormatter(output.Format(outputs.format))
+			if formatErr != nil {
+				return &InputError{Input: InputOption, Err: formatErr}
+			}
+			if writeErr := formatter.Write(os.Stdout, output.RecordsOf(mission.Report{Rover: inputErr.Rejected}, false)); writeErr != nil {
+				return writeErr
+			}
+		}
+		return err
+	}
+	viewport, err := s.parseOutputFlags(outputs)
 	if err != nil {
-		log.Error(err)
+		return &InputError{Input: InputOption, Err: err}
+	}
+	formatter, err := output.NewFormatter(output.Format(outputs.format))
+	if err != nil {
+		return &InputError{Input: InputOption, Err: err}
+	}
+	// Trace steps are NDJSON lines, only a JSON result can still be read after them
+	if m.Options.Trace {
+		switch output.Format(strings.ToLower(outputs.format)) {
+		case output.FormatJSON, output.FormatJSONPretty:
+		default:
+			return &InputError{Input: InputOption, Err: fmt.Errorf("trace steps are written as JSON, use --output json or json-pretty with them, not %s", outputs.format)}
+		}
+	}
+
+	if s.interactive {
+		var session repl.REPL = repl.NewREPL(game.NewGame(), m)
+		return session.Run(os.Stdin, os.Stdout)
+	}
+
+	// The rendered path is rebuilt from the trace, which is printed only when asked for
+	printTrace := m.Options.Trace
+	navigation := *m
+	navigation.Options.Trace = printTrace || outputs.render != ""
+
+	var g game.Game = game.NewGame()
+	report := mission.Navigate(g, &navigation)
+
+	// Blocked moves are listed once a policy is chosen, abort keeps the historical output
+	withBlocked := m.Options.ObstaclePolicy != ""
+
+	if printTrace {
+		if report.Fleet != nil {
+			// Steps come out in the order they ran, turn by turn for an interleaved fleet
+			var trace []game.Step
+			for _, roverResult := range report.Fleet.Rovers {
+				trace = append(trace, roverResult.Trace...)
+			}
+			slices.SortStableFunc(trace, func(a, b game.Step) int { return a.Turn - b.Turn })
+			s.printTrace(trace)
+		} else {
+			s.printTrace(report.Rover.Trace)
+		}
+	}
+	if err := formatter.Write(os.Stdout, output.RecordsOf(report, withBlocked)); err != nil {
+		return err
+	}
+
+	if outputs.render != "" {
+		s.render(m, report, viewport)
+	}
+	return statusError(report)
+}
+
+// statusError reports the rover, or the first fleet rover, that did not succeed.
+func statusError(report mission.Report) error {
+	if report.Fleet == nil {
+		if report.Rover.Status != game.StatusSuccess {
+			return &StatusError{Status: report.Rover.Status}
+		}
+		return nil
+	}
+
+	for _, roverResult := range report.Fleet.Rovers {
+		if roverResult.Status != game.StatusSuccess {
+			return &StatusError{Rover: roverResult.ID, Status: roverResult.Status}
+		}
+	}
+	return nil
+}
+
+// outputFlags select the result format and the extra output printed after the result.
+type outputFlags struct {
+	format   string
+	render   string
+	viewport string
+}
+
+func (s *consoleImpl) bindOutputFlags(flags *flag.FlagSet) *outputFlags {
+	o := &outputFlags{}
+	flags.StringVar(&o.format, "output", string(output.FormatJSON), "Result format: json, json-pretty, yaml, text or csv")
+	flags.StringVar(&o.render, "render", "", "Render the grid and rover path after the result (ascii)")
+	flags.StringVar(&o.viewport, "viewport", "", "Crop the rendered grid to WIDTHxHEIGHT cells around the rover")
+	return o
+}
+
+func (s *consoleImpl) parseOutputFlags(o *outputFlags) (model.Size, error) {
+	switch renderer.Format(strings.ToLower(o.render)) {
+	case "", renderer.FormatASCII:
+	default:
+		return model.Size{}, fmt.Errorf("unsupported render format: %q (use ascii)", o.render)
+	}
+
+	if o.viewport == "" {
+		return model.Size{}, nil
+	}
+	width, height, err := s.parseGrid(o.viewport)
+	if err != nil || width <= 0 || height <= 0 {
+		return model.Size{}, fmt.Errorf("invalid viewport: %q (use WIDTHxHEIGHT)", o.viewport)
+	}
+	return model.Size{Width: width, Height: height}, nil
+}
+
+func (s *consoleImpl) render(m *mission.Mission, report mission.Report, viewport model.Size) {
+	// Nothing to draw when the grid itself was rejected
+	if m.Grid.Width <= 0 || m.Grid.Height <= 0 {
 		return
 	}
 
+	var tracks []renderer.Track
+	if report.Fleet != nil {
+		for i, roverResult := range report.Fleet.Rovers {
+			start := m.Rovers[i].Start
+			tracks = append(tracks, renderer.TrackOf(model.Pose{X: start.X, Y: start.Y, Direction: start.Direction}, roverResult.ExtendedResult))
+		}
+	} else {
+		tracks = append(tracks, renderer.TrackOf(model.Pose{X: m.Start.X, Y: m.Start.Y, Direction: m.Start.Direction}, *report.Rover))
+	}
+
+	env := environment.New(m.Grid.Width, m.Grid.Height, m.Obstacles)
+	var r renderer.Renderer = renderer.NewRenderer()
+	fmt.Print(r.Render(env, tracks, viewport))
+}
+
+func (s *consoleImpl) serve(args []string) error {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := flags.String("addr", ":8080", "HTTP listen address")
+	maxBodyBytes := flags.Int64("max_body_bytes", server.DefaultMaxBodyBytes, "Maximum request body size in bytes")
+	if err := flags.Parse(args); err != nil {
+		return &InputError{Input: InputFlags, Err: err}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var srv server.Server = server.Provide(server.Config{MaxBodyBytes: *maxBodyBytes})
+	if err := srv.Run(ctx, *addr); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (s *consoleImpl) plan(args []string) error {
+	flags := flag.NewFlagSet("plan", flag.ContinueOnError)
+	gridInput := s.bindGridFlags(flags)
+	goalX := flags.Int("goal_x", 0, "Goal X position")
+	goalY := flags.Int("goal_y", 0, "Goal Y position")
+	goalDirection := flags.String("goal_direction", "", "Required final direction (N, E, S, W), any when empty")
+	moveCost := flags.Int("move_cost", planner.DefaultCosts.Move, "Cost of a move (M)")
+	turnCost := flags.Int("turn_cost", planner.DefaultCosts.Turn, "Cost of a turn (L, R)")
+	if err := flags.Parse(args); err != nil {
+		return &InputError{Input: InputFlags, Err: err}
+	}
+
+	grid, obstacles, start, err := s.parseGridFlags(gridInput)
+	if err != nil {
+		return err
+	}
+
+	env := environment.New(grid.Width, grid.Height, obstacles)
+	topology, _ := environment.ParseTopology(gridInput.topology)
+	env.SetTopology(topology)
+	var p planner.Planner = planner.NewPlanner(planner.Costs{Move: *moveCost, Turn: *turnCost})
+	result := p.Plan(env, model.Pose{X: start.X, Y: start.Y, Direction: start.Direction}, planner.Goal{
+		Position:  model.Position{X: *goalX, Y: *goalY},
+		Direction: model.Direction(strings.ToUpper(*goalDirection)),
+	})
+
+	if err := json.NewEncoder(os.Stdout).Encode(result); err != nil {
+		return err
+	}
+	if result.Status != planner.StatusSuccess {
+		return &PlanError{Status: result.Status}
+	}
+	return nil
+}
+
+// generate writes a mission on a generated map, the same flags always write the same mission.
+func (s *consoleImpl) generate(args []string) error {
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	gridInput := s.bindGridFlags(flags)
+	seed := flags.Int64("seed", 1, "Seed of the map, the same seed and flags always give the same map")
+	density := flags.Float64("density", 0, "Chance of every cell to hold a scattered boulder, 0 to 1")
+	boulders := flags.Int("boulders", 0, "Number of boulder fields")
+	boulderRadius := flags.Int("boulder_radius", generator.DefaultBoulderRadius, "Radius of a boulder field")
+	craters := flags.Int("craters", 0, "Number of crater rings")
+	craterRadius := flags.Int("crater_radius", generator.DefaultCraterRadius, "Radius of a crater ring")
+	corridors := flags.String("corridors", "[]", "Pairs of cells kept connected, in --obstacles format: [(0,0),(9,9),...] connects (0,0) to (9,9)")
+	commands := flags.String("commands", "", "Command program of the mission")
+	out := flags.String("out", "-", "Mission file to write (.json, .yaml or .yml), - writes YAML to stdout")
+	if err := flags.Parse(args); err != nil {
+		return &InputError{Input: InputFlags, Err: err}
+	}
+
+	grid, obstacles, start, err := s.parseGridFlags(gridInput)
+	if err != nil {
+		return err
+	}
+	ends, err := s.modules.ObstacleParser.Parse(*corridors)
+	if err != nil {
+		return &InputError{Input: InputOption, Err: fmt.Errorf("corridors: %w", err)}
+	}
+	if len(ends)%2 != 0 {
+		return &InputError{Input: InputOption, Err: fmt.Errorf("corridors: %d cells do not pair up", len(ends))}
+	}
+	if *commands == "" {
+		return &InputError{Input: InputCommands, Err: fmt.Errorf("commands are required")}
+	}
+
+	format := mission.FormatYAML
+	if *out != "-" {
+		if format, err = mission.FormatFromPath(*out); err != nil {
+			return &InputError{Input: InputOption, Err: err}
+		}
+	}
+
+	params := generator.Params{
+		Seed: *seed, Size: model.Size{Width: grid.Width, Height: grid.Height}, Obstacles: obstacles, Density: *density,
+		Boulders: *boulders, BoulderRadius: *boulderRadius, Craters: *craters, CraterRadius: *craterRadius,
+		Keep: []model.Position{{X: start.X, Y: start.Y}},
+	}
+	for i := 0; i < len(ends); i += 2 {
+		params.Corridors = append(params.Corridors, generator.Corridor{From: ends[i], To: ends[i+1]})
+	}
+	var g generator.Generator = generator.NewGenerator()
+	generated, err := g.Generate(params)
+	if err != nil {
+		return &InputError{Input: InputOption, Err: err}
+	}
+
+	m := mission.Mission{
+		Grid:      grid,
+		Obstacles: generated.Obstacles,
+		Start:     start,
+		Commands:  *commands,
+		Options:   mission.Options{Topology: gridInput.topology},
+	}
+
+	var data []byte
+	switch format {
+	case mission.FormatJSON:
+		data, err = json.MarshalIndent(m, "", "  ")
+	case mission.FormatYAML:
+		data, err = yaml.Marshal(m)
+	}
+	if err != nil {
+		return fmt.Errorf("encode mission: %w", err)
+	}
+
+	// The file is read back the way --mission loads it, a mission that would not load is not written
+	if _, err := s.modules.MissionLoader.Parse(data, format); err != nil {
+		return &InputError{Input: generatedInput(err), Err: err}
+	}
+	if *out == "-" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	return os.WriteFile(*out, data, 0o644)
+}
+
+// generatedInput is InputCommands when only the program of a generated mission is rejected, InputMission otherwise.
+func generatedInput(err error) Input {
+	var validationErr *mission.ValidationError
+	if !errors.As(err, &validationErr) {
+		return InputMission
+	}
+	for _, fieldErr := range validationErr.Errors {
+		if fieldErr.Path != "commands" {
+			return InputMission
+		}
+	}
+	return InputCommands
+}
+
+// explore drives the rover to the nearest unexplored cells and prints the result with the commands that replay it.
+func (s *consoleImpl) explore(args []string) error {
+	flags := flag.NewFlagSet("explore", flag.ContinueOnError)
+	gridInput := s.bindGridFlags(flags)
+	sensorRadius := flags.Int("sensor_radius", game.DefaultSensorRadius, "Cells around the rover it senses after every move")
+	maxMoves := flags.Int("max_moves", 0, "Moves the rover may make, 0 explores until no reachable cell is unknown")
+	profile := flags.String("profile", "", "Rover profile whose battery bounds the exploration: standard, heavy or scout (default unlimited)")
+	format := flags.String("output", string(output.FormatJSON), "Result format: json, json-pretty, yaml, text or csv")
+	if err := flags.Parse(args); err != nil {
+		return &InputError{Input: InputFlags, Err: err}
+	}
+
+	grid, obstacles, start, err := s.parseGridFlags(gridInput)
+	if err != nil {
+		return err
+	}
+	formatter, err := output.NewFormatter(output.Format(*format))
+	if err != nil {
+		return &InputError{Input: InputOption, Err: err}
+	}
+
+	options := game.Options{SensorRadius: *sensorRadius}
+	options.Topology, _ = environment.ParseTopology(gridInput.topology)
+	if *profile != "" {
+		energy, ok := game.Profiles[strings.ToLower(*profile)]
+		if !ok {
+			return &InputError{Input: InputOption, Err: fmt.Errorf("unknown profile %q (use standard, heavy or scout)", *profile)}
+		}
+		options.Energy = &energy
+	}
+
 	var g game.Game = game.NewGame()
-	result := g.NavigateRover(gridSize, obstacles, commands)
+	result := g.Explore(grid.Width, grid.Height, obstacles, model.Position{X: start.X, Y: start.Y}, start.Direction, *maxMoves, options)
+	if err := formatter.Write(os.Stdout, output.RecordsOfExploration(result)); err != nil {
+		return err
+	}
+	if result.Status != game.StatusSuccess {
+		return &StatusError{Status: result.Status}
+	}
+	return nil
+}
+
+// batch runs the NDJSON missions of --input and ends with a summary line, it fails when a --fail_on condition is met.
+func (s *consoleImpl) batch(args []string) error {
+	flags := flag.NewFlagSet("batch", flag.ContinueOnError)
+	input := flags.String("input", "-", "NDJSON file with one mission per line, - for stdin")
+	workers := flags.Int("workers", runtime.NumCPU(), "Missions run at once")
+	order := flags.String("order", string(batch.OrderInput), "Result order: input, or completed to write each result with its id as soon as it is done")
+	failOn := flags.String("fail_on", string(batch.FailOnInvalid), "Comma separated conditions that fail the batch: invalid, any or a status such as obstacle_encountered")
+	if err := flags.Parse(args); err != nil {
+		return &InputError{Input: InputFlags, Err: err}
+	}
+
+	switch batch.Order(strings.ToLower(*order)) {
+	case batch.OrderInput, batch.OrderCompleted:
+	default:
+		return &InputError{Input: InputOption, Err: fmt.Errorf("unknown order %q (use input or completed)", *order)}
+	}
+	conditions, err := batch.ParseFailConditions(*failOn)
+	if err != nil {
+		return &InputError{Input: InputOption, Err: err}
+	}
+
+	in := io.Reader(os.Stdin)
+	if *input != "-" {
+		file, err := os.Open(*input)
+		if err != nil {
+			return fmt.Errorf("open missions: %w", err)
+		}
+		defer file.Close()
+		in = file
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+
+	var runner batch.Runner = batch.NewRunner(s.modules.MissionLoader, game.NewGame(),
+		batch.Options{Workers: *workers, Order: batch.Order(strings.ToLower(*order))})
+	summary, err := runner.Run(ctx, in, out)
+	if err != nil {
+		return err
+	}
+	if err := json.NewEncoder(out).Encode(struct {
+		Summary batch.Summary `json:"summary"`
+	}{summary}); err != nil {
+		return err
+	}
+
+	if summary.Failed(conditions) {
+		return fmt.Errorf("%w on %s", ErrBatchFailed, *failOn)
+	}
+	return nil
+}
 
-	fmt.Printf("{\"final_position\": [%d, %d], \"final_direction\": \"%s\", \"status\": \"%s\"}\n",
-		result.FinalPosition.X, result.FinalPosition.Y, result.FinalDirection, result.Status)
+// printTrace writes one JSON object per line (NDJSON) for every executed step.
+func (s *consoleImpl) printTrace(trace []game.Step) {
+	encoder := json.NewEncoder(os.Stdout)
+	for _, step := range trace {
+		if err := encoder.Encode(step); err != nil {
+			log.Error(err)
+			return
+		}
+	}
+}
+
+// gridFlags are the grid, obstacles and start flags shared by the run and plan modes.
+type gridFlags struct {
+	flags          *flag.FlagSet
+	gridSize       int
+	grid           string
+	obstacles      string
+	startX         int
+	startY         int
+	startDirection string
+	topology       string
+}
+
+func (s *consoleImpl) bindGridFlags(flags *flag.FlagSet) *gridFlags {
+	g := &gridFlags{flags: flags}
+	flags.IntVar(&g.gridSize, "grid_size", 0, "Square grid size (shorthand for --grid NxN)")
+	flags.StringVar(&g.grid, "grid", "", "Grid dimensions in format WIDTHxHEIGHT or N for a square grid")
+	flags.StringVar(&g.obstacles, "obstacles", "[]", "Obstacles in format [(x,y),(x,y),...] or [[x,y],...], a coordinate may be a range like (2..5,3)")
+	flags.IntVar(&g.startX, "start_x", 0, "Rover start X position")
+	flags.IntVar(&g.startY, "start_y", 0, "Rover start Y position")
+	flags.StringVar(&g.startDirection, "start_direction", string(model.North), "Rover start direction (N, E, S, W, or NE, SE, SW, NW with --compass eight)")
+	flags.StringVar(&g.topology, "topology", "", "How the grid edges connect: bounded, wrap_x, wrap_y or torus (default bounded)")
+	return g
 }
 
-func (s *consoleImpl) processFlags() (int, []model.Position, string, error) {
-	var gridSize int
-	var obstaclesInput string
+// parseGridFlags returns the grid dimensions, obstacles and start pose given by the flags.
+func (s *consoleImpl) parseGridFlags(g *gridFlags) (mission.Grid, []model.Position, mission.Pose, error) {
+	width, height := g.gridSize, g.gridSize
+	if g.grid != "" {
+		var err error
+		width, height, err = s.parseGrid(g.grid)
+		if err != nil {
+			return mission.Grid{}, nil, mission.Pose{}, &InputError{Input: InputGrid, Err: err}
+		}
+	}
+
+	if width == 0 || height == 0 {
+		fmt.Println("Error: grid size is required")
+		g.flags.Usage()
+		return mission.Grid{}, nil, mission.Pose{}, &InputError{Input: InputGrid, Err: fmt.Errorf("grid size is required")}
+	}
+	// The subcommands build their environment straight from the flags, the game never sees the grid
+	if width < 0 || height < 0 {
+		return mission.Grid{}, nil, mission.Pose{}, &InputError{Input: InputGrid,
+			Err: fmt.Errorf("grid width and height must be positive, got %dx%d", width, height)}
+	}
+
+	g.topology = strings.ToLower(g.topology)
+	if _, err := environment.ParseTopology(g.topology); err != nil {
+		return mission.Grid{}, nil, mission.Pose{}, &InputError{Input: InputOption, Err: err}
+	}
+
+	// A malformed obstacle list still returns the grid and start, for the problems of the rest of the input
+	grid := mission.Grid{Width: width, Height: height}
+	start := mission.Pose{X: g.startX, Y: g.startY, Direction: model.Direction(strings.ToUpper(g.startDirection))}
+	obstacles, err := s.parseObstacles(g.obstacles)
+	if err != nil {
+		return grid, nil, start, &InputError{Input: InputObstacles, Err: err}
+	}
+	return grid, obstacles, start, nil
+}
+
+func (s *consoleImpl) processFlags() (*mission.Mission, error) {
 	var commands string
+	var missionPath string
+	var trace bool
+	var policy string
+	var skipLimit int
+	var compass string
+	var cornerCutting bool
+	var profile string
+	var sensorRadius int
 
-	flag.IntVar(&gridSize, "grid_size", 0, "Grid size")
-	flag.StringVar(&obstaclesInput, "obstacles", "[]", "Obstacles in format [(x,y),(x,y),...]")
-	flag.StringVar(&commands, "commands", "", "Commands string")
+	gridInput := s.bindGridFlags(flag.CommandLine)
+	flag.StringVar(&commands, "commands", "", "Command program of L, R, M, B, U (A, C with --compass eight) with counts (5M), groups ((MMR)4) and # comments")
+	flag.StringVar(&missionPath, "mission", "", "Mission file (.json, .yaml or .yml) with grid, obstacles, start and commands")
+	flag.BoolVar(&trace, "trace", false, "Print every executed command as NDJSON before the result")
+	flag.StringVar(&policy, "obstacle_policy", "", "What a blocked move does: abort, skip, skip_with_limit or replan (default abort)")
+	flag.IntVar(&skipLimit, "skip_limit", 0, "Blocked moves skipped by skip_with_limit before the rover stops")
+	flag.StringVar(&compass, "compass", "", "Headings a rover can take: four, or eight for diagonals and the A, C 45 degree turns (default four)")
+	flag.BoolVar(&cornerCutting, "corner_cutting", false, "Let a diagonal move pass between two obstacles touching its corners")
+	flag.BoolVar(&s.interactive, "interactive", false, "Drive the rover line by line from stdin, the commands run first (type help for the session commands)")
+	flag.StringVar(&profile, "profile", "", "Rover profile whose battery the rover runs on: standard, heavy, scout or one of the mission (default unlimited)")
+	flag.IntVar(&sensorRadius, "sensor_radius", 0, "Run in fog of war, the rover only knows the cells within this radius of where it has been (default 0, sensors off)")
 	flag.Parse()
 
-	if gridSize == 0 {
-		fmt.Println("Error: grid size is required")
-		flag.Usage()
-		return 0, nil, "", fmt.Errorf("grid size is required")
+	policy = strings.ToLower(policy)
+	compass = strings.ToLower(compass)
+	switch game.Compass(compass) {
+	case "", game.CompassFour, game.CompassEight:
+	default:
+		return nil, &InputError{Input: InputOption, Err: fmt.Errorf("unknown compass %q (use four or eight)", compass)}
+	}
+	if missionPath != "" {
+		m, err := s.loadMission(missionPath)
+		if err != nil {
+			return nil, err
+		}
+		m.Options.Trace = m.Options.Trace || trace
+		// Policy flags override the mission options
+		if policy != "" {
+			m.Options.ObstaclePolicy = policy
+		}
+		if skipLimit != 0 {
+			m.Options.SkipLimit = skipLimit
+		}
+		if gridInput.topology != "" {
+			topology := strings.ToLower(gridInput.topology)
+			if _, err := environment.ParseTopology(topology); err != nil {
+				return nil, &InputError{Input: InputOption, Err: err}
+			}
+			m.Options.Topology = topology
+		}
+		if compass != "" {
+			m.Options.Compass = compass
+		}
+		m.Options.CornerCutting = m.Options.CornerCutting || cornerCutting
+		if profile != "" {
+			if _, ok := m.EnergyProfile(profile); !ok {
+				return nil, &InputError{Input: InputOption, Err: fmt.Errorf("unknown profile %q", profile)}
+			}
+			m.Options.Profile = profile
+		}
+		if sensorRadius != 0 {
+			m.Options.SensorRadius = sensorRadius
+		}
+		return m, nil
+	}
+
+	grid, obstacles, start, err := s.parseGridFlags(gridInput)
+	// A malformed obstacle list is rejected together with the problems of the commands and the rest of the input
+	var obstacleErrs game.ValidationErrors
+	var inputErr *InputError
+	if err != nil && !(errors.As(err, &inputErr) && inputErr.Input == InputObstacles && errors.As(err, &obstacleErrs)) {
+		return nil, err
 	}
 
-	if commands == "" {
+	if commands == "" && !s.interactive {
 		fmt.Println("Error: commands are required")
 		flag.Usage()
-		return 0, nil, "", fmt.Errorf("commands are required")
+		return nil, &InputError{Input: InputCommands, Err: fmt.Errorf("commands are required")}
 	}
 
-	if err := s.validateObstaclesInput(obstaclesInput); err != nil {
-		log.Error(err)
-		return 0, nil, "", err
+	m := &mission.Mission{
+		Grid:      grid,
+		Obstacles: obstacles,
+		Start:     start,
+		Commands:  commands,
+		Options: mission.Options{Trace: trace, ObstaclePolicy: policy, SkipLimit: skipLimit, Topology: gridInput.topology,
+			Compass: compass, CornerCutting: cornerCutting, Profile: profile, SensorRadius: sensorRadius},
+	}
+	if _, ok := m.EnergyProfile(profile); !ok {
+		return nil, &InputError{Input: InputOption, Err: fmt.Errorf("unknown profile %q (use standard, heavy or scout)", profile)}
 	}
 
-	obstacles := s.parseObstacles(obstaclesInput)
-	return gridSize, obstacles, commands, nil
+	compiled, err := s.modules.CommandCompiler.Compile(commands, nil)
+	var commandErrs game.ValidationErrors
+	if err != nil {
+		commandErrs = commandErrors(commands, err)
+	}
+	if len(obstacleErrs) > 0 || len(commandErrs) > 0 {
+		return nil, s.rejectInput(m, obstacleErrs, commandErrs)
+	}
+	m.Commands = compiled
+	return m, nil
 }
 
-func (s *consoleImpl) parseObstacles(obstaclesInput string) []model.Position {
-	var obstacles []model.Position
+// rejectInput lists the obstacles and commands that did not parse with the problems the game finds in the rest of m,
+// in the order the game checks the fields.
+func (s *consoleImpl) rejectInput(m *mission.Mission, obstacleErrs, commandErrs game.ValidationErrors) error {
+	rest := *m
+	rest.Commands = ""
+	rest.Options.Trace = false
+	report := mission.Navigate(game.NewGame(), &rest)
 
-	if obstaclesInput == "[]" || strings.TrimSpace(obstaclesInput[1:len(obstaclesInput)-1]) == "" {
-		return obstacles
+	errs := append(game.ValidationErrors{}, obstacleErrs...)
+	for _, err := range report.Rover.Errors {
+		if err.Code == game.CodeStartOnObstacle {
+			errs = append(errs, commandErrs...)
+			commandErrs = nil
+		}
+		errs = append(errs, err)
 	}
+	errs = append(errs, commandErrs...)
 
-	obstaclesInput = obstaclesInput[1 : len(obstaclesInput)-1] // remove outer [ ]
-	pairs := obstaclesInput
-	pairs = strings.ReplaceAll(pairs, "(", "")
-	pairs = strings.ReplaceAll(pairs, ")", "")
-	pairs = strings.ReplaceAll(pairs, " ", "")
-	pairStrs := strings.Split(pairs, ",")
+	input := InputCommands
+	if len(obstacleErrs) > 0 {
+		input = InputObstacles
+	}
+	rejected := &game.ExtendedResult{Result: game.Result{
+		FinalPosition:  model.Position{X: 0, Y: 0},
+		FinalDirection: model.North,
+		Status:         game.StatusInvalidInput,
+		Grid:           model.Size{Width: m.Grid.Width, Height: m.Grid.Height},
+		Errors:         errs,
+	}}
+	return &InputError{Input: input, Err: errs, Rejected: rejected}
+}
 
-	for i := 0; i < len(pairStrs); i += 2 {
-		if i+1 < len(pairStrs) {
-			var x, y int
-			fmt.Sscanf(pairStrs[i], "%d", &x)
-			fmt.Sscanf(pairStrs[i+1], "%d", &y)
-			obstacles = append(obstacles, model.Position{X: x, Y: y})
+// commandErrors reports the syntax errors of a command program as invalid_command errors, indexed by the character of
+// source they point at.
+func commandErrors(source string, err error) game.ValidationErrors {
+	var syntaxErrs command.SyntaxErrors
+	if !errors.As(err, &syntaxErrs) {
+		var syntaxErr *command.SyntaxError
+		if !errors.As(err, &syntaxErr) {
+			return game.ValidationErrors{game.NewValidationError(game.CodeInvalidCommand, "commands", source, err.Error())}
 		}
+		syntaxErrs = command.SyntaxErrors{syntaxErr}
 	}
 
-	return obstacles
+	runes := []rune(source)
+	errs := make(game.ValidationErrors, 0, len(syntaxErrs))
+	for _, syntaxErr := range syntaxErrs {
+		index := sourceIndex(runes, syntaxErr.Line, syntaxErr.Column)
+		// An error at the end of the program points past the last character
+		value := ""
+		if index < len(runes) {
+			value = string(runes[index])
+		}
+		errs = append(errs, game.NewIndexedValidationError(game.CodeInvalidCommand, "commands", index, value, syntaxErr.Message))
+	}
+	return errs
 }
 
-func (s *consoleImpl) validateObstaclesInput(obstaclesInput string) error {
-	if len(obstaclesInput) < 2 {
-		return fmt.Errorf("invalid obstacles format: too short")
+// sourceIndex is the index in runes of the 1-based line and column the command compiler reports.
+func sourceIndex(runes []rune, line, column int) int {
+	l, c := 1, 1
+	for i, r := range runes {
+		if l == line && c == column {
+			return i
+		}
+		if r == '\n' {
+			l, c = l+1, 1
+		} else {
+			c++
+		}
 	}
+	return len(runes)
+}
 
-	if obstaclesInput[0] != '[' || obstaclesInput[len(obstaclesInput)-1] != ']' {
-		return fmt.Errorf("invalid obstacles format: must be wrapped in []")
+func (s *consoleImpl) loadMission(missionPath string) (*mission.Mission, error) {
+	// The mission file is the single source of the navigation input
+	var conflicts []string
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "grid", "grid_size", "obstacles", "commands", "start_x", "start_y", "start_direction":
+			conflicts = append(conflicts, "--"+f.Name)
+		}
+	})
+	if len(conflicts) > 0 {
+		return nil, &InputError{Input: InputFlags, Err: fmt.Errorf("--mission cannot be combined with %s", strings.Join(conflicts, ", "))}
 	}
 
-	// Check if it's empty brackets
-	content := obstaclesInput[1 : len(obstaclesInput)-1]
-	if strings.TrimSpace(content) == "" {
-		return nil // empty obstacles is valid
+	m, err := s.modules.MissionLoader.Load(missionPath)
+	if err != nil {
+		return nil, &InputError{Input: InputMission, Err: err}
 	}
+	return m, nil
+}
 
-	// Basic validation for parentheses pairs
-	openCount := strings.Count(content, "(")
-	closeCount := strings.Count(content, ")")
-	if openCount != closeCount {
-		return fmt.Errorf("invalid obstacles format: mismatched parentheses")
+func (s *consoleImpl) parseGrid(gridInput string) (int, int, error) {
+	parts := strings.Split(strings.ToLower(strings.TrimSpace(gridInput)), "x")
+	if len(parts) > 2 {
+		return 0, 0, fmt.Errorf("invalid grid format: expected WIDTHxHEIGHT or N")
 	}
 
-	return nil
+	width, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid grid width: %q", parts[0])
+	}
+
+	// A single number is the square shorthand
+	if len(parts) == 1 {
+		return width, width, nil
+	}
+
+	height, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid grid height: %q", parts[1])
+	}
+
+	return width, height, nil
+}
+
+// parseObstacles reads the obstacle list, a syntax error is reported as game.ValidationErrors like the obstacles the game rejects.
+func (s *consoleImpl) parseObstacles(obstaclesInput string) ([]model.Position, error) {
+	obstacles, err := s.modules.ObstacleParser.Parse(obstaclesInput)
+	if err != nil {
+		return nil, game.ValidationErrors{game.NewValidationError(game.CodeInvalidFormat, "obstacles", obstaclesInput, err.Error())}
+	}
+	return obstacles, nil
 }
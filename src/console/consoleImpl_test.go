@@ -2,12 +2,22 @@ package console
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
 	"flag"
 	"mars-rover-navigation/src/model"
+	"mars-rover-navigation/src/modules/game"
+	"mars-rover-navigation/src/modules/mission"
+	"mars-rover-navigation/src/modules/output"
+	"mars-rover-navigation/src/modules/planner"
 	"os"
+	"path/filepath"
 	"reflect"
+	"slices"
 	"strings"
 	"testing"
+
+	"gopkg.in/yaml.v3"
 )
 
 func TestProvide(t *testing.T) {
@@ -15,8 +25,14 @@ func TestProvide(t *testing.T) {
 	if impl == nil {
 		t.Error("Provide() returned nil")
 	}
-	if impl.modules == (Modules{}) {
-		// Expected empty modules for now
+	if impl.modules.MissionLoader == nil {
+		t.Error("Provide() should initialize the mission loader")
+	}
+	if impl.modules.ObstacleParser == nil {
+		t.Error("Provide() should initialize the obstacle parser")
+	}
+	if impl.modules.CommandCompiler == nil {
+		t.Error("Provide() should initialize the command compiler")
 	}
 }
 
@@ -52,6 +68,301 @@ func TestConsoleImpl_Start_Success(t *testing.T) {
 	}
 }
 
+func TestConsoleImpl_Start_Error(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected int
+	}{
+		{name: "Success", args: []string{"-grid_size=5", "-commands=MMMRM"}, expected: ExitSuccess},
+		{name: "Obstacle encountered", args: []string{"-grid_size=5", "-obstacles=[(0,1)]", "-commands=M"}, expected: ExitObstacleEncountered},
+		{name: "Invalid obstacles", args: []string{"-grid_size=5", "-obstacles=(0,1)", "-commands=M"}, expected: ExitInvalidObstacles},
+		{name: "Invalid commands", args: []string{"-grid_size=5", "-commands=MX"}, expected: ExitInvalidCommands},
+		{name: "Invalid viewport", args: []string{"-grid_size=5", "-commands=M", "-render=ascii", "-viewport=0x0"}, expected: ExitInvalidOption},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			oldArgs := os.Args
+			defer func() { os.Args = oldArgs }()
+			os.Args = append([]string{"cmd"}, tc.args...)
+			flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+
+			oldStdout := os.Stdout
+			_, w, _ := os.Pipe()
+			os.Stdout = w
+
+			err := Provide().Start()
+
+			w.Close()
+			os.Stdout = oldStdout
+
+			if code := ExitCode(err); code != tc.expected {
+				t.Errorf("Start() error = %v, exit code %d, want %d", err, code, tc.expected)
+			}
+		})
+	}
+}
+
+func TestConsoleImpl_Start_Trace(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	os.Args = []string{"cmd", "-grid_size=5", "-obstacles=[(1,2)]", "-commands=MMRM", "-trace"}
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	impl := Provide()
+	impl.Start()
+
+	w.Close()
+	os.Stdout = oldStdout
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+
+	// 4 steps followed by the result line
+	if len(lines) != 5 {
+		t.Fatalf("Expected 5 NDJSON lines, got %d: %s", len(lines), buf.String())
+	}
+	for i, line := range lines[:4] {
+		var step game.Step
+		if err := json.Unmarshal([]byte(line), &step); err != nil {
+			t.Fatalf("line %d is not valid JSON: %v", i, err)
+		}
+		if step.Index != i {
+			t.Errorf("line %d has index %d", i, step.Index)
+		}
+	}
+	if !strings.Contains(lines[3], `"move_status":"Obstacle encountered"`) {
+		t.Errorf("Expected last step to hit the obstacle, got: %s", lines[3])
+	}
+	if !strings.Contains(lines[4], "final_position") {
+		t.Errorf("Expected result as last line, got: %s", lines[4])
+	}
+}
+
+func TestConsoleImpl_Start_FleetTrace(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	missionPath := filepath.Join(t.TempDir(), "fleet.json")
+	os.WriteFile(missionPath, []byte(`{
+		"grid": {"width": 5, "height": 5},
+		"rovers": [
+			{"id": "a", "start": {"x": 0, "y": 0, "direction": "E"}, "commands": "MML"},
+			{"start": {"x": 4, "y": 4, "direction": "S"}, "commands": "M"}
+		],
+		"options": {"fleet_mode": "interleaved", "trace": true}
+	}`), 0o600)
+
+	os.Args = []string{"cmd", "-mission=" + missionPath}
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	Provide().Start()
+
+	w.Close()
+	os.Stdout = oldStdout
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+
+	// The steps come out in the order they ran, followed by one result line per rover
+	expected := []struct {
+		rover string
+		turn  int
+		index int
+	}{
+		{"a", 1, 0},
+		{"rover-2", 1, 0},
+		{"a", 2, 1},
+		{"a", 3, 2},
+	}
+	if len(lines) != len(expected)+2 {
+		t.Fatalf("Expected %d NDJSON lines, got %d: %s", len(expected)+2, len(lines), buf.String())
+	}
+	for i, want := range expected {
+		var step game.Step
+		if err := json.Unmarshal([]byte(lines[i]), &step); err != nil {
+			t.Fatalf("line %d is not valid JSON: %v", i, err)
+		}
+		if step.Rover != want.rover || step.Turn != want.turn || step.Index != want.index {
+			t.Errorf("line %d = %s, want rover %s turn %d index %d", i, lines[i], want.rover, want.turn, want.index)
+		}
+	}
+}
+
+func TestConsoleImpl_Start_Render(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	os.Args = []string{"cmd", "-grid=4x3", "-obstacles=[(2,2)]", "-commands=MRM", "-render=ascii"}
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	impl := Provide()
+	impl.Start()
+
+	w.Close()
+	os.Stdout = oldStdout
+	var buf bytes.Buffer
+	buf.ReadFrom(r)
+
+	// The trace feeds the path but is not printed without --trace
+	expected := "{\"version\":1,\"final_position\":[1,1],\"final_direction\":\"E\",\"status\":\"Success\",\"grid\":[4,3]}\n" +
+		"2 ..#.\n" +
+		"1 *>..\n" +
+		"0 S...\n" +
+		"  x=0..3\n"
+	if buf.String() != expected {
+		t.Errorf("Expected output:\n%s\ngot:\n%s", expected, buf.String())
+	}
+}
+
+func TestConsoleImpl_Start_Output(t *testing.T) {
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{format: "json", expected: "{\"version\":1,\"final_position\":[1,3],\"final_direction\":\"E\",\"status\":\"Success\",\"grid\":[5,5]}\n"},
+		{format: "yaml", expected: "version: 1\nfinal_position: [1, 3]\nfinal_direction: E\nstatus: Success\ngrid: [5, 5]\n"},
+		{format: "text", expected: "(1, 3) E Success on a 5x5 grid\n"},
+		{format: "csv", expected: "version,rover,x,y,direction,status,width,height,remaining_charge,blocked,errors,explored,commands,moves,stop\n1,,1,3,E,Success,5,5,,0,0,,,,\n"},
+		{format: "xml", expected: ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.format, func(t *testing.T) {
+			oldArgs := os.Args
+			defer func() { os.Args = oldArgs }()
+			os.Args = []string{"cmd", "-grid_size=5", "-obstacles=[(1,2),(3,3)]", "-commands=MMMRM", "-output=" + tc.format}
+			flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			Provide().Start()
+
+			w.Close()
+			os.Stdout = oldStdout
+			var buf bytes.Buffer
+			buf.ReadFrom(r)
+
+			// An unsupported format is logged and no result is printed
+			got := buf.String()
+			if tc.expected == "" {
+				if strings.Contains(got, "final_position") {
+					t.Errorf("Start() output = %s, want no result", got)
+				}
+				return
+			}
+			if got != tc.expected {
+				t.Errorf("Start() output =\n%s\nwant\n%s", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestConsoleImpl_Start_TraceOutput(t *testing.T) {
+	tests := []struct {
+		format  string
+		wantErr bool
+	}{
+		{format: "json"},
+		{format: "json-pretty"},
+		{format: "yaml", wantErr: true},
+		{format: "text", wantErr: true},
+		{format: "CSV", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.format, func(t *testing.T) {
+			oldArgs := os.Args
+			defer func() { os.Args = oldArgs }()
+			os.Args = []string{"cmd", "-grid_size=5", "-commands=MMRMM", "-trace", "-output=" + tc.format}
+			flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			err := Provide().Start()
+
+			w.Close()
+			os.Stdout = oldStdout
+			var buf bytes.Buffer
+			buf.ReadFrom(r)
+
+			if tc.wantErr {
+				var inputErr *InputError
+				if !errors.As(err, &inputErr) || inputErr.Input != InputOption || buf.Len() != 0 {
+					t.Errorf("Start() error = %v, output = %s, want an option error and no output", err, buf.String())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Start() error = %v", err)
+			}
+			// Every step and the result decode as one JSON stream
+			decoder := json.NewDecoder(&buf)
+			values := 0
+			for decoder.More() {
+				var value map[string]any
+				if err := decoder.Decode(&value); err != nil {
+					t.Fatalf("Start() output is not a JSON stream: %v", err)
+				}
+				values++
+			}
+			if values != 6 {
+				t.Errorf("Start() wrote %d JSON values, want 5 steps and the result", values)
+			}
+		})
+	}
+}
+
+func TestConsoleImpl_ParseOutputFlags(t *testing.T) {
+	tests := []struct {
+		name     string
+		render   string
+		viewport string
+		expected model.Size
+		wantErr  bool
+	}{
+		{name: "No rendering", expected: model.Size{}},
+		{name: "ASCII", render: "ascii", expected: model.Size{}},
+		{name: "ASCII uppercase", render: "ASCII", expected: model.Size{}},
+		{name: "Viewport", render: "ascii", viewport: "21x11", expected: model.Size{Width: 21, Height: 11}},
+		{name: "Square viewport", render: "ascii", viewport: "9", expected: model.Size{Width: 9, Height: 9}},
+		{name: "Unknown format", render: "svg", wantErr: true},
+		{name: "Invalid viewport", render: "ascii", viewport: "0x3", wantErr: true},
+		{name: "Malformed viewport", render: "ascii", viewport: "axb", wantErr: true},
+	}
+
+	impl := Provide()
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := impl.parseOutputFlags(&outputFlags{render: tc.render, viewport: tc.viewport})
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("parseOutputFlags() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got != tc.expected {
+				t.Errorf("parseOutputFlags() = %+v, want %+v", got, tc.expected)
+			}
+		})
+	}
+}
+
 func TestConsoleImpl_Start_MissingGridSize(t *testing.T) {
 	oldArgs := os.Args
 	defer func() { os.Args = oldArgs }()
@@ -119,11 +430,24 @@ func TestConsoleImpl_ParseObstacles_Empty(t *testing.T) {
 			input:    "[(1, 2), (3, 4)]",
 			expected: []model.Position{{X: 1, Y: 2}, {X: 3, Y: 4}},
 		},
+		{
+			name:     "json arrays",
+			input:    "[[1,2],[3,3]]",
+			expected: []model.Position{{X: 1, Y: 2}, {X: 3, Y: 3}},
+		},
+		{
+			name:     "wall",
+			input:    "[(2..4,3)]",
+			expected: []model.Position{{X: 2, Y: 3}, {X: 3, Y: 3}, {X: 4, Y: 3}},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := impl.parseObstacles(tt.input)
+			result, err := impl.parseObstacles(tt.input)
+			if err != nil {
+				t.Fatalf("parseObstacles(%s) error = %v", tt.input, err)
+			}
 			if len(result) != len(tt.expected) {
 				t.Errorf("parseObstacles(%s) length = %d, want %d", tt.input, len(result), len(tt.expected))
 				return
@@ -137,104 +461,465 @@ func TestConsoleImpl_ParseObstacles_Empty(t *testing.T) {
 	}
 }
 
-func TestConsoleImpl_ValidateObstaclesInput(t *testing.T) {
+func TestConsoleImpl_ParseObstacles_Error(t *testing.T) {
 	impl := Provide()
-	
+
+	_, err := impl.parseObstacles("[(1,a)]")
+	expected := game.ValidationErrors{
+		{Code: game.CodeInvalidFormat, Field: "obstacles", Value: "[(1,a)]", Message: "column 5: unexpected character 'a'"},
+	}
+	if !reflect.DeepEqual(err, expected) {
+		t.Errorf("parseObstacles() error = %#v, want %#v", err, expected)
+	}
+}
+
+func TestConsoleImpl_ProcessFlags_Success(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	os.Args = []string{"cmd", "-grid_size=5", "-obstacles=[(1,2)]", "-commands=MMMRM"}
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+
+	impl := Provide()
+	input, err := impl.processFlags()
+
+	if err != nil {
+		t.Errorf("processFlags() error = %v, want nil", err)
+	}
+	if input.Grid.Width != 5 || input.Grid.Height != 5 {
+		t.Errorf("grid = %dx%d, want 5x5", input.Grid.Width, input.Grid.Height)
+	}
+	if input.Commands != "MMMRM" {
+		t.Errorf("commands = %s, want MMMRM", input.Commands)
+	}
+	expectedObstacles := []model.Position{{X: 1, Y: 2}}
+	if !reflect.DeepEqual(input.Obstacles, expectedObstacles) {
+		t.Errorf("obstacles = %v, want %v", input.Obstacles, expectedObstacles)
+	}
+	if input.Start != (mission.Pose{X: 0, Y: 0, Direction: model.North}) {
+		t.Errorf("start = %+v, want (0,0) N", input.Start)
+	}
+	if input.Options.Trace {
+		t.Error("trace should be disabled by default")
+	}
+}
+
+func TestConsoleImpl_ProcessFlags_StartPose(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	os.Args = []string{"cmd", "-grid_size=5", "-commands=M", "-start_x=2", "-start_y=3", "-start_direction=w"}
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+
+	impl := Provide()
+	input, err := impl.processFlags()
+
+	if err != nil {
+		t.Errorf("processFlags() error = %v, want nil", err)
+	}
+	if input.Start != (mission.Pose{X: 2, Y: 3, Direction: model.West}) {
+		t.Errorf("start = %+v, want (2,3) W", input.Start)
+	}
+}
+
+func TestConsoleImpl_ProcessFlags_ObstaclePolicy(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	os.Args = []string{"cmd", "-grid_size=5", "-commands=M", "-obstacle_policy=SKIP_WITH_LIMIT", "-skip_limit=3"}
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+
+	impl := Provide()
+	input, err := impl.processFlags()
+
+	if err != nil {
+		t.Fatalf("processFlags() error = %v, want nil", err)
+	}
+	if input.Options.ObstaclePolicy != "skip_with_limit" || input.Options.SkipLimit != 3 {
+		t.Errorf("options = %+v, want skip_with_limit with limit 3", input.Options)
+	}
+}
+
+func TestConsoleImpl_ProcessFlags_Topology(t *testing.T) {
+	tests := []struct {
+		name     string
+		topology string
+		expected string
+		wantErr  bool
+	}{
+		{name: "Default", expected: ""},
+		{name: "Torus", topology: "-topology=TORUS", expected: "torus"},
+		{name: "Unknown", topology: "-topology=sphere", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			oldArgs := os.Args
+			defer func() { os.Args = oldArgs }()
+
+			os.Args = []string{"cmd", "-grid_size=5", "-commands=M"}
+			if tc.topology != "" {
+				os.Args = append(os.Args, tc.topology)
+			}
+			flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+
+			input, err := Provide().processFlags()
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("processFlags() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if err == nil && input.Options.Topology != tc.expected {
+				t.Errorf("topology = %q, want %q", input.Options.Topology, tc.expected)
+			}
+		})
+	}
+}
+
+func TestConsoleImpl_ProcessFlags_Profile(t *testing.T) {
+	tests := []struct {
+		name     string
+		profile  string
+		expected string
+		wantErr  bool
+	}{
+		{name: "Unlimited", expected: ""},
+		{name: "Built-in", profile: "-profile=scout", expected: "scout"},
+		{name: "Unknown", profile: "-profile=rocket", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			oldArgs := os.Args
+			defer func() { os.Args = oldArgs }()
+
+			os.Args = []string{"cmd", "-grid_size=5", "-commands=M"}
+			if tc.profile != "" {
+				os.Args = append(os.Args, tc.profile)
+			}
+			flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+
+			input, err := Provide().processFlags()
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("processFlags() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if err == nil && input.Options.Profile != tc.expected {
+				t.Errorf("profile = %q, want %q", input.Options.Profile, tc.expected)
+			}
+		})
+	}
+}
+
+func TestConsoleImpl_ProcessFlags_SensorRadius(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	os.Args = []string{"cmd", "-grid_size=5", "-commands=M", "-sensor_radius=2"}
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+
+	input, err := Provide().processFlags()
+	if err != nil {
+		t.Fatalf("processFlags() error = %v", err)
+	}
+	if input.Options.SensorRadius != 2 {
+		t.Errorf("sensor radius = %d, want 2", input.Options.SensorRadius)
+	}
+}
+
+func TestConsoleImpl_ProcessFlags_Interactive(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	// An interactive session needs no commands
+	os.Args = []string{"cmd", "-grid_size=5", "-interactive"}
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+
+	impl := Provide()
+	input, err := impl.processFlags()
+	if err != nil {
+		t.Fatalf("processFlags() error = %v", err)
+	}
+	if !impl.interactive || input.Commands != "" {
+		t.Errorf("processFlags() interactive = %v, commands = %q, want an interactive session without commands", impl.interactive, input.Commands)
+	}
+}
+
+func TestConsoleImpl_ProcessFlags_CommandProgram(t *testing.T) {
+	tests := []struct {
+		name     string
+		commands string
+		expected string
+		wantErr  string
+	}{
+		{name: "Counts and groups", commands: "2M(RM)2", expected: "MMRMRM"},
+		{name: "Syntax error", commands: "MM(X", wantErr: "commands[3]: unexpected character 'X'"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			oldArgs := os.Args
+			defer func() { os.Args = oldArgs }()
+
+			os.Args = []string{"cmd", "-grid_size=5", "-commands=" + tc.commands}
+			flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+
+			input, err := Provide().processFlags()
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("processFlags() error = %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("processFlags() error = %v", err)
+			}
+			if input.Commands != tc.expected {
+				t.Errorf("commands = %q, want %q", input.Commands, tc.expected)
+			}
+		})
+	}
+}
+
+func TestConsoleImpl_ProcessFlags_InvalidObstacles(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	os.Args = []string{"cmd", "-grid_size=5", "-obstacles=invalid", "-commands=MMMRM"}
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+
+	impl := Provide()
+	_, err := impl.processFlags()
+
+	if err == nil {
+		t.Error("processFlags() error = nil, want error for invalid obstacles")
+	}
+}
+
+func TestConsoleImpl_ProcessFlags_RejectedInput(t *testing.T) {
 	tests := []struct {
 		name      string
-		input     string
-		expectErr bool
+		args      []string
+		wantInput Input
+		expected  game.ValidationErrors
 	}{
 		{
-			name:      "valid empty",
-			input:     "[]",
-			expectErr: false,
-		},
-		{
-			name:      "valid with obstacles",
-			input:     "[(1,2),(3,4)]",
-			expectErr: false,
-		},
-		{
-			name:      "too short",
-			input:     "[",
-			expectErr: true,
-		},
-		{
-			name:      "missing opening bracket",
-			input:     "1,2)]",
-			expectErr: true,
-		},
-		{
-			name:      "missing closing bracket",
-			input:     "[(1,2)",
-			expectErr: true,
-		},
-		{
-			name:      "mismatched parentheses - extra open",
-			input:     "[(1,2),((3,4)]",
-			expectErr: true,
+			name:      "Every problem of the input",
+			args:      []string{"-grid=5", "-obstacles=[(9,9),(7,7)]", "-start_direction=Q", "-commands=MXZ"},
+			wantInput: InputCommands,
+			expected: game.ValidationErrors{
+				game.NewIndexedValidationError(game.CodeOutOfBounds, "obstacles", 0, "(9, 9)", "obstacle is outside the 5x5 grid"),
+				game.NewIndexedValidationError(game.CodeOutOfBounds, "obstacles", 1, "(7, 7)", "obstacle is outside the 5x5 grid"),
+				game.NewValidationError(game.CodeInvalidDirection, "direction", "Q", "direction must be N, E, S, W, NE, SE, SW or NW"),
+				game.NewIndexedValidationError(game.CodeInvalidCommand, "commands", 1, "X", "unexpected character 'X'"),
+				game.NewIndexedValidationError(game.CodeInvalidCommand, "commands", 2, "Z", "unexpected character 'Z'"),
+			},
 		},
 		{
-			name:      "mismatched parentheses - extra close",
-			input:     "[(1,2),(3,4))]",
-			expectErr: true,
+			name:      "Malformed obstacles with commands",
+			args:      []string{"-grid=5", "-obstacles=[(1,x)]", "-commands=M # up\n(R"},
+			wantInput: InputObstacles,
+			expected: game.ValidationErrors{
+				game.NewValidationError(game.CodeInvalidFormat, "obstacles", "[(1,x)]", "column 5: unexpected character 'x'"),
+				game.NewIndexedValidationError(game.CodeInvalidCommand, "commands", 7, "(", "( is never closed"),
+			},
 		},
 		{
-			name:      "valid empty with spaces",
-			input:     "[ ]",
-			expectErr: false,
+			name:      "Commands before the start on an obstacle",
+			args:      []string{"-grid=5", "-obstacles=[(0,0)]", "-commands=M?"},
+			wantInput: InputCommands,
+			expected: game.ValidationErrors{
+				game.NewIndexedValidationError(game.CodeInvalidCommand, "commands", 1, "?", "unexpected character '?'"),
+				game.NewValidationError(game.CodeStartOnObstacle, "start", "(0, 0)", "start is on an obstacle"),
+			},
 		},
 	}
 
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			oldArgs := os.Args
+			defer func() { os.Args = oldArgs }()
+
+			os.Args = append([]string{"cmd"}, tc.args...)
+			flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+
+			_, err := Provide().processFlags()
+			var inputErr *InputError
+			if !errors.As(err, &inputErr) || inputErr.Rejected == nil {
+				t.Fatalf("processFlags() error = %v, want an InputError with the rejected result", err)
+			}
+			if inputErr.Input != tc.wantInput {
+				t.Errorf("Input = %q, want %q", inputErr.Input, tc.wantInput)
+			}
+			if inputErr.Rejected.Status != game.StatusInvalidInput || inputErr.Rejected.Grid != (model.Size{Width: 5, Height: 5}) {
+				t.Errorf("Rejected = %+v, want an invalid input on a 5x5 grid", inputErr.Rejected.Result)
+			}
+			if !reflect.DeepEqual(inputErr.Rejected.Errors, tc.expected) {
+				t.Errorf("Errors = %+v, want %+v", inputErr.Rejected.Errors, tc.expected)
+			}
+		})
+	}
+}
+
+func TestConsoleImpl_ProcessFlags_Grid(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	os.Args = []string{"cmd", "-grid=20x5", "-commands=M"}
+	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+
+	impl := Provide()
+	input, err := impl.processFlags()
+
+	if err != nil {
+		t.Errorf("processFlags() error = %v, want nil", err)
+	}
+	if input.Grid.Width != 20 || input.Grid.Height != 5 {
+		t.Errorf("grid = %dx%d, want 20x5", input.Grid.Width, input.Grid.Height)
+	}
+}
+
+func TestConsoleImpl_ParseGrid(t *testing.T) {
+	impl := Provide()
+
+	tests := []struct {
+		name           string
+		input          string
+		expectedWidth  int
+		expectedHeight int
+		expectErr      bool
+	}{
+		{name: "rectangle", input: "20x5", expectedWidth: 20, expectedHeight: 5},
+		{name: "uppercase separator", input: "3X7", expectedWidth: 3, expectedHeight: 7},
+		{name: "with spaces", input: " 4 x 6 ", expectedWidth: 4, expectedHeight: 6},
+		{name: "square shorthand", input: "5", expectedWidth: 5, expectedHeight: 5},
+		{name: "non numeric width", input: "ax5", expectErr: true},
+		{name: "non numeric height", input: "5xb", expectErr: true},
+		{name: "missing height", input: "5x", expectErr: true},
+		{name: "too many dimensions", input: "1x2x3", expectErr: true},
+		{name: "empty", input: "", expectErr: true},
+	}
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := impl.validateObstaclesInput(tt.input)
+			width, height, err := impl.parseGrid(tt.input)
 			if (err != nil) != tt.expectErr {
-				t.Errorf("validateObstaclesInput(%s) error = %v, expectErr %v", tt.input, err, tt.expectErr)
+				t.Fatalf("parseGrid(%s) error = %v, expectErr %v", tt.input, err, tt.expectErr)
+			}
+			if width != tt.expectedWidth || height != tt.expectedHeight {
+				t.Errorf("parseGrid(%s) = %dx%d, want %dx%d", tt.input, width, height, tt.expectedWidth, tt.expectedHeight)
 			}
 		})
 	}
 }
 
-func TestConsoleImpl_ProcessFlags_Success(t *testing.T) {
+func TestConsoleImpl_ProcessFlags_Mission(t *testing.T) {
 	oldArgs := os.Args
 	defer func() { os.Args = oldArgs }()
 
-	os.Args = []string{"cmd", "-grid_size=5", "-obstacles=[(1,2)]", "-commands=MMMRM"}
+	missionPath := filepath.Join(t.TempDir(), "mission.yaml")
+	os.WriteFile(missionPath, []byte(`
+grid: {width: 20, height: 5}
+obstacles: [{x: 1, y: 2}]
+start: {x: 4, y: 1, direction: S}
+commands: MLM
+options: {trace: true}
+`), 0o600)
+
+	os.Args = []string{"cmd", "-mission=" + missionPath}
 	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
 
 	impl := Provide()
-	gridSize, obstacles, commands, err := impl.processFlags()
+	input, err := impl.processFlags()
 
 	if err != nil {
-		t.Errorf("processFlags() error = %v, want nil", err)
-	}
-	if gridSize != 5 {
-		t.Errorf("gridSize = %d, want 5", gridSize)
+		t.Fatalf("processFlags() error = %v, want nil", err)
 	}
-	if commands != "MMMRM" {
-		t.Errorf("commands = %s, want MMMRM", commands)
+	expected := &mission.Mission{
+		Grid:      mission.Grid{Width: 20, Height: 5},
+		Obstacles: []model.Position{{X: 1, Y: 2}},
+		Start:     mission.Pose{X: 4, Y: 1, Direction: model.South},
+		Commands:  "MLM",
+		Options:   mission.Options{Trace: true},
 	}
-	expectedObstacles := []model.Position{{X: 1, Y: 2}}
-	if !reflect.DeepEqual(obstacles, expectedObstacles) {
-		t.Errorf("obstacles = %v, want %v", obstacles, expectedObstacles)
+	if !reflect.DeepEqual(input, expected) {
+		t.Errorf("processFlags() = %+v, want %+v", input, expected)
 	}
 }
 
-func TestConsoleImpl_ProcessFlags_InvalidObstacles(t *testing.T) {
+func TestConsoleImpl_ProcessFlags_FleetMission(t *testing.T) {
 	oldArgs := os.Args
 	defer func() { os.Args = oldArgs }()
 
-	os.Args = []string{"cmd", "-grid_size=5", "-obstacles=invalid", "-commands=MMMRM"}
+	missionPath := filepath.Join(t.TempDir(), "fleet.json")
+	os.WriteFile(missionPath, []byte(`{
+		"grid": {"width": 5, "height": 5},
+		"rovers": [
+			{"id": "a", "start": {"x": 0, "y": 0, "direction": "E"}, "commands": "MM"},
+			{"start": {"x": 4, "y": 4}, "commands": "L"}
+		],
+		"options": {"fleet_mode": "interleaved"}
+	}`), 0o600)
+
+	os.Args = []string{"cmd", "-mission=" + missionPath}
 	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
 
 	impl := Provide()
-	_, _, _, err := impl.processFlags()
+	input, err := impl.processFlags()
 
-	if err == nil {
-		t.Error("processFlags() error = nil, want error for invalid obstacles")
+	if err != nil {
+		t.Fatalf("processFlags() error = %v, want nil", err)
+	}
+	expectedRovers := []mission.Rover{
+		{ID: "a", Start: mission.Pose{X: 0, Y: 0, Direction: model.East}, Commands: "MM"},
+		{ID: "rover-2", Start: mission.Pose{X: 4, Y: 4, Direction: model.North}, Commands: "L"},
+	}
+	if !reflect.DeepEqual(input.Rovers, expectedRovers) {
+		t.Errorf("rovers = %+v, want %+v", input.Rovers, expectedRovers)
+	}
+	if input.Options.FleetMode != "interleaved" {
+		t.Errorf("fleet mode = %s, want interleaved", input.Options.FleetMode)
+	}
+}
+
+func TestConsoleImpl_ProcessFlags_MissionErrors(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	dir := t.TempDir()
+	validPath := filepath.Join(dir, "valid.json")
+	os.WriteFile(validPath, []byte(`{"grid": {"width": 5, "height": 5}, "commands": "M"}`), 0o600)
+	invalidPath := filepath.Join(dir, "invalid.json")
+	os.WriteFile(invalidPath, []byte(`{"grid": {"width": 0, "height": 5}, "commands": "M"}`), 0o600)
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "missing file", args: []string{"-mission=" + filepath.Join(dir, "missing.json")}},
+		{name: "invalid mission", args: []string{"-mission=" + invalidPath}},
+		{name: "combined with input flags", args: []string{"-mission=" + validPath, "-commands=M"}},
 	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Args = append([]string{"cmd"}, tt.args...)
+			flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+
+			impl := Provide()
+			if _, err := impl.processFlags(); err == nil {
+				t.Error("processFlags() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestConsoleImpl_Start_ServeInvalidFlags(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	os.Args = []string{"cmd", "serve", "-max_body_bytes=abc"}
+
+	impl := Provide()
+	impl.Start()
+	// Test passes if the server does not start and no panic occurs
 }
 
 func TestWire(t *testing.T) {
@@ -252,3 +937,356 @@ func TestWire(t *testing.T) {
 		t.Error("Wire() returned object that doesn't implement Console interface")
 	}
 }
+
+func TestConsoleImpl_Start_Plan(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want planner.Plan
+	}{
+		{
+			name: "Path around a wall",
+			args: []string{"cmd", "plan", "-grid=5x5", "-obstacles=[(0,2),(1,2),(2,2)]", "-goal_x=0", "-goal_y=4"},
+			want: planner.Plan{Status: planner.StatusSuccess, Commands: "MRMMMLMMMLMMM", Cost: 13},
+		},
+		{
+			name: "Goal heading",
+			args: []string{"cmd", "plan", "-grid=3", "-goal_x=0", "-goal_y=1", "-goal_direction=s"},
+			want: planner.Plan{Status: planner.StatusSuccess, Commands: "MLL", Cost: 3},
+		},
+		{
+			name: "Unreachable goal",
+			args: []string{"cmd", "plan", "-grid=3", "-obstacles=[(2,2)]", "-goal_x=2", "-goal_y=2"},
+			want: planner.Plan{Status: planner.StatusUnreachable, Reason: "goal (2,2): Obstacle encountered"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			oldArgs := os.Args
+			defer func() { os.Args = oldArgs }()
+			os.Args = tc.args
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			Provide().Start()
+
+			w.Close()
+			os.Stdout = oldStdout
+			var buf bytes.Buffer
+			buf.ReadFrom(r)
+
+			var got planner.Plan
+			if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+				t.Fatalf("Expected a JSON plan, got: %s", buf.String())
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Start() plan = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestConsoleImpl_Plan_InvalidGrid(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "Negative width", args: []string{"-grid=-3x5", "-goal_x=1"}},
+		{name: "Negative square", args: []string{"-grid_size=-3"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Provide().plan(tc.args)
+			var inputErr *InputError
+			if !errors.As(err, &inputErr) || inputErr.Input != InputGrid || !strings.Contains(err.Error(), "must be positive") {
+				t.Errorf("plan() error = %v, want a grid input error", err)
+			}
+		})
+	}
+}
+
+func TestConsoleImpl_Explore(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         []string
+		wantStop     game.ExploreStop
+		wantExplored float64
+		wantText     string
+		wantErr      string
+	}{
+		{name: "Open grid", args: []string{"-grid=4x3"}, wantStop: game.ExploreStopExplored, wantExplored: 1},
+		{name: "Walled in", args: []string{"-grid=5", "-obstacles=[(0..2,2),(2,0..1)]"}, wantStop: game.ExploreStopExplored, wantExplored: 0.32},
+		{name: "Move budget", args: []string{"-grid=20", "-max_moves=5"}, wantStop: game.ExploreStopMoveBudget},
+		{name: "Battery", args: []string{"-grid=50", "-profile=scout"}, wantStop: game.ExploreStopEnergyBudget},
+		{name: "Text output", args: []string{"-grid=5", "-max_moves=2", "-output=text"}, wantText: `(0, 2) N Success on a 5x5 grid, explored 0.28, 2 moves "MM" until move_budget`},
+		{name: "Unknown profile", args: []string{"-grid=5", "-profile=rocket"}, wantErr: `unknown profile "rocket"`},
+		{name: "Unknown output", args: []string{"-grid=5", "-output=xml"}, wantErr: `unsupported output format: "xml"`},
+		{name: "Negative budget", args: []string{"-grid=5", "-max_moves=-1"}, wantErr: string(game.StatusInvalidInput)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			err := Provide().explore(tc.args)
+
+			w.Close()
+			os.Stdout = oldStdout
+			var buf bytes.Buffer
+			buf.ReadFrom(r)
+
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Errorf("explore() error = %v, want %s", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("explore() error = %v", err)
+			}
+
+			if tc.wantText != "" {
+				if got := strings.TrimSpace(buf.String()); got != tc.wantText {
+					t.Errorf("explore() = %s, want %s", got, tc.wantText)
+				}
+				return
+			}
+
+			var got output.Record
+			if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+				t.Fatalf("Expected a JSON result, got: %s", buf.String())
+			}
+			if got.Version != output.SchemaVersion || got.Stop != tc.wantStop || got.Commands == "" || got.Moves == nil {
+				t.Errorf("explore() = %s, want stop %s and commands", buf.String(), tc.wantStop)
+			}
+			if tc.wantExplored != 0 && (got.Explored == nil || *got.Explored != tc.wantExplored) {
+				t.Errorf("explore() explored = %s, want %v", buf.String(), tc.wantExplored)
+			}
+		})
+	}
+}
+
+func TestConsoleImpl_Batch(t *testing.T) {
+	input := filepath.Join(t.TempDir(), "missions.ndjson")
+	missions := `{"id": "ok", "grid": {"width": 5, "height": 5}, "commands": "MMRM"}
+{"id": "lost", "grid": {"width": 5, "height": 5}, "commands": "MMMMMM"}
+`
+	if err := os.WriteFile(input, []byte(missions), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "Default fails on invalid missions only", args: []string{"-input=" + input, "-workers=2"}},
+		{name: "Fails on a status", args: []string{"-input=" + input, "-fail_on=out_of_bounds"}, wantErr: "batch failed on out_of_bounds"},
+		{name: "Unknown order", args: []string{"-input=" + input, "-order=random"}, wantErr: `unknown order "random"`},
+		{name: "Unknown fail condition", args: []string{"-input=" + input, "-fail_on=lost"}, wantErr: `unknown fail condition "lost"`},
+		{name: "Missing input", args: []string{"-input=" + input + ".missing"}, wantErr: "open missions"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			err := Provide().batch(tc.args)
+
+			w.Close()
+			os.Stdout = oldStdout
+			var buf bytes.Buffer
+			buf.ReadFrom(r)
+
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Errorf("batch() error = %v, want %s", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("batch() error = %v", err)
+			}
+
+			lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			want := `{"summary":{"missions":2,"invalid":0,"statuses":{"Out of bounds":1,"Success":1}}}`
+			if len(lines) != 3 || lines[2] != want {
+				t.Errorf("batch() output = %s, want two results and %s", buf.String(), want)
+			}
+		})
+	}
+}
+
+func TestConsoleImpl_Generate(t *testing.T) {
+	dir := t.TempDir()
+	args := []string{"-grid=20x10", "-seed=3", "-density=0.3", "-boulders=2", "-craters=1",
+		"-corridors=[(0,0),(19,9)]", "-obstacles=[(5,5)]", "-start_x=2", "-commands=2M"}
+
+	var generated []*mission.Mission
+	for _, name := range []string{"first.json", "second.yaml"} {
+		path := filepath.Join(dir, name)
+		if err := Provide().generate(append(args, "-out="+path)); err != nil {
+			t.Fatalf("generate() error = %v", err)
+		}
+		m, err := mission.NewLoader().Load(path)
+		if err != nil {
+			t.Fatalf("Load(%s) error = %v", name, err)
+		}
+		generated = append(generated, m)
+	}
+
+	first := generated[0]
+	if !reflect.DeepEqual(first.Obstacles, generated[1].Obstacles) {
+		t.Error("generate() wrote two maps for the same seed")
+	}
+	if first.Grid != (mission.Grid{Width: 20, Height: 10}) || first.Start.X != 2 || first.Commands != "MM" {
+		t.Errorf("generate() mission = %+v", first)
+	}
+	obstacles := map[model.Position]bool{}
+	for _, o := range first.Obstacles {
+		obstacles[o] = true
+	}
+	if !obstacles[model.Position{X: 5, Y: 5}] || obstacles[model.Position{X: 2, Y: 0}] || obstacles[model.Position{X: 19, Y: 9}] {
+		t.Errorf("generate() should keep --obstacles and clear the start and corridor ends, got %v", first.Obstacles)
+	}
+}
+
+// schemaRules are the parts of mission.Schema a written mission file is checked against.
+type schemaRules struct {
+	Required []string `json:"required"`
+	OneOf    []struct {
+		Required []string `json:"required"`
+		Not      struct {
+			Required []string `json:"required"`
+			AnyOf    []struct {
+				Required []string `json:"required"`
+			} `json:"anyOf"`
+		} `json:"not"`
+	} `json:"oneOf"`
+	Properties map[string]struct {
+		MinItems   int `json:"minItems"`
+		Properties map[string]struct {
+			Enum []any `json:"enum"`
+		} `json:"properties"`
+	} `json:"properties"`
+}
+
+// checkMissionSchema reports the fields of a decoded mission file that break the required, oneOf, minItems and
+// option enum rules of mission.Schema, null included.
+func checkMissionSchema(t *testing.T, file map[string]any) {
+	t.Helper()
+	var rules schemaRules
+	if err := json.Unmarshal(mission.Schema, &rules); err != nil {
+		t.Fatalf("mission schema: %v", err)
+	}
+
+	has := func(fields []string) bool {
+		for _, field := range fields {
+			if _, ok := file[field]; !ok {
+				return false
+			}
+		}
+		return true
+	}
+	if !has(rules.Required) {
+		t.Errorf("mission %v misses a field of %v", file, rules.Required)
+	}
+	matched := 0
+	for _, branch := range rules.OneOf {
+		excluded := len(branch.Not.Required) > 0 && has(branch.Not.Required)
+		for _, alternative := range branch.Not.AnyOf {
+			excluded = excluded || has(alternative.Required)
+		}
+		if has(branch.Required) && !excluded {
+			matched++
+		}
+	}
+	if matched != 1 {
+		t.Errorf("mission %v matches %d oneOf branches, want 1", file, matched)
+	}
+
+	for field, value := range file {
+		property, ok := rules.Properties[field]
+		switch {
+		case !ok:
+			t.Errorf("mission field %q is not in the schema", field)
+		case value == nil:
+			t.Errorf("mission field %q is null", field)
+		}
+		if items, isList := value.([]any); isList && len(items) < property.MinItems {
+			t.Errorf("mission field %q has %d items, want at least %d", field, len(items), property.MinItems)
+		}
+	}
+	options, _ := file["options"].(map[string]any)
+	for name, value := range options {
+		enum := rules.Properties["options"].Properties[name].Enum
+		if len(enum) > 0 && !slices.Contains(enum, value) {
+			t.Errorf("option %s = %v, want one of %v", name, value, enum)
+		}
+	}
+}
+
+func TestConsoleImpl_Generate_Schema(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"mission.json", "mission.yaml"} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+			args := []string{"-grid=8x6", "-seed=5", "-density=0.2", "-start_x=1", "-commands=2M", "-out=" + path}
+			if err := Provide().generate(args); err != nil {
+				t.Fatalf("generate() error = %v", err)
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var file map[string]any
+			if filepath.Ext(name) == ".json" {
+				err = json.Unmarshal(data, &file)
+			} else {
+				err = yaml.Unmarshal(data, &file)
+			}
+			if err != nil {
+				t.Fatalf("decode %s: %v", name, err)
+			}
+			checkMissionSchema(t, file)
+		})
+	}
+}
+
+func TestConsoleImpl_Generate_Error(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		wantInput Input
+		wantErr   string
+	}{
+		{name: "Missing commands", args: []string{"-grid=5"}, wantInput: InputCommands, wantErr: "commands are required"},
+		{name: "Odd corridors", args: []string{"-grid=5", "-corridors=[(0,0)]", "-commands=M"}, wantInput: InputOption, wantErr: "1 cells do not pair up"},
+		{name: "Malformed corridors", args: []string{"-grid=5", "-corridors=[(0,0", "-commands=M"}, wantInput: InputOption, wantErr: "corridors: column 6"},
+		{name: "Density", args: []string{"-grid=5", "-density=2", "-commands=M"}, wantInput: InputOption, wantErr: "density must be between 0 and 1"},
+		{name: "Output extension", args: []string{"-grid=5", "-commands=M", "-out=map.txt"}, wantInput: InputOption, wantErr: "unsupported mission file extension"},
+		{name: "Invalid program", args: []string{"-grid=5", "-commands=MX"}, wantInput: InputCommands, wantErr: "unexpected character 'X'"},
+		{name: "Start outside the grid", args: []string{"-grid=6x4", "-start_x=9", "-commands=M"}, wantInput: InputMission, wantErr: "start: must be within the grid"},
+		{name: "Unknown direction", args: []string{"-grid=5", "-start_direction=Q", "-commands=M"}, wantInput: InputMission, wantErr: "start.direction"},
+		{name: "Diagonal on the four compass", args: []string{"-grid=5", "-start_direction=NE", "-commands=M"}, wantInput: InputMission, wantErr: "needs options.compass eight"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Provide().generate(tc.args)
+			var inputErr *InputError
+			if !errors.As(err, &inputErr) || inputErr.Input != tc.wantInput || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("generate() error = %v, want %s input error %q", err, tc.wantInput, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package console
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"mars-rover-navigation/src/modules/game"
+	"mars-rover-navigation/src/modules/planner"
+)
+
+// Exit codes of the process, a rover status and every kind of rejected input has its own.
+const (
+	ExitSuccess = 0
+	// ExitFailure is any other error, e.g. a file that cannot be read or a server that cannot listen
+	ExitFailure = 1
+	// ExitUsage is an unknown flag or a flag value of the wrong type
+	ExitUsage = 2
+
+	ExitObstacleEncountered = 3
+	ExitOutOfBounds         = 4
+	ExitInvalidInput        = 5
+	ExitStartOnObstacle     = 6
+	ExitRoverCollision      = 7
+	ExitBatteryDepleted     = 8
+	ExitImpassableTerrain   = 9
+
+	ExitInvalidGrid      = 10
+	ExitInvalidObstacles = 11
+	ExitInvalidCommands  = 12
+	ExitInvalidMission   = 13
+	ExitInvalidOption    = 14
+
+	// ExitUnreachable is a plan that found no path to its goal
+	ExitUnreachable = 15
+
+	// ExitBatchFailed is a batch that met a --fail_on condition
+	ExitBatchFailed = 20
+)
+
+var planExitCodes = map[planner.Status]int{
+	planner.StatusSuccess:      ExitSuccess,
+	planner.StatusUnreachable:  ExitUnreachable,
+	planner.StatusInvalidInput: ExitInvalidInput,
+}
+
+var statusExitCodes = map[game.Status]int{
+	game.StatusSuccess:             ExitSuccess,
+	game.StatusObstacleEncountered: ExitObstacleEncountered,
+	game.StatusOutOfBounds:         ExitOutOfBounds,
+	game.StatusInvalidInput:        ExitInvalidInput,
+	game.StatusStartOnObstacle:     ExitStartOnObstacle,
+	game.StatusRoverCollision:      ExitRoverCollision,
+	game.StatusBatteryDepleted:     ExitBatteryDepleted,
+	game.StatusImpassableTerrain:   ExitImpassableTerrain,
+}
+
+// Input is the part of the command line an InputError rejects.
+type Input string
+
+const (
+	InputFlags     Input = "flags"
+	InputGrid      Input = "grid"
+	InputObstacles Input = "obstacles"
+	InputCommands  Input = "commands"
+	InputMission   Input = "mission"
+	InputOption    Input = "option"
+)
+
+var inputExitCodes = map[Input]int{
+	InputFlags:     ExitUsage,
+	InputGrid:      ExitInvalidGrid,
+	InputObstacles: ExitInvalidObstacles,
+	InputCommands:  ExitInvalidCommands,
+	InputMission:   ExitInvalidMission,
+	InputOption:    ExitInvalidOption,
+}
+
+// InputError is a command line the console could not turn into a mission.
+type InputError struct {
+	Input Input
+	Err   error
+	// Rejected is set when the input was checked as a whole, the result lists every problem of it
+	Rejected *game.ExtendedResult
+}
+
+func (e *InputError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *InputError) Unwrap() error {
+	return e.Err
+}
+
+// StatusError is a mission whose rover did not succeed, the result is already printed.
+// For a fleet it holds the first rover that did not succeed.
+type StatusError struct {
+	Rover  string
+	Status game.Status
+}
+
+func (e *StatusError) Error() string {
+	if e.Rover != "" {
+		return fmt.Sprintf("rover %s: %s", e.Rover, e.Status)
+	}
+	return string(e.Status)
+}
+
+// PlanError is a plan that did not succeed, the plan is already printed.
+type PlanError struct {
+	Status planner.Status
+}
+
+func (e *PlanError) Error() string {
+	return "plan: " + string(e.Status)
+}
+
+// ErrBatchFailed is returned by a batch that met a --fail_on condition.
+var ErrBatchFailed = errors.New("batch failed")
+
+// ExitCode maps the error returned by Console.Start to the exit code of the process.
+func ExitCode(err error) int {
+	if err == nil || errors.Is(err, flag.ErrHelp) {
+		return ExitSuccess
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		if code, ok := statusExitCodes[statusErr.Status]; ok {
+			return code
+		}
+		return ExitFailure
+	}
+
+	var planErr *PlanError
+	if errors.As(err, &planErr) {
+		if code, ok := planExitCodes[planErr.Status]; ok {
+			return code
+		}
+		return ExitFailure
+	}
+
+	var inputErr *InputError
+	if errors.As(err, &inputErr) {
+		if code, ok := inputExitCodes[inputErr.Input]; ok {
+			return code
+		}
+		return ExitFailure
+	}
+
+	if errors.Is(err, ErrBatchFailed) {
+		return ExitBatchFailed
+	}
+	return ExitFailure
+}
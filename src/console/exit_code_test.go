@@ -0,0 +1,65 @@
+package console
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"mars-rover-navigation/src/modules/game"
+	"mars-rover-navigation/src/modules/planner"
+	"testing"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"No error", nil, ExitSuccess},
+		{"Help", &InputError{Input: InputFlags, Err: flag.ErrHelp}, ExitSuccess},
+		{"Obstacle encountered", &StatusError{Status: game.StatusObstacleEncountered}, ExitObstacleEncountered},
+		{"Fleet rover collision", &StatusError{Rover: "alpha", Status: game.StatusRoverCollision}, ExitRoverCollision},
+		{"Unknown status", &StatusError{Status: "Lost"}, ExitFailure},
+		{"Unreachable plan", &PlanError{Status: planner.StatusUnreachable}, ExitUnreachable},
+		{"Invalid plan", &PlanError{Status: planner.StatusInvalidInput}, ExitInvalidInput},
+		{"Invalid grid", &InputError{Input: InputGrid, Err: errors.New("bad grid")}, ExitInvalidGrid},
+		{"Wrapped invalid mission", fmt.Errorf("load: %w", &InputError{Input: InputMission, Err: errors.New("bad")}), ExitInvalidMission},
+		{"Batch failed", fmt.Errorf("%w on invalid", ErrBatchFailed), ExitBatchFailed},
+		{"Other error", errors.New("disk full"), ExitFailure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := ExitCode(tt.err); code != tt.expected {
+				t.Errorf("ExitCode(%v) = %d, expected %d", tt.err, code, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExitCode_EveryStatus(t *testing.T) {
+	// Every status has its own code, a pipeline can tell them all apart
+	seen := map[int]game.Status{}
+	for _, status := range game.Statuses {
+		code, ok := statusExitCodes[status]
+		if !ok {
+			t.Errorf("status %q has no exit code", status)
+			continue
+		}
+		if other, taken := seen[code]; taken {
+			t.Errorf("statuses %q and %q share exit code %d", status, other, code)
+		}
+		seen[code] = status
+	}
+	for input, code := range inputExitCodes {
+		if status, taken := seen[code]; taken {
+			t.Errorf("input %q shares exit code %d with status %q", input, code, status)
+		}
+		if code == ExitUnreachable {
+			t.Errorf("input %q shares exit code %d with an unreachable plan", input, code)
+		}
+	}
+	if status, taken := seen[ExitUnreachable]; taken {
+		t.Errorf("status %q shares exit code %d with an unreachable plan", status, ExitUnreachable)
+	}
+}
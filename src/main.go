@@ -1,7 +1,11 @@
 package main
 
 import (
+	"errors"
 	"mars-rover-navigation/src/console"
+	"os"
+
+	"github.com/labstack/gommon/log"
 )
 
 func main() {
@@ -9,5 +13,15 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
-	c.Start()
+
+	err = c.Start()
+	if code := console.ExitCode(err); code != console.ExitSuccess {
+		// The result of a rover or a plan that did not succeed is already printed
+		var statusErr *console.StatusError
+		var planErr *console.PlanError
+		if !errors.As(err, &statusErr) && !errors.As(err, &planErr) {
+			log.Error(err)
+		}
+		os.Exit(code)
+	}
 }
@@ -3,11 +3,24 @@ package model
 type Cell struct {
 	Position   Position
 	IsObstacle bool
+	// Terrain is the terrain class of the cell, plain ground when empty
+	Terrain string
 }
 
 type Position struct {
-	X int
-	Y int
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+type Pose struct {
+	X         int       `json:"x"`
+	Y         int       `json:"y"`
+	Direction Direction `json:"direction"`
+}
+
+type Size struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
 }
 
 type Direction string
@@ -17,4 +30,18 @@ const (
 	East  Direction = "E"
 	South Direction = "S"
 	West  Direction = "W"
+
+	// The diagonal headings are only reachable in the eight-direction compass mode
+	NorthEast Direction = "NE"
+	SouthEast Direction = "SE"
+	SouthWest Direction = "SW"
+	NorthWest Direction = "NW"
 )
+
+func (d Direction) IsDiagonal() bool {
+	switch d {
+	case NorthEast, SouthEast, SouthWest, NorthWest:
+		return true
+	}
+	return false
+}
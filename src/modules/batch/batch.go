@@ -0,0 +1,65 @@
+//go:generate go run github.com/golang/mock/mockgen -source=batch.go -destination=./mock/mock_batch.go -package=mock
+
+package batch
+
+import (
+	"context"
+	"io"
+	"mars-rover-navigation/src/modules/game"
+	"mars-rover-navigation/src/modules/mission"
+)
+
+type Runner interface {
+	// Run executes the JSON missions of in, one per line, and writes one JSON result per mission to out
+	Run(ctx context.Context, in io.Reader, out io.Writer) (Summary, error)
+}
+
+// Order is the order Run writes the results in.
+type Order string
+
+const (
+	// OrderInput writes the results in the order of the missions
+	OrderInput Order = "input"
+	// OrderCompleted writes every result as soon as its mission is done
+	OrderCompleted Order = "completed"
+)
+
+type Options struct {
+	// Workers is how many missions run at once, runtime.NumCPU() when zero
+	Workers int
+	// Order of the results, OrderInput when empty
+	Order Order
+}
+
+// Result is the outcome of the mission on Line, one of Rover, Fleet or Error is set.
+type Result struct {
+	Line  int                  `json:"line"`
+	ID    string               `json:"id,omitempty"`
+	Rover *game.ExtendedResult `json:"result,omitempty"`
+	Fleet *game.FleetResult    `json:"fleet,omitempty"`
+	Error *Error               `json:"error,omitempty"`
+}
+
+// Error rejects a mission line, Code is invalid_json or invalid_mission like the HTTP API.
+type Error struct {
+	Code    string               `json:"code"`
+	Message string               `json:"message"`
+	Details []mission.FieldError `json:"details,omitempty"`
+}
+
+// Summary counts the results of a batch, Statuses counts every rover of a fleet.
+type Summary struct {
+	Missions int                 `json:"missions"`
+	Invalid  int                 `json:"invalid"`
+	Statuses map[game.Status]int `json:"statuses"`
+}
+
+// FailCondition is an outcome that fails the whole batch, see ParseFailConditions.
+type FailCondition string
+
+const (
+	// FailOnInvalid fails on a mission line that cannot be parsed or validated
+	FailOnInvalid FailCondition = "invalid"
+	// FailOnAny fails on an invalid mission and on any rover that does not end with game.StatusSuccess
+	FailOnAny FailCondition = "any"
+)
@@ -0,0 +1,250 @@
+package batch
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mars-rover-navigation/src/modules/game"
+	"mars-rover-navigation/src/modules/mission"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// readAhead is how many missions per worker are read before their results are written
+const readAhead = 4
+
+type runnerImpl struct {
+	loader  mission.Loader
+	game    game.Game
+	options Options
+}
+
+func NewRunner(loader mission.Loader, g game.Game, options Options) *runnerImpl {
+	if options.Workers <= 0 {
+		options.Workers = runtime.NumCPU()
+	}
+	if options.Order == "" {
+		options.Order = OrderInput
+	}
+
+	return &runnerImpl{
+		loader:  loader,
+		game:    g,
+		options: options,
+	}
+}
+
+type job struct {
+	index int
+	line  int
+	data  []byte
+}
+
+type indexedResult struct {
+	index int
+	Result
+}
+
+func (r *runnerImpl) Run(ctx context.Context, in io.Reader, out io.Writer) (Summary, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobs := make(chan job)
+	results := make(chan indexedResult)
+	// window holds a slot for every mission read but not written yet, so a slow mission
+	// cannot make OrderInput buffer the rest of the input
+	window := make(chan struct{}, r.options.Workers*readAhead)
+
+	readErr := make(chan error, 1)
+	go func() {
+		defer close(jobs)
+		readErr <- read(ctx, in, jobs, window)
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < r.options.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				results <- indexedResult{index: j.index, Result: r.run(j.line, j.data)}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	summary := Summary{Statuses: map[game.Status]int{}}
+	encoder := json.NewEncoder(out)
+	var writeErr error
+	write := func(result Result) {
+		<-window
+		if writeErr != nil {
+			return
+		}
+		summary.add(result)
+		if err := encoder.Encode(result); err != nil {
+			writeErr = fmt.Errorf("write result: %w", err)
+			cancel()
+		}
+	}
+
+	// pending holds the results that wait for an earlier mission in OrderInput
+	pending := map[int]Result{}
+	next := 0
+	for result := range results {
+		if r.options.Order == OrderCompleted {
+			write(result.Result)
+			continue
+		}
+		pending[result.index] = result.Result
+		for ready, ok := pending[next]; ok; ready, ok = pending[next] {
+			delete(pending, next)
+			write(ready)
+			next++
+		}
+	}
+
+	if writeErr != nil {
+		return summary, writeErr
+	}
+	return summary, <-readErr
+}
+
+// read sends every non-blank line of in as a job, line numbers count the blank lines too.
+func read(ctx context.Context, in io.Reader, jobs chan<- job, window chan struct{}) error {
+	reader := bufio.NewReader(in)
+	index := 0
+	for line := 1; ; line++ {
+		data, err := reader.ReadBytes('\n')
+		if len(bytes.TrimSpace(data)) > 0 {
+			select {
+			case window <- struct{}{}:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			jobs <- job{index: index, line: line, data: data}
+			index++
+		}
+
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read missions: %w", err)
+		}
+	}
+}
+
+func (r *runnerImpl) run(line int, data []byte) Result {
+	result := Result{Line: line}
+
+	m, err := r.loader.Parse(data, mission.FormatJSON)
+	if err != nil {
+		// The id of a rejected mission is still worth reporting when it can be read
+		var named struct {
+			ID string `json:"id"`
+		}
+		_ = json.Unmarshal(data, &named)
+		result.ID = named.ID
+
+		var validationErr *mission.ValidationError
+		if errors.As(err, &validationErr) {
+			result.Error = &Error{Code: "invalid_mission", Message: "mission failed validation", Details: validationErr.Errors}
+		} else {
+			result.Error = &Error{Code: "invalid_json", Message: err.Error()}
+		}
+		return result
+	}
+
+	result.ID = m.ID
+	report := mission.Navigate(r.game, m)
+	result.Rover, result.Fleet = report.Rover, report.Fleet
+	return result
+}
+
+func (s *Summary) add(result Result) {
+	s.Missions++
+	switch {
+	case result.Error != nil:
+		s.Invalid++
+	case result.Fleet != nil:
+		for _, rover := range result.Fleet.Rovers {
+			s.Statuses[rover.Status]++
+		}
+	case result.Rover != nil:
+		s.Statuses[result.Rover.Status]++
+	}
+}
+
+// Failed reports whether the summary meets any of the conditions.
+func (s Summary) Failed(conditions []FailCondition) bool {
+	for _, condition := range conditions {
+		switch condition {
+		case FailOnInvalid:
+			if s.Invalid > 0 {
+				return true
+			}
+		case FailOnAny:
+			if s.Invalid > 0 || s.Statuses[game.StatusSuccess] != s.rovers() {
+				return true
+			}
+		default:
+			for status, count := range s.Statuses {
+				if count > 0 && conditionOf(status) == condition {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (s Summary) rovers() int {
+	total := 0
+	for _, count := range s.Statuses {
+		total += count
+	}
+	return total
+}
+
+// ParseFailConditions reads a comma separated list of invalid, any and game statuses
+// in snake case, e.g. "invalid,obstacle_encountered,out_of_bounds".
+func ParseFailConditions(input string) ([]FailCondition, error) {
+	var conditions []FailCondition
+	for _, name := range strings.Split(input, ",") {
+		condition := FailCondition(strings.ToLower(strings.TrimSpace(name)))
+		if condition == "" {
+			continue
+		}
+		if !isKnownCondition(condition) {
+			return nil, fmt.Errorf("unknown fail condition %q (use invalid, any or a status such as obstacle_encountered)", name)
+		}
+		conditions = append(conditions, condition)
+	}
+	return conditions, nil
+}
+
+func isKnownCondition(condition FailCondition) bool {
+	if condition == FailOnInvalid || condition == FailOnAny {
+		return true
+	}
+	for _, status := range game.Statuses {
+		if conditionOf(status) == condition {
+			return true
+		}
+	}
+	return false
+}
+
+// conditionOf names the condition that fails on status, e.g. "obstacle_encountered".
+func conditionOf(status game.Status) FailCondition {
+	return FailCondition(strings.ReplaceAll(strings.ToLower(string(status)), " ", "_"))
+}
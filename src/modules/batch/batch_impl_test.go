@@ -0,0 +1,202 @@
+package batch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"mars-rover-navigation/src/model"
+	"mars-rover-navigation/src/modules/game"
+	"mars-rover-navigation/src/modules/game/mock"
+	"mars-rover-navigation/src/modules/mission"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func decodeResults(t *testing.T, out string) []Result {
+	t.Helper()
+	var results []Result
+	decoder := json.NewDecoder(strings.NewReader(out))
+	for decoder.More() {
+		var result Result
+		if err := decoder.Decode(&result); err != nil {
+			t.Fatalf("decode result: %v", err)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+func TestRunner_Run(t *testing.T) {
+	input := `{"id": "ok", "grid": {"width": 5, "height": 5}, "obstacles": [{"x": 1, "y": 2}], "commands": "MMRM"}
+
+{"id": "broken", "grid": {"width": 5, "height": 5}, "commands": "MM"
+{"id": "invalid", "grid": {"width": 0, "height": 5}, "commands": "MM"}
+{"grid": {"width": 5, "height": 5}, "rovers": [{"id": "a", "commands": "MMMMMM"}, {"start": {"x": 4, "y": 4}, "commands": "M"}]}
+`
+	runner := NewRunner(mission.NewLoader(), game.NewGame(), Options{Workers: 3})
+	var out bytes.Buffer
+	summary, err := runner.Run(context.Background(), strings.NewReader(input), &out)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	results := decodeResults(t, out.String())
+	if len(results) != 4 {
+		t.Fatalf("Run() wrote %d results, want 4:\n%s", len(results), out.String())
+	}
+
+	tests := []struct {
+		line      int
+		id        string
+		status    game.Status
+		errorCode string
+		fleet     []game.Status
+	}{
+		{line: 1, id: "ok", status: game.StatusObstacleEncountered},
+		{line: 3, errorCode: "invalid_json"},
+		{line: 4, id: "invalid", errorCode: "invalid_mission"},
+		{line: 5, fleet: []game.Status{game.StatusOutOfBounds, game.StatusOutOfBounds}},
+	}
+	for i, tt := range tests {
+		result := results[i]
+		if result.Line != tt.line || result.ID != tt.id {
+			t.Errorf("result %d is line %d id %q, want line %d id %q", i, result.Line, result.ID, tt.line, tt.id)
+		}
+		switch {
+		case tt.errorCode != "":
+			if result.Error == nil || result.Error.Code != tt.errorCode {
+				t.Errorf("line %d error = %+v, want code %s", tt.line, result.Error, tt.errorCode)
+			}
+		case tt.fleet != nil:
+			if result.Fleet == nil || len(result.Fleet.Rovers) != len(tt.fleet) {
+				t.Fatalf("line %d fleet = %+v, want %d rovers", tt.line, result.Fleet, len(tt.fleet))
+			}
+			for j, status := range tt.fleet {
+				if result.Fleet.Rovers[j].Status != status {
+					t.Errorf("line %d rover %d status = %s, want %s", tt.line, j, result.Fleet.Rovers[j].Status, status)
+				}
+			}
+		default:
+			if result.Rover == nil || result.Rover.Status != tt.status {
+				t.Errorf("line %d result = %+v, want status %s", tt.line, result.Rover, tt.status)
+			}
+		}
+	}
+
+	expected := Summary{Missions: 4, Invalid: 2, Statuses: map[game.Status]int{
+		game.StatusObstacleEncountered: 1,
+		game.StatusOutOfBounds:         2,
+	}}
+	if !reflect.DeepEqual(summary, expected) {
+		t.Errorf("Run() summary = %+v, want %+v", summary, expected)
+	}
+}
+
+func TestRunner_Order(t *testing.T) {
+	// The mission on a grid 1 wide is slow, every other mission finishes first
+	ctrl := gomock.NewController(t)
+	mockGame := mock.NewMockGame(ctrl)
+	mockGame.EXPECT().NavigateRoverWithOptions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(width, height int, _ []model.Position, _ model.Position, _ model.Direction, _ string, _ game.Options) game.ExtendedResult {
+			if width == 1 {
+				time.Sleep(50 * time.Millisecond)
+			}
+			return game.ExtendedResult{Result: game.Result{Status: game.StatusSuccess, Grid: model.Size{Width: width, Height: height}}}
+		}).AnyTimes()
+
+	input := `{"id": "slow", "grid": {"width": 1, "height": 1}, "commands": "L"}
+{"id": "fast-1", "grid": {"width": 2, "height": 2}, "commands": "L"}
+{"id": "fast-2", "grid": {"width": 3, "height": 3}, "commands": "L"}
+`
+
+	tests := []struct {
+		order    Order
+		expected []string
+	}{
+		{OrderInput, []string{"slow", "fast-1", "fast-2"}},
+		{OrderCompleted, []string{"fast-1", "fast-2", "slow"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.order), func(t *testing.T) {
+			runner := NewRunner(mission.NewLoader(), mockGame, Options{Workers: 2, Order: tt.order})
+			var out bytes.Buffer
+			if _, err := runner.Run(context.Background(), strings.NewReader(input), &out); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+
+			var ids []string
+			for _, result := range decodeResults(t, out.String()) {
+				ids = append(ids, result.ID)
+			}
+			// The two fast missions may finish in either order
+			if tt.order == OrderCompleted && len(ids) == 3 && ids[0] == "fast-2" {
+				ids[0], ids[1] = ids[1], ids[0]
+			}
+			if !reflect.DeepEqual(ids, tt.expected) {
+				t.Errorf("Run() ids = %v, want %v", ids, tt.expected)
+			}
+		})
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestRunner_WriteError(t *testing.T) {
+	input := strings.Repeat(`{"grid": {"width": 5, "height": 5}, "commands": "M"}`+"\n", 100)
+	runner := NewRunner(mission.NewLoader(), game.NewGame(), Options{Workers: 2})
+
+	_, err := runner.Run(context.Background(), strings.NewReader(input), failingWriter{})
+	if err == nil || !strings.Contains(err.Error(), "disk full") {
+		t.Errorf("Run() error = %v, want the write error", err)
+	}
+}
+
+func TestSummary_Failed(t *testing.T) {
+	summary := Summary{Missions: 3, Invalid: 0, Statuses: map[game.Status]int{
+		game.StatusSuccess:     2,
+		game.StatusOutOfBounds: 1,
+	}}
+
+	tests := []struct {
+		name       string
+		summary    Summary
+		conditions string
+		expected   bool
+	}{
+		{"no condition never fails", summary, "", false},
+		{"invalid without invalid missions", summary, "invalid", false},
+		{"invalid with an invalid mission", Summary{Missions: 1, Invalid: 1, Statuses: map[game.Status]int{}}, "invalid", true},
+		{"any with a failed rover", summary, "any", true},
+		{"any when every rover succeeds", Summary{Missions: 1, Statuses: map[game.Status]int{game.StatusSuccess: 1}}, "any", false},
+		{"matching status", summary, "obstacle_encountered, out_of_bounds", true},
+		{"other status", summary, "battery_depleted", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conditions, err := ParseFailConditions(tt.conditions)
+			if err != nil {
+				t.Fatalf("ParseFailConditions(%q) error = %v", tt.conditions, err)
+			}
+			if result := tt.summary.Failed(conditions); result != tt.expected {
+				t.Errorf("Failed(%q) = %v, expected %v", tt.conditions, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseFailConditions_Unknown(t *testing.T) {
+	if _, err := ParseFailConditions("invalid,crashed"); err == nil || !strings.Contains(err.Error(), `"crashed"`) {
+		t.Errorf("ParseFailConditions() error = %v, want an unknown condition error", err)
+	}
+}
@@ -0,0 +1,36 @@
+//go:generate go run github.com/golang/mock/mockgen -source=command.go -destination=./mock/mock_command.go -package=mock
+
+package command
+
+// Compiler turns a command program into the primitive commands the rover executes.
+//
+// A program is a sequence of:
+//   - primitives: L, R, M, B (backward) and U (U-turn)
+//   - counts: 5M, 3(MR)
+//   - groups with a repeat count: (MMR)4
+//   - macro calls: @zigzag, bodies are programs themselves
+//   - comments from # to the end of the line
+//
+// Whitespace is ignored.
+type Compiler interface {
+	Compile(source string, macros map[string]string) (string, error)
+}
+
+// SyntaxError points at the offending token, Macro is set when it sits in a macro body.
+type SyntaxError struct {
+	Macro   string `json:"macro,omitempty"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Message string `json:"message"`
+}
+
+// SyntaxErrors are the characters of a program that are not part of the language, in source order.
+// errors.As finds the first of them as a *SyntaxError.
+type SyntaxErrors []*SyntaxError
+
+const (
+	// Primitives are the commands a compiled program is made of, A and C are the 45 degree turns
+	Primitives = "LRMBUAC"
+	// DefaultMaxCommands caps the length of a compiled program
+	DefaultMaxCommands = 1 << 20
+)
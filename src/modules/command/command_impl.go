@@ -0,0 +1,224 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type compilerImpl struct {
+	maxCommands int
+}
+
+func NewCompiler() *compilerImpl {
+	return &compilerImpl{
+		maxCommands: DefaultMaxCommands,
+	}
+}
+
+func (c *compilerImpl) Compile(source string, macros map[string]string) (string, error) {
+	names := make([]string, 0, len(macros))
+	for name := range macros {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !IsMacroName(name) {
+			return "", &SyntaxError{Macro: name, Line: 1, Column: 1, Message: "macro names use letters, digits and _ and cannot start with a digit"}
+		}
+	}
+
+	p := &compilation{macros: macros, compiled: map[string]string{}, expanding: map[string]bool{}, maxCommands: c.maxCommands}
+	return p.program(source, "")
+}
+
+// compilation holds the state shared by a program and the macros it calls.
+type compilation struct {
+	macros      map[string]string
+	compiled    map[string]string
+	expanding   map[string]bool
+	maxCommands int
+}
+
+func (p *compilation) program(source, macro string) (string, error) {
+	tokens, err := tokenize(source, macro)
+	if err != nil {
+		return "", err
+	}
+
+	ps := &parser{compilation: p, tokens: tokens, macro: macro}
+	return ps.sequence(nil)
+}
+
+func (p *compilation) expand(name string) (string, bool, error) {
+	if commands, ok := p.compiled[name]; ok {
+		return commands, true, nil
+	}
+	body, ok := p.macros[name]
+	if !ok {
+		return "", false, nil
+	}
+	if p.expanding[name] {
+		return "", true, fmt.Errorf("macro @%s calls itself", name)
+	}
+
+	p.expanding[name] = true
+	commands, err := p.program(body, name)
+	delete(p.expanding, name)
+	if err != nil {
+		return "", true, err
+	}
+
+	p.compiled[name] = commands
+	return commands, true, nil
+}
+
+type parser struct {
+	*compilation
+	tokens []token
+	pos    int
+	macro  string
+}
+
+func (ps *parser) peek() token {
+	return ps.tokens[ps.pos]
+}
+
+func (ps *parser) next() token {
+	t := ps.tokens[ps.pos]
+	if t.kind != tokenEOF {
+		ps.pos++
+	}
+	return t
+}
+
+func (ps *parser) errorAt(t token, format string, args ...interface{}) error {
+	return &SyntaxError{Macro: ps.macro, Line: t.line, Column: t.column, Message: fmt.Sprintf(format, args...)}
+}
+
+// sequence compiles items up to the end of the program or the ) closing open.
+func (ps *parser) sequence(open *token) (string, error) {
+	var sb strings.Builder
+
+	for {
+		t := ps.peek()
+		switch {
+		case t.kind == tokenEOF && open != nil:
+			return "", ps.errorAt(*open, "( is never closed")
+		case t.kind == tokenEOF, t.kind == tokenClose && open != nil:
+			return sb.String(), nil
+		}
+
+		commands, err := ps.item()
+		if err != nil {
+			return "", err
+		}
+		if sb.Len()+len(commands) > ps.maxCommands {
+			return "", ps.errorAt(t, "program expands to more than %d commands", ps.maxCommands)
+		}
+		sb.WriteString(commands)
+	}
+}
+
+// item compiles [count] atom, where a group atom may carry its count after the ).
+func (ps *parser) item() (string, error) {
+	first := ps.peek()
+
+	count, counted := 1, false
+	if first.kind == tokenNumber {
+		n, err := ps.count(ps.next())
+		if err != nil {
+			return "", err
+		}
+		count, counted = n, true
+	}
+
+	atomToken := ps.next()
+	var body string
+	switch atomToken.kind {
+	case tokenPrimitive:
+		body = atomToken.text
+	case tokenOpen:
+		group, err := ps.sequence(&atomToken)
+		if err != nil {
+			return "", err
+		}
+		ps.next()
+		body = group
+
+		if suffix := ps.peek(); suffix.kind == tokenNumber {
+			if counted {
+				return "", ps.errorAt(suffix, "group already has the repeat count %d", count)
+			}
+			n, err := ps.count(ps.next())
+			if err != nil {
+				return "", err
+			}
+			count = n
+		}
+	case tokenMacro:
+		commands, ok, err := ps.expand(atomToken.text)
+		if err != nil {
+			var syntaxErr *SyntaxError
+			if errors.As(err, &syntaxErr) {
+				return "", err
+			}
+			return "", ps.errorAt(atomToken, "%s", err)
+		}
+		if !ok {
+			return "", ps.errorAt(atomToken, "undefined macro @%s", atomToken.text)
+		}
+		body = commands
+	case tokenNumber:
+		return "", ps.errorAt(atomToken, "expected a command, group or macro after the count, got another count")
+	case tokenClose:
+		if !counted {
+			return "", ps.errorAt(atomToken, "unexpected ) without a matching (")
+		}
+		return "", ps.errorAt(atomToken, "expected a command, group or macro after the count, got %s", atomToken)
+	default:
+		return "", ps.errorAt(atomToken, "expected a command, group or macro after the count, got %s", atomToken)
+	}
+
+	if len(body)*count > ps.maxCommands {
+		return "", ps.errorAt(first, "program expands to more than %d commands", ps.maxCommands)
+	}
+	return strings.Repeat(body, count), nil
+}
+
+func (ps *parser) count(t token) (int, error) {
+	n, err := strconv.Atoi(t.text)
+	if err != nil || n > ps.maxCommands {
+		return 0, ps.errorAt(t, "repeat count %s is too large", t.text)
+	}
+	if n == 0 {
+		return 0, ps.errorAt(t, "repeat count must be at least 1")
+	}
+	return n, nil
+}
+
+func (e *SyntaxError) Error() string {
+	position := fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Message)
+	if e.Macro != "" {
+		return "macro @" + e.Macro + ": " + position
+	}
+	return position
+}
+
+func (e SyntaxErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e SyntaxErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, err := range e {
+		errs = append(errs, err)
+	}
+	return errs
+}
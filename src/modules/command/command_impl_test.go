@@ -0,0 +1,219 @@
+package command
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNewCompiler(t *testing.T) {
+	c := NewCompiler()
+	if c == nil {
+		t.Fatal("NewCompiler() returned nil")
+	}
+	if c.maxCommands != DefaultMaxCommands {
+		t.Errorf("maxCommands = %d, want %d", c.maxCommands, DefaultMaxCommands)
+	}
+}
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		macros   map[string]string
+		expected string
+	}{
+		{name: "primitives", source: "LMR", expected: "LMR"},
+		{name: "backward and u-turn", source: "2BU(MB)2", expected: "BBUMBMB"},
+		{name: "empty", source: "", expected: ""},
+		{name: "count", source: "5M", expected: "MMMMM"},
+		{name: "multi digit count", source: "12M", expected: strings.Repeat("M", 12)},
+		{name: "count applies to the next command only", source: "2MR", expected: "MMR"},
+		{name: "group with suffix count", source: "(MMR)4", expected: "MMRMMRMMRMMR"},
+		{name: "group with prefix count", source: "3(ML)", expected: "MLMLML"},
+		{name: "group without count", source: "(MR)M", expected: "MRM"},
+		{name: "nested groups", source: "(2M(R)2)2", expected: "MMRRMMRR"},
+		{name: "empty group", source: "()3M", expected: "M"},
+		{name: "whitespace", source: " 2 M\tR \n L ", expected: "MMRL"},
+		{name: "comments", source: "MM # go north\n# turn\nR", expected: "MMR"},
+		{name: "comment at the end", source: "M#", expected: "M"},
+		{
+			name:     "macro",
+			source:   "@zig 2@zag",
+			macros:   map[string]string{"zig": "MR", "zag": "ML"},
+			expected: "MRMLML",
+		},
+		{
+			name:     "macro calling a macro",
+			source:   "(@square)2",
+			macros:   map[string]string{"side": "3M", "square": "(@side R)4"},
+			expected: strings.Repeat("MMMR", 8),
+		},
+		{
+			name:     "unused macros are not compiled",
+			source:   "M",
+			macros:   map[string]string{"broken": "("},
+			expected: "M",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewCompiler().Compile(tt.source, tt.macros)
+			if err != nil {
+				t.Fatalf("Compile(%q) error = %v", tt.source, err)
+			}
+			if got != tt.expected {
+				t.Errorf("Compile(%q) = %q, want %q", tt.source, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCompile_SyntaxError(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		macros   map[string]string
+		expected SyntaxError
+	}{
+		{
+			name:     "unknown command",
+			source:   "MMX",
+			expected: SyntaxError{Line: 1, Column: 3, Message: `unexpected character 'X'`},
+		},
+		{
+			name:     "lowercase command",
+			source:   "M\n  m",
+			expected: SyntaxError{Line: 2, Column: 3, Message: `unexpected character 'm'`},
+		},
+		{
+			name:     "unclosed group",
+			source:   "M (MR",
+			expected: SyntaxError{Line: 1, Column: 3, Message: "( is never closed"},
+		},
+		{
+			name:     "unmatched close",
+			source:   "MR)",
+			expected: SyntaxError{Line: 1, Column: 3, Message: "unexpected ) without a matching ("},
+		},
+		{
+			name:     "count at the end",
+			source:   "M3",
+			expected: SyntaxError{Line: 1, Column: 3, Message: "expected a command, group or macro after the count, got end of program"},
+		},
+		{
+			name:     "count before a close",
+			source:   "(M2)",
+			expected: SyntaxError{Line: 1, Column: 4, Message: `expected a command, group or macro after the count, got ")"`},
+		},
+		{
+			name:     "zero count",
+			source:   "0M",
+			expected: SyntaxError{Line: 1, Column: 1, Message: "repeat count must be at least 1"},
+		},
+		{
+			name:     "two counts on a group",
+			source:   "2(M)3",
+			expected: SyntaxError{Line: 1, Column: 5, Message: "group already has the repeat count 2"},
+		},
+		{
+			name:     "missing macro name",
+			source:   "M @ M",
+			expected: SyntaxError{Line: 1, Column: 3, Message: "expected a macro name after @"},
+		},
+		{
+			name:     "undefined macro",
+			source:   "# header\nM @nope",
+			expected: SyntaxError{Line: 2, Column: 3, Message: "undefined macro @nope"},
+		},
+		{
+			name:     "error inside a macro",
+			source:   "@bad",
+			macros:   map[string]string{"bad": "MM\n(R"},
+			expected: SyntaxError{Macro: "bad", Line: 2, Column: 1, Message: "( is never closed"},
+		},
+		{
+			name:     "recursive macros",
+			source:   "M @a",
+			macros:   map[string]string{"a": "M @b", "b": "R @a"},
+			expected: SyntaxError{Macro: "b", Line: 1, Column: 3, Message: "macro @a calls itself"},
+		},
+		{
+			name:     "invalid macro name",
+			source:   "M",
+			macros:   map[string]string{"9lives": "M"},
+			expected: SyntaxError{Macro: "9lives", Line: 1, Column: 1, Message: "macro names use letters, digits and _ and cannot start with a digit"},
+		},
+		{
+			name:     "expansion limit",
+			source:   "(1000(1000M))2",
+			expected: SyntaxError{Line: 1, Column: 1, Message: "program expands to more than 1048576 commands"},
+		},
+		{
+			name:     "huge count",
+			source:   "99999999999999999999M",
+			expected: SyntaxError{Line: 1, Column: 1, Message: "repeat count 99999999999999999999 is too large"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewCompiler().Compile(tt.source, tt.macros)
+
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("Compile(%q) error = %v, want *SyntaxError", tt.source, err)
+			}
+			if *syntaxErr != tt.expected {
+				t.Errorf("Compile(%q) error = %+v, want %+v", tt.source, *syntaxErr, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCompile_SyntaxErrors(t *testing.T) {
+	_, err := NewCompiler().Compile("MX 2(M@)\nZ", nil)
+
+	var errs SyntaxErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Compile() error = %v, want SyntaxErrors", err)
+	}
+	expected := []SyntaxError{
+		{Line: 1, Column: 2, Message: `unexpected character 'X'`},
+		{Line: 1, Column: 7, Message: "expected a macro name after @"},
+		{Line: 2, Column: 1, Message: `unexpected character 'Z'`},
+	}
+	if len(errs) != len(expected) {
+		t.Fatalf("Compile() error = %v, want %d errors", err, len(expected))
+	}
+	for i := range expected {
+		if *errs[i] != expected[i] {
+			t.Errorf("error %d = %+v, want %+v", i, *errs[i], expected[i])
+		}
+	}
+	if got := err.Error(); got != "line 1, column 2: unexpected character 'X'; line 1, column 7: expected a macro name after @; "+
+		"line 2, column 1: unexpected character 'Z'" {
+		t.Errorf("Error() = %q", got)
+	}
+}
+
+func TestSyntaxError_Error(t *testing.T) {
+	err := &SyntaxError{Line: 2, Column: 5, Message: "unexpected character 'X'"}
+	if got := err.Error(); got != "line 2, column 5: unexpected character 'X'" {
+		t.Errorf("Error() = %q", got)
+	}
+
+	err.Macro = "zig"
+	if got := err.Error(); got != "macro @zig: line 2, column 5: unexpected character 'X'" {
+		t.Errorf("Error() = %q", got)
+	}
+}
+
+func TestIsMacroName(t *testing.T) {
+	for name, expected := range map[string]bool{"zig": true, "_a1": true, "Square_2": true, "": false, "1a": false, "a-b": false} {
+		if got := IsMacroName(name); got != expected {
+			t.Errorf("IsMacroName(%q) = %v, want %v", name, got, expected)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPrimitive
+	tokenNumber
+	tokenOpen
+	tokenClose
+	tokenMacro
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	line   int
+	column int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of program"
+	case tokenMacro:
+		return "@" + t.text
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// tokenize splits a program into tokens, skipping whitespace and comments. It reads past a character it cannot
+// tokenize, so SyntaxErrors lists every one of the program.
+func tokenize(source, macro string) ([]token, error) {
+	var tokens []token
+	var errs SyntaxErrors
+	runes := []rune(source)
+	line, column := 1, 1
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		start := token{line: line, column: column}
+
+		switch {
+		case r == '\n':
+			line, column = line+1, 1
+			i++
+			continue
+		case r == ' ' || r == '\t' || r == '\r':
+		case r == '#':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+				column++
+			}
+			continue
+		case strings.ContainsRune(Primitives, r):
+			start.kind, start.text = tokenPrimitive, string(r)
+		case r == '(':
+			start.kind, start.text = tokenOpen, "("
+		case r == ')':
+			start.kind, start.text = tokenClose, ")"
+		case r >= '0' && r <= '9':
+			end := i
+			for end < len(runes) && runes[end] >= '0' && runes[end] <= '9' {
+				end++
+			}
+			start.kind, start.text = tokenNumber, string(runes[i:end])
+			column += end - i
+			i = end
+			tokens = append(tokens, start)
+			continue
+		case r == '@':
+			end := i + 1
+			for end < len(runes) && isNameRune(runes[end], end == i+1) {
+				end++
+			}
+			if end == i+1 {
+				errs = append(errs, &SyntaxError{Macro: macro, Line: line, Column: column, Message: "expected a macro name after @"})
+				i++
+				column++
+				continue
+			}
+			start.kind, start.text = tokenMacro, string(runes[i+1:end])
+			column += end - i
+			i = end
+			tokens = append(tokens, start)
+			continue
+		default:
+			errs = append(errs, &SyntaxError{Macro: macro, Line: line, Column: column, Message: fmt.Sprintf("unexpected character %q", r)})
+			i++
+			column++
+			continue
+		}
+
+		if start.kind != tokenEOF {
+			tokens = append(tokens, start)
+		}
+		i++
+		column++
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return append(tokens, token{kind: tokenEOF, line: line, column: column}), nil
+}
+
+// isNameRune accepts letters, digits and _ in macro names, a digit cannot start one.
+func isNameRune(r rune, first bool) bool {
+	switch {
+	case r == '_', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		return true
+	case r >= '0' && r <= '9':
+		return !first
+	}
+	return false
+}
+
+// IsMacroName reports whether name can be called as @name.
+func IsMacroName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		if !isNameRune(r, i == 0) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: command.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCompiler is a mock of Compiler interface.
+type MockCompiler struct {
+	ctrl     *gomock.Controller
+	recorder *MockCompilerMockRecorder
+}
+
+// MockCompilerMockRecorder is the mock recorder for MockCompiler.
+type MockCompilerMockRecorder struct {
+	mock *MockCompiler
+}
+
+// NewMockCompiler creates a new mock instance.
+func NewMockCompiler(ctrl *gomock.Controller) *MockCompiler {
+	mock := &MockCompiler{ctrl: ctrl}
+	mock.recorder = &MockCompilerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCompiler) EXPECT() *MockCompilerMockRecorder {
+	return m.recorder
+}
+
+// Compile mocks base method.
+func (m *MockCompiler) Compile(source string, macros map[string]string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Compile", source, macros)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Compile indicates an expected call of Compile.
+func (mr *MockCompilerMockRecorder) Compile(source, macros interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Compile", reflect.TypeOf((*MockCompiler)(nil).Compile), source, macros)
+}
@@ -5,8 +5,47 @@ package environment
 import "mars-rover-navigation/src/model"
 
 type Environment interface {
+	// GetGrid materializes every cell, prefer Size and Cell on large grids
 	GetGrid() [][]model.Cell
+	Size() model.Size
+	// Cell describes one cell, a position off the grid is an empty cell
+	Cell(position model.Position) model.Cell
 	CanMove(actorPosition model.Position) CanMoveStatus
+	// CanMoveFrom is CanMove for a single step, a diagonal step may also be blocked by the cells at its corners
+	CanMoveFrom(from, to model.Position) CanMoveStatus
+	// SetDynamicObstacles replaces the cells occupied by other actors, e.g. rovers of a fleet
+	SetDynamicObstacles(positions []model.Position)
+	// SetTopology changes how the grid edges connect, Bounded by default
+	SetTopology(topology Topology)
+	// SetCornerCutting lets diagonal steps pass between two obstacles touching their corners, off by default
+	SetCornerCutting(allowed bool)
+	// Normalize maps a position onto the grid, ok is false when it falls off a hard edge
+	Normalize(position model.Position) (model.Position, bool)
+	// Distance is the fewest moves between two cells ignoring obstacles
+	Distance(from, to model.Position) int
+	// SetTerrain names the terrain class of cells, the rest is plain ground ("")
+	SetTerrain(terrain map[model.Position]string)
+	// SetTerrainClasses replaces the built-in TerrainClasses
+	SetTerrainClasses(classes map[string]TerrainClass)
+	// SetRoverType selects whose passability rules CanMove applies, like SetDynamicObstacles for the rover about to move
+	SetRoverType(roverType string)
+	TerrainAt(position model.Position) string
+	// TerrainCost is the cost multiplier of moving onto position, 1 for plain ground
+	TerrainCost(position model.Position) float64
+}
+
+// Topology decides how the edges of a grid connect.
+type Topology interface {
+	Normalize(position model.Position, size model.Size) (model.Position, bool)
+	Distance(from, to model.Position, size model.Size) int
+}
+
+// TerrainClass is a kind of ground that costs more to cross, or cannot be crossed by some rovers.
+type TerrainClass struct {
+	// Cost multiplies the cost of moving onto the terrain, plain ground costs 1
+	Cost float64
+	// ImpassableFor lists the rover types that cannot enter the terrain
+	ImpassableFor []string
 }
 
 type CanMoveStatus string
@@ -15,4 +54,6 @@ const (
 	Success             CanMoveStatus = "Success"
 	ObstacleEncountered CanMoveStatus = "Obstacle encountered"
 	OutOfBounds         CanMoveStatus = "Out of bounds"
+	RoverCollision      CanMoveStatus = "Rover collision"
+	ImpassableTerrain   CanMoveStatus = "Impassable terrain"
 )
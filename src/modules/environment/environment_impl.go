@@ -5,28 +5,51 @@ import (
 )
 
 type environmentImpl struct {
-	Size      int
+	Width     int
+	Height    int
 	Obstacles []model.Position
 	Grid      [][]model.Cell
+
+	DynamicObstacles []model.Position
+	Topology         Topology
+	CornerCutting    bool
+	TerrainClasses   map[string]TerrainClass
+	RoverType        string
 }
 
-func NewEnvironment(size int, obstacles []model.Position) *environmentImpl {
+// DenseCellLimit is the largest grid area New stores as a dense grid, larger grids are sparse.
+const DenseCellLimit = 1 << 20
+
+// New picks the dense environment for grids up to DenseCellLimit cells and the sparse one above.
+func New(width, height int, obstacles []model.Position) Environment {
+	if width > 0 && height > 0 && width > DenseCellLimit/height {
+		return NewSparseEnvironment(width, height, obstacles)
+	}
+	return NewEnvironment(width, height, obstacles)
+}
+
+// NewEnvironment builds a width x height grid, indexed as Grid[x][y].
+func NewEnvironment(width, height int, obstacles []model.Position) *environmentImpl {
 	instance := &environmentImpl{
-		Size:      size,
+		Width:     width,
+		Height:    height,
 		Obstacles: obstacles,
-		Grid:      make([][]model.Cell, size),
+		Grid:      make([][]model.Cell, width),
+		Topology:  Bounded,
+
+		TerrainClasses: TerrainClasses,
 	}
 
 	for i := range instance.Grid {
-		instance.Grid[i] = make([]model.Cell, size)
+		instance.Grid[i] = make([]model.Cell, height)
 		for j := range instance.Grid[i] {
-			isObstacle := isMatchObstacles(
-				model.Position{
-					X: i,
-					Y: j,
-				}, obstacles,
-			)
-			instance.Grid[i][j] = model.Cell{Position: model.Position{X: i, Y: j}, IsObstacle: isObstacle}
+			instance.Grid[i][j] = model.Cell{Position: model.Position{X: i, Y: j}}
+		}
+	}
+	// Obstacles are marked once each instead of searched for every cell
+	for _, obstacle := range obstacles {
+		if isWithinGrid(obstacle, width, height) {
+			instance.Grid[obstacle.X][obstacle.Y].IsObstacle = true
 		}
 	}
 	return instance
@@ -36,8 +59,20 @@ func (e *environmentImpl) GetGrid() [][]model.Cell {
 	return e.Grid
 }
 
+func (e *environmentImpl) Size() model.Size {
+	return model.Size{Width: e.Width, Height: e.Height}
+}
+
+func (e *environmentImpl) Cell(position model.Position) model.Cell {
+	if !isWithinGrid(position, e.Width, e.Height) {
+		return model.Cell{Position: position}
+	}
+	return e.Grid[position.X][position.Y]
+}
+
 func (e *environmentImpl) CanMove(actorPosition model.Position) CanMoveStatus {
-	if actorPosition.X < 0 || actorPosition.X >= e.Size || actorPosition.Y < 0 || actorPosition.Y >= e.Size {
+	actorPosition, ok := e.Normalize(actorPosition)
+	if !ok {
 		return OutOfBounds
 	}
 
@@ -47,9 +82,92 @@ func (e *environmentImpl) CanMove(actorPosition model.Position) CanMoveStatus {
 		return ObstacleEncountered
 	}
 
+	if isImpassable(e.TerrainClasses, e.RoverType, newRoverGrid.Terrain) {
+		return ImpassableTerrain
+	}
+
+	if isMatchObstacles(actorPosition, e.DynamicObstacles) {
+		return RoverCollision
+	}
+
 	return Success
 }
 
+func (e *environmentImpl) CanMoveFrom(from, to model.Position) CanMoveStatus {
+	status := e.CanMove(to)
+	if status != Success || e.CornerCutting || from.X == to.X || from.Y == to.Y {
+		return status
+	}
+
+	return cornerStatus(from, to, e.isObstacle)
+}
+
+func (e *environmentImpl) SetDynamicObstacles(positions []model.Position) {
+	e.DynamicObstacles = positions
+}
+
+func (e *environmentImpl) SetTopology(topology Topology) {
+	e.Topology = topology
+}
+
+func (e *environmentImpl) SetCornerCutting(allowed bool) {
+	e.CornerCutting = allowed
+}
+
+func (e *environmentImpl) SetTerrain(terrain map[model.Position]string) {
+	for position, class := range terrain {
+		if isWithinGrid(position, e.Width, e.Height) {
+			e.Grid[position.X][position.Y].Terrain = class
+		}
+	}
+}
+
+func (e *environmentImpl) SetTerrainClasses(classes map[string]TerrainClass) {
+	e.TerrainClasses = classes
+}
+
+func (e *environmentImpl) SetRoverType(roverType string) {
+	e.RoverType = roverType
+}
+
+func (e *environmentImpl) TerrainAt(position model.Position) string {
+	position, ok := e.Normalize(position)
+	if !ok {
+		return ""
+	}
+	return e.Grid[position.X][position.Y].Terrain
+}
+
+func (e *environmentImpl) TerrainCost(position model.Position) float64 {
+	return terrainCost(e.TerrainClasses, e.TerrainAt(position))
+}
+
+func (e *environmentImpl) Normalize(position model.Position) (model.Position, bool) {
+	return e.Topology.Normalize(position, model.Size{Width: e.Width, Height: e.Height})
+}
+
+func (e *environmentImpl) Distance(from, to model.Position) int {
+	return e.Topology.Distance(from, to, model.Size{Width: e.Width, Height: e.Height})
+}
+
+// isObstacle reports a static obstacle, positions off a hard edge are not obstacles.
+func (e *environmentImpl) isObstacle(position model.Position) bool {
+	position, ok := e.Normalize(position)
+	return ok && e.Grid[position.X][position.Y].IsObstacle
+}
+
+// cornerStatus blocks a diagonal step that would squeeze between the two orthogonal cells it passes.
+func cornerStatus(from, to model.Position, isObstacle func(model.Position) bool) CanMoveStatus {
+	if isObstacle(model.Position{X: to.X, Y: from.Y}) && isObstacle(model.Position{X: from.X, Y: to.Y}) {
+		return ObstacleEncountered
+	}
+	return Success
+}
+
+func isWithinGrid(position model.Position, width, height int) bool {
+	return position.X >= 0 && position.X < width && position.Y >= 0 && position.Y < height
+}
+
 func isMatchObstacles(position model.Position, obstacles []model.Position) bool {
 	for _, o := range obstacles {
 		if position.X == o.X && position.Y == o.Y {
@@ -192,7 +192,7 @@ func TestCanMove(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			env := NewEnvironment(tt.size, tt.obstacles)
+			env := NewEnvironment(tt.size, tt.size, tt.obstacles)
 			result := env.CanMove(tt.actorPosition)
 			if result != tt.expected {
 				t.Errorf("CanMove(%+v) = %v, expected %v",
@@ -241,7 +241,7 @@ func TestGetGrid(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			env := NewEnvironment(tt.size, tt.obstacles)
+			env := NewEnvironment(tt.size, tt.size, tt.obstacles)
 			grid := env.GetGrid()
 
 			// Test grid dimensions
@@ -292,3 +292,272 @@ func isPositionInObstacles(position model.Position, obstacles []model.Position)
 	}
 	return false
 }
+
+func TestCanMove_Rectangular(t *testing.T) {
+	tests := []struct {
+		name          string
+		width         int
+		height        int
+		obstacles     []model.Position
+		actorPosition model.Position
+		expected      CanMoveStatus
+	}{
+		{
+			name:          "wide grid - can move to far east cell",
+			width:         20,
+			height:        5,
+			obstacles:     []model.Position{},
+			actorPosition: model.Position{X: 19, Y: 4},
+			expected:      Success,
+		},
+		{
+			name:          "wide grid - Y beyond height",
+			width:         20,
+			height:        5,
+			obstacles:     []model.Position{},
+			actorPosition: model.Position{X: 10, Y: 5},
+			expected:      OutOfBounds,
+		},
+		{
+			name:          "wide grid - X beyond width",
+			width:         20,
+			height:        5,
+			obstacles:     []model.Position{},
+			actorPosition: model.Position{X: 20, Y: 0},
+			expected:      OutOfBounds,
+		},
+		{
+			name:          "tall grid - can move to far north cell",
+			width:         2,
+			height:        10,
+			obstacles:     []model.Position{},
+			actorPosition: model.Position{X: 1, Y: 9},
+			expected:      Success,
+		},
+		{
+			name:          "tall grid - X beyond width",
+			width:         2,
+			height:        10,
+			obstacles:     []model.Position{},
+			actorPosition: model.Position{X: 2, Y: 3},
+			expected:      OutOfBounds,
+		},
+		{
+			name:          "tall grid - obstacle",
+			width:         2,
+			height:        10,
+			obstacles:     []model.Position{{X: 1, Y: 7}},
+			actorPosition: model.Position{X: 1, Y: 7},
+			expected:      ObstacleEncountered,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := NewEnvironment(tt.width, tt.height, tt.obstacles)
+			result := env.CanMove(tt.actorPosition)
+			if result != tt.expected {
+				t.Errorf("CanMove(%+v) = %v, expected %v",
+					tt.actorPosition, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetGrid_Rectangular(t *testing.T) {
+	env := NewEnvironment(4, 2, []model.Position{{X: 3, Y: 1}})
+	grid := env.GetGrid()
+
+	if len(grid) != 4 {
+		t.Fatalf("GetGrid() returned grid with %d columns, expected 4", len(grid))
+	}
+	for x, column := range grid {
+		if len(column) != 2 {
+			t.Errorf("GetGrid() column %d has %d cells, expected 2", x, len(column))
+		}
+	}
+	if !grid[3][1].IsObstacle {
+		t.Error("GetGrid() cell at [3][1] should be an obstacle")
+	}
+}
+
+func TestCanMove_DynamicObstacles(t *testing.T) {
+	env := NewEnvironment(5, 5, []model.Position{{X: 1, Y: 1}})
+	env.SetDynamicObstacles([]model.Position{{X: 2, Y: 2}, {X: 1, Y: 1}})
+
+	tests := []struct {
+		name          string
+		actorPosition model.Position
+		expected      CanMoveStatus
+	}{
+		{"cell occupied by another rover", model.Position{X: 2, Y: 2}, RoverCollision},
+		{"static obstacle wins over rover", model.Position{X: 1, Y: 1}, ObstacleEncountered},
+		{"free cell", model.Position{X: 3, Y: 3}, Success},
+		{"out of bounds", model.Position{X: 5, Y: 0}, OutOfBounds},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := env.CanMove(tt.actorPosition)
+			if result != tt.expected {
+				t.Errorf("CanMove(%+v) = %v, expected %v", tt.actorPosition, result, tt.expected)
+			}
+		})
+	}
+
+	// Replacing the dynamic obstacles frees the previous cells
+	env.SetDynamicObstacles(nil)
+	if result := env.CanMove(model.Position{X: 2, Y: 2}); result != Success {
+		t.Errorf("CanMove() after clearing dynamic obstacles = %v, expected %v", result, Success)
+	}
+}
+
+func TestCanMove_Topology(t *testing.T) {
+	tests := []struct {
+		name          string
+		topology      Topology
+		actorPosition model.Position
+		expected      CanMoveStatus
+	}{
+		{"bounded west edge", Bounded, model.Position{X: -1, Y: 1}, OutOfBounds},
+		{"wrap_x west edge", WrapX, model.Position{X: -1, Y: 1}, Success},
+		{"wrap_x east edge onto obstacle", WrapX, model.Position{X: 5, Y: 2}, ObstacleEncountered},
+		{"wrap_x north edge", WrapX, model.Position{X: 1, Y: 3}, OutOfBounds},
+		{"wrap_y north edge", WrapY, model.Position{X: 1, Y: 3}, Success},
+		{"wrap_y east edge", WrapY, model.Position{X: 5, Y: 1}, OutOfBounds},
+		{"torus corner", Torus, model.Position{X: 6, Y: -1}, Success},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := NewEnvironment(5, 3, []model.Position{{X: 0, Y: 2}})
+			env.SetTopology(tt.topology)
+
+			result := env.CanMove(tt.actorPosition)
+			if result != tt.expected {
+				t.Errorf("CanMove(%+v) = %v, expected %v", tt.actorPosition, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNewEnvironment_DefaultTopology(t *testing.T) {
+	env := NewEnvironment(3, 3, nil)
+	if env.Topology != Bounded {
+		t.Errorf("Expected the bounded topology by default, got %+v", env.Topology)
+	}
+	if position, ok := env.Normalize(model.Position{X: 3, Y: 0}); ok {
+		t.Errorf("Normalize() = %+v, expected the position to fall off the grid", position)
+	}
+}
+
+func TestCanMoveFrom_CornerCutting(t *testing.T) {
+	// Obstacles at (1,2) and (2,1) touch the corners of the step (1,1) -> (2,2),
+	// (0,3) and (3,2) those of the step (0,2) -> (3,3) across the torus corner
+	obstacles := []model.Position{{X: 1, Y: 2}, {X: 2, Y: 1}, {X: 0, Y: 3}, {X: 3, Y: 2}}
+
+	tests := []struct {
+		name          string
+		cornerCutting bool
+		topology      Topology
+		from          model.Position
+		to            model.Position
+		expected      CanMoveStatus
+	}{
+		{"orthogonal step is a plain CanMove", false, Bounded, model.Position{X: 1, Y: 1}, model.Position{X: 1, Y: 2}, ObstacleEncountered},
+		{"diagonal squeeze blocked", false, Bounded, model.Position{X: 1, Y: 1}, model.Position{X: 2, Y: 2}, ObstacleEncountered},
+		{"diagonal squeeze allowed", true, Bounded, model.Position{X: 1, Y: 1}, model.Position{X: 2, Y: 2}, Success},
+		{"diagonal past a single obstacle", false, Bounded, model.Position{X: 1, Y: 1}, model.Position{X: 0, Y: 2}, Success},
+		{"diagonal onto an obstacle", true, Bounded, model.Position{X: 1, Y: 2}, model.Position{X: 0, Y: 3}, ObstacleEncountered},
+		{"diagonal off the grid", false, Bounded, model.Position{X: 0, Y: 0}, model.Position{X: -1, Y: 1}, OutOfBounds},
+		{"diagonal squeeze across a wrapped edge", false, Torus, model.Position{X: 0, Y: 2}, model.Position{X: -1, Y: 3}, ObstacleEncountered},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := NewEnvironment(4, 4, obstacles)
+			env.SetTopology(tt.topology)
+			env.SetCornerCutting(tt.cornerCutting)
+
+			result := env.CanMoveFrom(tt.from, tt.to)
+			if result != tt.expected {
+				t.Errorf("CanMoveFrom(%+v, %+v) = %v, expected %v", tt.from, tt.to, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTerrainAt(t *testing.T) {
+	env := NewEnvironment(3, 3, nil)
+	if terrain := env.TerrainAt(model.Position{X: 1, Y: 1}); terrain != "" {
+		t.Errorf("TerrainAt() without terrain = %q, expected plain ground", terrain)
+	}
+
+	env.SetTerrain(map[model.Position]string{{X: 1, Y: 1}: "sand"})
+
+	if terrain := env.TerrainAt(model.Position{X: 1, Y: 1}); terrain != "sand" {
+		t.Errorf("TerrainAt() = %q, expected sand", terrain)
+	}
+	if terrain := env.TerrainAt(model.Position{X: 0, Y: 1}); terrain != "" {
+		t.Errorf("TerrainAt() = %q, expected plain ground", terrain)
+	}
+}
+
+func TestCanMove_Terrain(t *testing.T) {
+	tests := []struct {
+		name      string
+		roverType string
+		terrain   string
+		expected  CanMoveStatus
+	}{
+		{"no rover type passes everything", "", "crater_rim", Success},
+		{"plain ground", "heavy", "", Success},
+		{"passable class", "heavy", "rock", Success},
+		{"impassable class", "heavy", "sand", ImpassableTerrain},
+		{"other rover type", "scout", "crater_rim", Success},
+		{"unknown class", "heavy", "gravel", Success},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := NewEnvironment(3, 3, nil)
+			env.SetTerrain(map[model.Position]string{{X: 1, Y: 1}: tt.terrain})
+			env.SetRoverType(tt.roverType)
+
+			if result := env.CanMove(model.Position{X: 1, Y: 1}); result != tt.expected {
+				t.Errorf("CanMove() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTerrainCost(t *testing.T) {
+	env := NewEnvironment(3, 3, nil)
+	env.SetTerrain(map[model.Position]string{{X: 0, Y: 0}: "sand", {X: 1, Y: 0}: "gravel", {X: 5, Y: 5}: "rock"})
+
+	tests := []struct {
+		position model.Position
+		expected float64
+	}{
+		{model.Position{X: 0, Y: 0}, 2},
+		{model.Position{X: 1, Y: 0}, 1},
+		{model.Position{X: 2, Y: 2}, 1},
+		{model.Position{X: 5, Y: 5}, 1},
+	}
+	for _, tt := range tests {
+		if cost := env.TerrainCost(tt.position); cost != tt.expected {
+			t.Errorf("TerrainCost(%+v) = %v, expected %v", tt.position, cost, tt.expected)
+		}
+	}
+
+	env.SetTerrainClasses(map[string]TerrainClass{"gravel": {Cost: 1.25}})
+	if cost := env.TerrainCost(model.Position{X: 1, Y: 0}); cost != 1.25 {
+		t.Errorf("TerrainCost() with custom classes = %v, expected 1.25", cost)
+	}
+	if cost := env.TerrainCost(model.Position{X: 0, Y: 0}); cost != 1 {
+		t.Errorf("TerrainCost() of a replaced class = %v, expected 1", cost)
+	}
+	if terrain := env.GetGrid()[0][0].Terrain; terrain != "sand" {
+		t.Errorf("Grid cell terrain = %q, expected sand", terrain)
+	}
+}
@@ -49,6 +49,48 @@ func (mr *MockEnvironmentMockRecorder) CanMove(actorPosition interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanMove", reflect.TypeOf((*MockEnvironment)(nil).CanMove), actorPosition)
 }
 
+// CanMoveFrom mocks base method.
+func (m *MockEnvironment) CanMoveFrom(from, to model.Position) environment.CanMoveStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CanMoveFrom", from, to)
+	ret0, _ := ret[0].(environment.CanMoveStatus)
+	return ret0
+}
+
+// CanMoveFrom indicates an expected call of CanMoveFrom.
+func (mr *MockEnvironmentMockRecorder) CanMoveFrom(from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanMoveFrom", reflect.TypeOf((*MockEnvironment)(nil).CanMoveFrom), from, to)
+}
+
+// Cell mocks base method.
+func (m *MockEnvironment) Cell(position model.Position) model.Cell {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cell", position)
+	ret0, _ := ret[0].(model.Cell)
+	return ret0
+}
+
+// Cell indicates an expected call of Cell.
+func (mr *MockEnvironmentMockRecorder) Cell(position interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cell", reflect.TypeOf((*MockEnvironment)(nil).Cell), position)
+}
+
+// Distance mocks base method.
+func (m *MockEnvironment) Distance(from, to model.Position) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Distance", from, to)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Distance indicates an expected call of Distance.
+func (mr *MockEnvironmentMockRecorder) Distance(from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Distance", reflect.TypeOf((*MockEnvironment)(nil).Distance), from, to)
+}
+
 // GetGrid mocks base method.
 func (m *MockEnvironment) GetGrid() [][]model.Cell {
 	m.ctrl.T.Helper()
@@ -62,3 +104,184 @@ func (mr *MockEnvironmentMockRecorder) GetGrid() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGrid", reflect.TypeOf((*MockEnvironment)(nil).GetGrid))
 }
+
+// Normalize mocks base method.
+func (m *MockEnvironment) Normalize(position model.Position) (model.Position, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Normalize", position)
+	ret0, _ := ret[0].(model.Position)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Normalize indicates an expected call of Normalize.
+func (mr *MockEnvironmentMockRecorder) Normalize(position interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Normalize", reflect.TypeOf((*MockEnvironment)(nil).Normalize), position)
+}
+
+// SetCornerCutting mocks base method.
+func (m *MockEnvironment) SetCornerCutting(allowed bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetCornerCutting", allowed)
+}
+
+// SetCornerCutting indicates an expected call of SetCornerCutting.
+func (mr *MockEnvironmentMockRecorder) SetCornerCutting(allowed interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCornerCutting", reflect.TypeOf((*MockEnvironment)(nil).SetCornerCutting), allowed)
+}
+
+// SetDynamicObstacles mocks base method.
+func (m *MockEnvironment) SetDynamicObstacles(positions []model.Position) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetDynamicObstacles", positions)
+}
+
+// SetDynamicObstacles indicates an expected call of SetDynamicObstacles.
+func (mr *MockEnvironmentMockRecorder) SetDynamicObstacles(positions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDynamicObstacles", reflect.TypeOf((*MockEnvironment)(nil).SetDynamicObstacles), positions)
+}
+
+// SetRoverType mocks base method.
+func (m *MockEnvironment) SetRoverType(roverType string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetRoverType", roverType)
+}
+
+// SetRoverType indicates an expected call of SetRoverType.
+func (mr *MockEnvironmentMockRecorder) SetRoverType(roverType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRoverType", reflect.TypeOf((*MockEnvironment)(nil).SetRoverType), roverType)
+}
+
+// SetTerrain mocks base method.
+func (m *MockEnvironment) SetTerrain(terrain map[model.Position]string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTerrain", terrain)
+}
+
+// SetTerrain indicates an expected call of SetTerrain.
+func (mr *MockEnvironmentMockRecorder) SetTerrain(terrain interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTerrain", reflect.TypeOf((*MockEnvironment)(nil).SetTerrain), terrain)
+}
+
+// SetTerrainClasses mocks base method.
+func (m *MockEnvironment) SetTerrainClasses(classes map[string]environment.TerrainClass) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTerrainClasses", classes)
+}
+
+// SetTerrainClasses indicates an expected call of SetTerrainClasses.
+func (mr *MockEnvironmentMockRecorder) SetTerrainClasses(classes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTerrainClasses", reflect.TypeOf((*MockEnvironment)(nil).SetTerrainClasses), classes)
+}
+
+// SetTopology mocks base method.
+func (m *MockEnvironment) SetTopology(topology environment.Topology) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTopology", topology)
+}
+
+// SetTopology indicates an expected call of SetTopology.
+func (mr *MockEnvironmentMockRecorder) SetTopology(topology interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTopology", reflect.TypeOf((*MockEnvironment)(nil).SetTopology), topology)
+}
+
+// Size mocks base method.
+func (m *MockEnvironment) Size() model.Size {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Size")
+	ret0, _ := ret[0].(model.Size)
+	return ret0
+}
+
+// Size indicates an expected call of Size.
+func (mr *MockEnvironmentMockRecorder) Size() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Size", reflect.TypeOf((*MockEnvironment)(nil).Size))
+}
+
+// TerrainAt mocks base method.
+func (m *MockEnvironment) TerrainAt(position model.Position) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TerrainAt", position)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// TerrainAt indicates an expected call of TerrainAt.
+func (mr *MockEnvironmentMockRecorder) TerrainAt(position interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TerrainAt", reflect.TypeOf((*MockEnvironment)(nil).TerrainAt), position)
+}
+
+// TerrainCost mocks base method.
+func (m *MockEnvironment) TerrainCost(position model.Position) float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TerrainCost", position)
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// TerrainCost indicates an expected call of TerrainCost.
+func (mr *MockEnvironmentMockRecorder) TerrainCost(position interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TerrainCost", reflect.TypeOf((*MockEnvironment)(nil).TerrainCost), position)
+}
+
+// MockTopology is a mock of Topology interface.
+type MockTopology struct {
+	ctrl     *gomock.Controller
+	recorder *MockTopologyMockRecorder
+}
+
+// MockTopologyMockRecorder is the mock recorder for MockTopology.
+type MockTopologyMockRecorder struct {
+	mock *MockTopology
+}
+
+// NewMockTopology creates a new mock instance.
+func NewMockTopology(ctrl *gomock.Controller) *MockTopology {
+	mock := &MockTopology{ctrl: ctrl}
+	mock.recorder = &MockTopologyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTopology) EXPECT() *MockTopologyMockRecorder {
+	return m.recorder
+}
+
+// Distance mocks base method.
+func (m *MockTopology) Distance(from, to model.Position, size model.Size) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Distance", from, to, size)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Distance indicates an expected call of Distance.
+func (mr *MockTopologyMockRecorder) Distance(from, to, size interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Distance", reflect.TypeOf((*MockTopology)(nil).Distance), from, to, size)
+}
+
+// Normalize mocks base method.
+func (m *MockTopology) Normalize(position model.Position, size model.Size) (model.Position, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Normalize", position, size)
+	ret0, _ := ret[0].(model.Position)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Normalize indicates an expected call of Normalize.
+func (mr *MockTopologyMockRecorder) Normalize(position, size interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Normalize", reflect.TypeOf((*MockTopology)(nil).Normalize), position, size)
+}
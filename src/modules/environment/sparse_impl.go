@@ -0,0 +1,148 @@
+package environment
+
+import (
+	"mars-rover-navigation/src/model"
+)
+
+// sparseEnvironmentImpl keeps only the cells that differ from plain empty ground, so that
+// building it and CanMove cost depend on the number of obstacles rather than the grid area.
+type sparseEnvironmentImpl struct {
+	Width     int
+	Height    int
+	Obstacles map[model.Position]struct{}
+	Terrain   map[model.Position]string
+
+	DynamicObstacles []model.Position
+	Topology         Topology
+	CornerCutting    bool
+	TerrainClasses   map[string]TerrainClass
+	RoverType        string
+}
+
+// NewSparseEnvironment builds a width x height grid without allocating its cells.
+func NewSparseEnvironment(width, height int, obstacles []model.Position) *sparseEnvironmentImpl {
+	instance := &sparseEnvironmentImpl{
+		Width:     width,
+		Height:    height,
+		Obstacles: make(map[model.Position]struct{}, len(obstacles)),
+		Terrain:   make(map[model.Position]string),
+		Topology:  Bounded,
+
+		TerrainClasses: TerrainClasses,
+	}
+
+	for _, obstacle := range obstacles {
+		if isWithinGrid(obstacle, width, height) {
+			instance.Obstacles[obstacle] = struct{}{}
+		}
+	}
+	return instance
+}
+
+func (e *sparseEnvironmentImpl) GetGrid() [][]model.Cell {
+	grid := make([][]model.Cell, e.Width)
+	for i := range grid {
+		grid[i] = make([]model.Cell, e.Height)
+		for j := range grid[i] {
+			grid[i][j] = e.Cell(model.Position{X: i, Y: j})
+		}
+	}
+	return grid
+}
+
+func (e *sparseEnvironmentImpl) Size() model.Size {
+	return model.Size{Width: e.Width, Height: e.Height}
+}
+
+func (e *sparseEnvironmentImpl) Cell(position model.Position) model.Cell {
+	_, isObstacle := e.Obstacles[position]
+	return model.Cell{Position: position, IsObstacle: isObstacle, Terrain: e.Terrain[position]}
+}
+
+func (e *sparseEnvironmentImpl) CanMove(actorPosition model.Position) CanMoveStatus {
+	actorPosition, ok := e.Normalize(actorPosition)
+	if !ok {
+		return OutOfBounds
+	}
+
+	if _, isObstacle := e.Obstacles[actorPosition]; isObstacle {
+		return ObstacleEncountered
+	}
+
+	if isImpassable(e.TerrainClasses, e.RoverType, e.Terrain[actorPosition]) {
+		return ImpassableTerrain
+	}
+
+	if isMatchObstacles(actorPosition, e.DynamicObstacles) {
+		return RoverCollision
+	}
+
+	return Success
+}
+
+func (e *sparseEnvironmentImpl) CanMoveFrom(from, to model.Position) CanMoveStatus {
+	status := e.CanMove(to)
+	if status != Success || e.CornerCutting || from.X == to.X || from.Y == to.Y {
+		return status
+	}
+
+	return cornerStatus(from, to, e.isObstacle)
+}
+
+func (e *sparseEnvironmentImpl) SetDynamicObstacles(positions []model.Position) {
+	e.DynamicObstacles = positions
+}
+
+func (e *sparseEnvironmentImpl) SetTopology(topology Topology) {
+	e.Topology = topology
+}
+
+func (e *sparseEnvironmentImpl) SetCornerCutting(allowed bool) {
+	e.CornerCutting = allowed
+}
+
+func (e *sparseEnvironmentImpl) SetTerrain(terrain map[model.Position]string) {
+	for position, class := range terrain {
+		if isWithinGrid(position, e.Width, e.Height) {
+			e.Terrain[position] = class
+		}
+	}
+}
+
+func (e *sparseEnvironmentImpl) SetTerrainClasses(classes map[string]TerrainClass) {
+	e.TerrainClasses = classes
+}
+
+func (e *sparseEnvironmentImpl) SetRoverType(roverType string) {
+	e.RoverType = roverType
+}
+
+func (e *sparseEnvironmentImpl) TerrainAt(position model.Position) string {
+	position, ok := e.Normalize(position)
+	if !ok {
+		return ""
+	}
+	return e.Terrain[position]
+}
+
+func (e *sparseEnvironmentImpl) TerrainCost(position model.Position) float64 {
+	return terrainCost(e.TerrainClasses, e.TerrainAt(position))
+}
+
+func (e *sparseEnvironmentImpl) Normalize(position model.Position) (model.Position, bool) {
+	return e.Topology.Normalize(position, e.Size())
+}
+
+func (e *sparseEnvironmentImpl) Distance(from, to model.Position) int {
+	return e.Topology.Distance(from, to, e.Size())
+}
+
+// isObstacle reports a static obstacle, positions off a hard edge are not obstacles.
+func (e *sparseEnvironmentImpl) isObstacle(position model.Position) bool {
+	position, ok := e.Normalize(position)
+	if !ok {
+		return false
+	}
+	_, isObstacle := e.Obstacles[position]
+	return isObstacle
+}
@@ -0,0 +1,156 @@
+package environment
+
+import (
+	"fmt"
+	"mars-rover-navigation/src/model"
+	"reflect"
+	"testing"
+)
+
+func TestNew_SelectsStorage(t *testing.T) {
+	tests := []struct {
+		name   string
+		width  int
+		height int
+		sparse bool
+	}{
+		{"small grid is dense", 5, 5, false},
+		{"grid at the limit is dense", 1024, 1024, false},
+		{"grid above the limit is sparse", 1025, 1024, true},
+		{"very large grid is sparse", 100_000, 100_000, true},
+		{"invalid grid is dense", 0, 5, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, sparse := New(tt.width, tt.height, nil).(*sparseEnvironmentImpl)
+			if sparse != tt.sparse {
+				t.Errorf("New(%d, %d) sparse = %v, expected %v", tt.width, tt.height, sparse, tt.sparse)
+			}
+		})
+	}
+}
+
+func TestSparseEnvironment_MatchesDense(t *testing.T) {
+	obstacles := []model.Position{{X: 1, Y: 2}, {X: 2, Y: 1}, {X: 4, Y: 0}, {X: 9, Y: 9}}
+	terrain := map[model.Position]string{{X: 0, Y: 1}: "sand", {X: 3, Y: 3}: "rock", {X: 8, Y: 8}: "slope"}
+
+	tests := []struct {
+		name      string
+		topology  Topology
+		roverType string
+		corners   bool
+		dynamic   []model.Position
+	}{
+		{name: "bounded", topology: Bounded},
+		{name: "torus with a heavy rover", topology: Torus, roverType: "heavy"},
+		{name: "corner cutting with other rovers", topology: WrapX, corners: true, dynamic: []model.Position{{X: 3, Y: 0}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dense := NewEnvironment(5, 4, obstacles)
+			sparse := NewSparseEnvironment(5, 4, obstacles)
+			for _, env := range []Environment{dense, sparse} {
+				env.SetTopology(tt.topology)
+				env.SetTerrain(terrain)
+				env.SetRoverType(tt.roverType)
+				env.SetCornerCutting(tt.corners)
+				env.SetDynamicObstacles(tt.dynamic)
+			}
+
+			if !reflect.DeepEqual(sparse.GetGrid(), dense.GetGrid()) {
+				t.Errorf("GetGrid() differs:\nsparse %+v\ndense  %+v", sparse.GetGrid(), dense.GetGrid())
+			}
+			if sparse.Size() != dense.Size() {
+				t.Errorf("Size() = %+v, expected %+v", sparse.Size(), dense.Size())
+			}
+
+			for x := -1; x <= 5; x++ {
+				for y := -1; y <= 4; y++ {
+					position := model.Position{X: x, Y: y}
+					if got, want := sparse.Cell(position), dense.Cell(position); got != want {
+						t.Errorf("Cell(%+v) = %+v, expected %+v", position, got, want)
+					}
+					if got, want := sparse.CanMove(position), dense.CanMove(position); got != want {
+						t.Errorf("CanMove(%+v) = %v, expected %v", position, got, want)
+					}
+					if got, want := sparse.TerrainCost(position), dense.TerrainCost(position); got != want {
+						t.Errorf("TerrainCost(%+v) = %v, expected %v", position, got, want)
+					}
+					diagonal := model.Position{X: x + 1, Y: y + 1}
+					if got, want := sparse.CanMoveFrom(position, diagonal), dense.CanMoveFrom(position, diagonal); got != want {
+						t.Errorf("CanMoveFrom(%+v, %+v) = %v, expected %v", position, diagonal, got, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestSparseEnvironment_LargeGrid(t *testing.T) {
+	env := New(100_000, 100_000, []model.Position{{X: 99_999, Y: 50_000}, {X: 100_000, Y: 0}})
+
+	tests := []struct {
+		position model.Position
+		expected CanMoveStatus
+	}{
+		{model.Position{X: 99_999, Y: 50_000}, ObstacleEncountered},
+		{model.Position{X: 99_999, Y: 99_999}, Success},
+		{model.Position{X: 100_000, Y: 0}, OutOfBounds},
+		{model.Position{X: 0, Y: -1}, OutOfBounds},
+	}
+	for _, tt := range tests {
+		if result := env.CanMove(tt.position); result != tt.expected {
+			t.Errorf("CanMove(%+v) = %v, expected %v", tt.position, result, tt.expected)
+		}
+	}
+}
+
+// obstaclesOf spreads count obstacles over a size x size grid.
+func obstaclesOf(count, size int) []model.Position {
+	obstacles := make([]model.Position, count)
+	for i := range obstacles {
+		obstacles[i] = model.Position{X: (i * 7919) % size, Y: (i * 104729) % size}
+	}
+	return obstacles
+}
+
+// The sparse construction and CanMove timings stay flat as the area grows 10^6 times,
+// and grow with the obstacle count only.
+func BenchmarkNewSparseEnvironment(b *testing.B) {
+	for _, size := range []int{100, 100_000} {
+		for _, count := range []int{100, 10_000} {
+			obstacles := obstaclesOf(count, size)
+			b.Run(fmt.Sprintf("size=%d/obstacles=%d", size, count), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					NewSparseEnvironment(size, size, obstacles)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkSparseCanMove(b *testing.B) {
+	for _, size := range []int{100, 100_000} {
+		for _, count := range []int{100, 10_000} {
+			env := NewSparseEnvironment(size, size, obstaclesOf(count, size))
+			b.Run(fmt.Sprintf("size=%d/obstacles=%d", size, count), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					env.CanMove(model.Position{X: i % size, Y: (i / size) % size})
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkNewEnvironment(b *testing.B) {
+	for _, size := range []int{100, 1000} {
+		obstacles := obstaclesOf(100, size)
+		b.Run(fmt.Sprintf("size=%d/obstacles=100", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				NewEnvironment(size, size, obstacles)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package environment
+
+import (
+	"slices"
+)
+
+// TerrainClasses are the built-in terrain classes.
+var TerrainClasses = map[string]TerrainClass{
+	"rock":       {Cost: 1.5},
+	"sand":       {Cost: 2, ImpassableFor: []string{"heavy"}},
+	"slope":      {Cost: 3, ImpassableFor: []string{"heavy"}},
+	"crater_rim": {Cost: 4, ImpassableFor: []string{"heavy", "standard"}},
+}
+
+// isImpassable applies the rules of terrain to roverType, any rover passes when it is unset.
+func isImpassable(classes map[string]TerrainClass, roverType, terrain string) bool {
+	if roverType == "" {
+		return false
+	}
+	class, ok := classes[terrain]
+	return ok && slices.Contains(class.ImpassableFor, roverType)
+}
+
+// terrainCost is the cost multiplier of terrain, 1 for plain ground and unknown classes.
+func terrainCost(classes map[string]TerrainClass, terrain string) float64 {
+	if class, ok := classes[terrain]; ok {
+		return class.Cost
+	}
+	return 1
+}
@@ -0,0 +1,74 @@
+package environment
+
+import (
+	"fmt"
+	"mars-rover-navigation/src/model"
+)
+
+// wrapTopology joins the opposite edges of the wrapping axes, the others are hard edges.
+type wrapTopology struct {
+	wrapX bool
+	wrapY bool
+}
+
+var (
+	// Bounded treats every edge as OutOfBounds
+	Bounded Topology = wrapTopology{}
+	// WrapX joins the west and east edges, like longitude
+	WrapX Topology = wrapTopology{wrapX: true}
+	// WrapY joins the south and north edges
+	WrapY Topology = wrapTopology{wrapY: true}
+	// Torus joins both pairs of edges
+	Torus Topology = wrapTopology{wrapX: true, wrapY: true}
+)
+
+var topologies = map[string]Topology{
+	"bounded": Bounded,
+	"wrap_x":  WrapX,
+	"wrap_y":  WrapY,
+	"torus":   Torus,
+}
+
+// ParseTopology returns the topology called name, Bounded when name is empty.
+func ParseTopology(name string) (Topology, error) {
+	if name == "" {
+		return Bounded, nil
+	}
+	topology, ok := topologies[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown topology: %q (use bounded, wrap_x, wrap_y or torus)", name)
+	}
+	return topology, nil
+}
+
+func (t wrapTopology) Normalize(position model.Position, size model.Size) (model.Position, bool) {
+	x, okX := normalizeAxis(position.X, size.Width, t.wrapX)
+	y, okY := normalizeAxis(position.Y, size.Height, t.wrapY)
+	if !okX || !okY {
+		return position, false
+	}
+	return model.Position{X: x, Y: y}, true
+}
+
+func (t wrapTopology) Distance(from, to model.Position, size model.Size) int {
+	return axisDistance(from.X, to.X, size.Width, t.wrapX) + axisDistance(from.Y, to.Y, size.Height, t.wrapY)
+}
+
+func normalizeAxis(value, length int, wrap bool) (int, bool) {
+	if wrap && length > 0 {
+		return ((value % length) + length) % length, true
+	}
+	return value, value >= 0 && value < length
+}
+
+func axisDistance(from, to, length int, wrap bool) int {
+	distance := from - to
+	if distance < 0 {
+		distance = -distance
+	}
+	// Going the other way round is shorter past half the axis
+	if wrap && length-distance < distance {
+		return length - distance
+	}
+	return distance
+}
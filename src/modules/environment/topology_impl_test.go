@@ -0,0 +1,89 @@
+package environment
+
+import (
+	"mars-rover-navigation/src/model"
+	"testing"
+)
+
+func TestParseTopology(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected Topology
+		wantErr  bool
+	}{
+		{name: "", expected: Bounded},
+		{name: "bounded", expected: Bounded},
+		{name: "wrap_x", expected: WrapX},
+		{name: "wrap_y", expected: WrapY},
+		{name: "torus", expected: Torus},
+		{name: "sphere", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			topology, err := ParseTopology(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTopology(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if topology != tt.expected {
+				t.Errorf("ParseTopology(%q) = %+v, expected %+v", tt.name, topology, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTopology_Normalize(t *testing.T) {
+	size := model.Size{Width: 4, Height: 3}
+
+	tests := []struct {
+		name       string
+		topology   Topology
+		position   model.Position
+		expected   model.Position
+		expectedOK bool
+	}{
+		{"inside stays", Torus, model.Position{X: 2, Y: 1}, model.Position{X: 2, Y: 1}, true},
+		{"bounded keeps the position off the grid", Bounded, model.Position{X: 4, Y: 1}, model.Position{X: 4, Y: 1}, false},
+		{"wrap_x east to west", WrapX, model.Position{X: 4, Y: 1}, model.Position{X: 0, Y: 1}, true},
+		{"wrap_x west to east", WrapX, model.Position{X: -1, Y: 1}, model.Position{X: 3, Y: 1}, true},
+		{"wrap_x does not wrap Y", WrapX, model.Position{X: 1, Y: -1}, model.Position{X: 1, Y: -1}, false},
+		{"wrap_y north to south", WrapY, model.Position{X: 1, Y: 3}, model.Position{X: 1, Y: 0}, true},
+		{"wrap_y south to north", WrapY, model.Position{X: 1, Y: -1}, model.Position{X: 1, Y: 2}, true},
+		{"torus far away", Torus, model.Position{X: -9, Y: 7}, model.Position{X: 3, Y: 1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			position, ok := tt.topology.Normalize(tt.position, size)
+			if position != tt.expected || ok != tt.expectedOK {
+				t.Errorf("Normalize(%+v) = %+v, %v, expected %+v, %v", tt.position, position, ok, tt.expected, tt.expectedOK)
+			}
+		})
+	}
+}
+
+func TestTopology_Distance(t *testing.T) {
+	size := model.Size{Width: 10, Height: 6}
+
+	tests := []struct {
+		name     string
+		topology Topology
+		from     model.Position
+		to       model.Position
+		expected int
+	}{
+		{"bounded is Manhattan", Bounded, model.Position{X: 0, Y: 0}, model.Position{X: 9, Y: 5}, 14},
+		{"wrap_x across the edge", WrapX, model.Position{X: 0, Y: 0}, model.Position{X: 9, Y: 5}, 6},
+		{"wrap_y across the edge", WrapY, model.Position{X: 0, Y: 0}, model.Position{X: 9, Y: 5}, 10},
+		{"torus across both edges", Torus, model.Position{X: 0, Y: 0}, model.Position{X: 9, Y: 5}, 2},
+		{"torus direct when shorter", Torus, model.Position{X: 2, Y: 1}, model.Position{X: 4, Y: 2}, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if distance := tt.topology.Distance(tt.from, tt.to, size); distance != tt.expected {
+				t.Errorf("Distance(%+v, %+v) = %d, expected %d", tt.from, tt.to, distance, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package game
+
+import (
+	"mars-rover-navigation/src/model"
+	"mars-rover-navigation/src/modules/environment"
+	"math"
+)
+
+// EnergyProfile is the battery of a kind of rover and the charge every command draws from it.
+type EnergyProfile struct {
+	// Name is the rover type that terrain passability rules refer to
+	Name string
+	// Charge is the battery at the start of the mission
+	Charge  float64
+	Move    float64
+	Turn    float64
+	Reverse float64
+	// TerrainMultipliers scale Move and Reverse by the terrain entered, replacing the terrain class cost
+	TerrainMultipliers map[string]float64
+}
+
+// Profiles are the built-in rover profiles.
+var Profiles = map[string]EnergyProfile{
+	"standard": {Name: "standard", Charge: 100, Move: 1, Turn: 0.5, Reverse: 1.5},
+	"heavy":    {Name: "heavy", Charge: 300, Move: 3, Turn: 1, Reverse: 4},
+	"scout":    {Name: "scout", Charge: 40, Move: 0.5, Turn: 0.25, Reverse: 0.75},
+}
+
+// chargeEpsilon absorbs the rounding of fractional costs, 0.1 three times is still 0.3
+const chargeEpsilon = 1e-9
+
+func isValidEnergy(profile *EnergyProfile) bool {
+	if profile == nil {
+		return true
+	}
+	if profile.Charge < 0 || profile.Move < 0 || profile.Turn < 0 || profile.Reverse < 0 {
+		return false
+	}
+	for _, multiplier := range profile.TerrainMultipliers {
+		if multiplier < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// cost is the charge cmd draws before terrain, a U-turn is two turns and A, C half a turn.
+func (p *EnergyProfile) cost(cmd rune) float64 {
+	switch cmd {
+	case 'M':
+		return p.Move
+	case 'B':
+		return p.Reverse
+	case 'U':
+		return 2 * p.Turn
+	case 'A', 'C':
+		return p.Turn / 2
+	}
+	return p.Turn
+}
+
+// moveCost is the charge a move onto position draws, terrain is only looked up with a battery.
+func (r *roverRunner) moveCost(env environment.Environment, cmd rune, position model.Position) float64 {
+	if r.options.Energy == nil {
+		return 0
+	}
+	multiplier, ok := r.options.Energy.TerrainMultipliers[env.TerrainAt(position)]
+	if !ok {
+		multiplier = env.TerrainCost(position)
+	}
+	return r.options.Energy.cost(cmd) * multiplier
+}
+
+// roverType names the rover for terrain passability, a rover without a profile passes everywhere.
+func (o Options) roverType() string {
+	if o.Energy == nil {
+		return ""
+	}
+	return o.Energy.Name
+}
+
+// isValidTerrain keeps every terrain class at least as costly as plain ground, the planner relies on it.
+func isValidTerrain(options Options) bool {
+	for _, class := range options.TerrainClasses {
+		if class.Cost < 1 {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *roverRunner) turnCost(cmd rune) float64 {
+	if r.options.Energy == nil {
+		return 0
+	}
+	return r.options.Energy.cost(cmd)
+}
+
+// drain takes cost from the battery, the rover stops when the charge left cannot cover it.
+func (r *roverRunner) drain(cost float64) bool {
+	if r.options.Energy == nil {
+		return true
+	}
+	if cost > r.charge+chargeEpsilon {
+		r.status = StatusBatteryDepleted
+		return false
+	}
+	r.charge = math.Max(r.charge-cost, 0)
+	return true
+}
+
+// remainingCharge is reported for rovers with a battery only.
+func (r *roverRunner) remainingCharge() *float64 {
+	if r.options.Energy == nil {
+		return nil
+	}
+	charge := math.Round(r.charge*1e6) / 1e6
+	return &charge
+}
@@ -0,0 +1,187 @@
+package game
+
+import (
+	"mars-rover-navigation/src/model"
+	"mars-rover-navigation/src/modules/environment"
+	"reflect"
+	"testing"
+)
+
+func TestNavigateRoverWithOptions_Energy(t *testing.T) {
+	battery := &EnergyProfile{Charge: 3, Move: 1, Turn: 0.5, Reverse: 1.5}
+	sandy := &EnergyProfile{Charge: 3, Move: 1, Turn: 0.5, Reverse: 1.5, TerrainMultipliers: map[string]float64{"sand": 2}}
+
+	tests := []struct {
+		name              string
+		commands          string
+		direction         model.Direction
+		options           Options
+		expectedPosition  model.Position
+		expectedDirection model.Direction
+		expectedStatus    Status
+		expectedCharge    float64
+	}{
+		{"Exact charge", "MMM", model.North, Options{Energy: battery}, model.Position{X: 0, Y: 3}, model.North, StatusSuccess, 0},
+		{"Depleted before the move", "MMMM", model.North, Options{Energy: battery}, model.Position{X: 0, Y: 3}, model.North, StatusBatteryDepleted, 0},
+		{"Turns cost charge", "RMLM", model.North, Options{Energy: battery}, model.Position{X: 1, Y: 1}, model.North, StatusSuccess, 0},
+		{"Depleted before the turn", "MMMR", model.North, Options{Energy: battery}, model.Position{X: 0, Y: 3}, model.North, StatusBatteryDepleted, 0},
+		{"Reverse costs more", "MB", model.North, Options{Energy: battery}, model.Position{X: 0, Y: 0}, model.North, StatusSuccess, 0.5},
+		{"U-turn is two turns", "U", model.North, Options{Energy: battery}, model.Position{X: 0, Y: 0}, model.South, StatusSuccess, 2},
+		{"Half turns are half a turn", "CA", model.North, Options{Energy: battery, Compass: CompassEight}, model.Position{X: 0, Y: 0}, model.North, StatusSuccess, 2.5},
+		{"Terrain multiplies moves", "MM", model.North, Options{Energy: sandy, Terrain: map[model.Position]string{{X: 0, Y: 1}: "sand"}}, model.Position{X: 0, Y: 2}, model.North, StatusSuccess, 0},
+		{"Terrain drains the battery sooner", "MMM", model.North, Options{Energy: sandy, Terrain: map[model.Position]string{{X: 0, Y: 1}: "sand"}}, model.Position{X: 0, Y: 2}, model.North, StatusBatteryDepleted, 0},
+		{"Unknown terrain costs the plain move", "MMM", model.North, Options{Energy: sandy, Terrain: map[model.Position]string{{X: 0, Y: 1}: "gravel"}}, model.Position{X: 0, Y: 3}, model.North, StatusSuccess, 0},
+		{"Terrain class cost without a multiplier", "MM", model.North, Options{Energy: battery, Terrain: map[model.Position]string{{X: 0, Y: 1}: "rock"}}, model.Position{X: 0, Y: 2}, model.North, StatusSuccess, 0.5},
+		{"Mission terrain classes", "MM", model.North, Options{Energy: battery, Terrain: map[model.Position]string{{X: 0, Y: 1}: "ice"},
+			TerrainClasses: map[string]environment.TerrainClass{"ice": {Cost: 2}}}, model.Position{X: 0, Y: 2}, model.North, StatusSuccess, 0},
+		{"Blocked moves cost nothing", "MM", model.South, Options{Energy: battery, Policy: PolicySkip}, model.Position{X: 0, Y: 0}, model.South, StatusSuccess, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NewGame().NavigateRoverWithOptions(5, 5, nil, model.Position{X: 0, Y: 0}, tt.direction, tt.commands, tt.options)
+
+			if result.FinalPosition != tt.expectedPosition || result.FinalDirection != tt.expectedDirection || result.Status != tt.expectedStatus {
+				t.Errorf("Expected %v %v %v, got %v %v %v", tt.expectedPosition, tt.expectedDirection, tt.expectedStatus,
+					result.FinalPosition, result.FinalDirection, result.Status)
+			}
+			if result.RemainingCharge == nil || *result.RemainingCharge != tt.expectedCharge {
+				t.Errorf("Expected remaining charge %v, got %v", tt.expectedCharge, result.RemainingCharge)
+			}
+		})
+	}
+}
+
+func TestNavigateRoverWithOptions_NoEnergy(t *testing.T) {
+	result := NewGame().NavigateRoverWithOptions(5, 5, nil, model.Position{X: 0, Y: 0}, model.North, "MMMM", Options{})
+
+	if result.Status != StatusSuccess {
+		t.Errorf("Expected status %v, got %v", StatusSuccess, result.Status)
+	}
+	if result.RemainingCharge != nil {
+		t.Errorf("Expected no remaining charge without a profile, got %v", *result.RemainingCharge)
+	}
+}
+
+func TestNavigateRoverWithOptions_InvalidEnergy(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile *EnergyProfile
+	}{
+		{"Negative charge", &EnergyProfile{Charge: -1, Move: 1}},
+		{"Negative move cost", &EnergyProfile{Charge: 10, Move: -1}},
+		{"Negative terrain multiplier", &EnergyProfile{Charge: 10, Move: 1, TerrainMultipliers: map[string]float64{"sand": -2}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NewGame().NavigateRoverWithOptions(5, 5, nil, model.Position{X: 0, Y: 0}, model.North, "M", Options{Energy: tt.profile})
+
+			if result.Status != StatusInvalidInput {
+				t.Errorf("Expected status %v, got %v", StatusInvalidInput, result.Status)
+			}
+		})
+	}
+}
+
+func TestNavigateFleet_EnergyProfiles(t *testing.T) {
+	scout := Profiles["scout"]
+	rovers := []RoverMission{
+		{ID: "default", Start: model.Position{X: 0, Y: 0}, Direction: model.North, Commands: "MMM"},
+		{ID: "scout", Start: model.Position{X: 2, Y: 0}, Direction: model.North, Commands: "MMM", Energy: &scout},
+	}
+
+	result := NewGame().NavigateFleet(5, 5, nil, rovers, FleetModeSequential, Options{Energy: &EnergyProfile{Charge: 2, Move: 1}})
+
+	expected := []struct {
+		status Status
+		charge float64
+	}{
+		{StatusBatteryDepleted, 0},
+		{StatusSuccess, 38.5},
+	}
+	for i, want := range expected {
+		got := result.Rovers[i]
+		if got.Status != want.status || got.RemainingCharge == nil || *got.RemainingCharge != want.charge {
+			t.Errorf("rover %s: expected %v with charge %v, got %v with %v", got.ID, want.status, want.charge, got.Status, got.RemainingCharge)
+		}
+	}
+}
+
+func TestNavigateRoverWithOptions_ImpassableTerrain(t *testing.T) {
+	heavy := Profiles["heavy"]
+	scout := Profiles["scout"]
+	terrain := map[model.Position]string{{X: 0, Y: 1}: "sand"}
+
+	tests := []struct {
+		name             string
+		options          Options
+		expectedPosition model.Position
+		expectedStatus   Status
+	}{
+		{"No rover type passes", Options{Terrain: terrain}, model.Position{X: 0, Y: 2}, StatusSuccess},
+		{"Scout crosses sand", Options{Terrain: terrain, Energy: &scout}, model.Position{X: 0, Y: 2}, StatusSuccess},
+		{"Heavy stops at sand", Options{Terrain: terrain, Energy: &heavy}, model.Position{X: 0, Y: 0}, StatusImpassableTerrain},
+		{"Invalid terrain class cost", Options{TerrainClasses: map[string]environment.TerrainClass{"ice": {Cost: 0.5}}}, model.Position{X: 0, Y: 0}, StatusInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NewGame().NavigateRoverWithOptions(5, 5, nil, model.Position{X: 0, Y: 0}, model.North, "MM", tt.options)
+
+			if result.FinalPosition != tt.expectedPosition || result.Status != tt.expectedStatus {
+				t.Errorf("Expected %v %v, got %v %v", tt.expectedPosition, tt.expectedStatus, result.FinalPosition, result.Status)
+			}
+		})
+	}
+}
+
+func TestNavigateRoverWithOptions_TraceTerrain(t *testing.T) {
+	result := NewGame().NavigateRoverWithOptions(5, 5, nil, model.Position{X: 0, Y: 0}, model.North, "MRM",
+		Options{Trace: true, Terrain: map[model.Position]string{{X: 0, Y: 1}: "rock"}})
+
+	expected := []string{"rock", "", ""}
+	if len(result.Trace) != len(expected) {
+		t.Fatalf("Expected %d steps, got %d", len(expected), len(result.Trace))
+	}
+	for i, terrain := range expected {
+		if result.Trace[i].Terrain != terrain {
+			t.Errorf("step %d terrain = %q, want %q", i, result.Trace[i].Terrain, terrain)
+		}
+	}
+}
+
+func TestNavigateRoverWithOptions_TraceBatteryDepleted(t *testing.T) {
+	result := NewGame().NavigateRoverWithOptions(5, 5, nil, model.Position{X: 0, Y: 0}, model.North, "MMM",
+		Options{Trace: true, Energy: &EnergyProfile{Charge: 1.5, Move: 1}})
+
+	if result.Status != StatusBatteryDepleted || result.FinalPosition != (model.Position{X: 0, Y: 1}) {
+		t.Fatalf("Expected Battery depleted at (0,1), got %v at %v", result.Status, result.FinalPosition)
+	}
+	expected := []Step{
+		{Index: 0, Command: "M", Before: model.Pose{X: 0, Y: 0, Direction: model.North}, After: model.Pose{X: 0, Y: 1, Direction: model.North}, MoveStatus: environment.Success},
+		// The refused move keeps the pose and reports no move status
+		{Index: 1, Command: "M", Before: model.Pose{X: 0, Y: 1, Direction: model.North}, After: model.Pose{X: 0, Y: 1, Direction: model.North}},
+	}
+	if !reflect.DeepEqual(result.Trace, expected) {
+		t.Errorf("Expected trace %+v, got %+v", expected, result.Trace)
+	}
+}
+
+func TestNavigateFleet_RoverTypes(t *testing.T) {
+	heavy := Profiles["heavy"]
+	rovers := []RoverMission{
+		{ID: "heavy", Start: model.Position{X: 0, Y: 0}, Direction: model.North, Commands: "M", Energy: &heavy},
+		{ID: "any", Start: model.Position{X: 1, Y: 0}, Direction: model.North, Commands: "M"},
+	}
+	terrain := map[model.Position]string{{X: 0, Y: 1}: "slope", {X: 1, Y: 1}: "slope"}
+
+	result := NewGame().NavigateFleet(5, 5, nil, rovers, FleetModeInterleaved, Options{Terrain: terrain})
+
+	if result.Rovers[0].Status != StatusImpassableTerrain {
+		t.Errorf("rover heavy status = %v, want %v", result.Rovers[0].Status, StatusImpassableTerrain)
+	}
+	if result.Rovers[1].Status != StatusSuccess || result.Rovers[1].FinalPosition != (model.Position{X: 1, Y: 1}) {
+		t.Errorf("rover any = %v at %v, want %v at (1,1)", result.Rovers[1].Status, result.Rovers[1].FinalPosition, StatusSuccess)
+	}
+}
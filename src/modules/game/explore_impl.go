@@ -0,0 +1,201 @@
+package game
+
+import (
+	"fmt"
+	"mars-rover-navigation/src/model"
+	"mars-rover-navigation/src/modules/environment"
+	"mars-rover-navigation/src/modules/planner"
+	"mars-rover-navigation/src/modules/rover"
+)
+
+// ExploreStop tells why an exploration ended.
+type ExploreStop string
+
+const (
+	// ExploreStopExplored is reached once no frontier is left the rover can drive to
+	ExploreStopExplored ExploreStop = "explored"
+	// ExploreStopMoveBudget is reached when the next move would exceed the move budget
+	ExploreStopMoveBudget ExploreStop = "move_budget"
+	// ExploreStopEnergyBudget is reached when the battery cannot cover the next move and the turns before it
+	ExploreStopEnergyBudget ExploreStop = "energy_budget"
+)
+
+// DefaultSensorRadius is the sensor radius of an exploring rover when Options.SensorRadius is 0.
+const DefaultSensorRadius = 1
+
+type ExploreResult struct {
+	ExtendedResult
+	// Commands replays the exploration with NavigateRoverWithOptions and the same options
+	Commands string      `json:"commands"`
+	Moves    int         `json:"moves"`
+	Stop     ExploreStop `json:"stop,omitempty"`
+}
+
+// Explore drives the rover without commands, always to the nearest frontier: a known free cell next to an unknown one.
+// maxMoves caps the M commands, 0 is unlimited, and Options.Energy caps the charge.
+func (e *gameImpl) Explore(width, height int, obstacles []model.Position, start model.Position, direction model.Direction, maxMoves int, options Options) ExploreResult {
+	grid := model.Size{Width: width, Height: height}
+	if options.SensorRadius == 0 {
+		options.SensorRadius = DefaultSensorRadius
+	}
+
+	errs := append(validateInputs(width, height, obstacles, start, direction, ""), validateOptions(options, direction, "")...)
+	if direction.IsDiagonal() {
+		// The planner drives with orthogonal moves only
+		errs = append(errs, NewValidationError(CodeInvalidDirection, "direction", string(direction), "exploration starts heading N, E, S or W"))
+	}
+	if maxMoves < 0 {
+		errs = append(errs, NewValidationError(CodeInvalidOption, "max_moves", fmt.Sprint(maxMoves), "move budget must not be negative"))
+	}
+	if len(errs) > 0 {
+		return ExploreResult{ExtendedResult: rejectedResult(errs.Status(), start, direction, grid, errs)}
+	}
+
+	var env environment.Environment = e.newEnvironment(width, height, obstacles, options)
+	runner := e.newRoverRunner(start, direction, "", options)
+	runner.observe(env)
+
+	explorer := &explorer{runner: runner, env: env, planner: e.planner, roverFactory: e.roverFactory, maxMoves: maxMoves,
+		unreachable: map[model.Position]bool{}}
+	stop := explorer.run()
+
+	return ExploreResult{
+		ExtendedResult: runner.result(grid),
+		Commands:       runner.commands,
+		Moves:          explorer.moves,
+		Stop:           stop,
+	}
+}
+
+// explorer steps a rover with sensors through the commands it plans on its belief.
+type explorer struct {
+	runner       *roverRunner
+	env          environment.Environment
+	planner      planner.Planner
+	roverFactory func(int, int, model.Direction) rover.Rover
+	maxMoves     int
+	moves        int
+	// unreachable holds the frontiers the planner found no path to, they are not picked again
+	unreachable map[model.Position]bool
+}
+
+func (x *explorer) run() ExploreStop {
+	for {
+		frontier, ok := x.nearestFrontier()
+		if !ok {
+			return ExploreStopExplored
+		}
+
+		plan := x.planner.Plan(x.runner.belief, poseOf(x.runner.rover), planner.Goal{Position: frontier})
+		if plan.Status != planner.StatusSuccess {
+			x.unreachable[frontier] = true
+			continue
+		}
+		if stop := x.drive(plan.Commands, frontier); stop != "" {
+			return stop
+		}
+	}
+}
+
+// drive runs commands a move at a time, with the turns before it, and leaves early once the move is blocked on what
+// the rover sensed on the way or frontier is no longer next to an unknown cell.
+func (x *explorer) drive(commands string, frontier model.Position) ExploreStop {
+	r := x.runner
+	for len(commands) > 0 {
+		if !x.isFrontier(frontier) {
+			return ""
+		}
+
+		end := 0
+		for commands[end] != 'M' {
+			end++
+		}
+		chunk := commands[:end+1]
+		commands = commands[end+1:]
+
+		// A plan turns the rover on the spot, the move decides whether the chunk is still possible
+		target := x.moveTarget(chunk)
+		if r.belief.CanMove(target) != environment.Success {
+			return ""
+		}
+		if x.maxMoves > 0 && x.moves == x.maxMoves {
+			return ExploreStopMoveBudget
+		}
+		if r.options.Energy != nil && r.chunkCost(x.env, chunk, target) > r.charge+chargeEpsilon {
+			return ExploreStopEnergyBudget
+		}
+
+		r.commands += chunk
+		for !r.done() {
+			r.step(x.env)
+		}
+		x.moves++
+	}
+	return ""
+}
+
+// nearestFrontier searches the known free cells in move order from the rover, its own cell first.
+func (x *explorer) nearestFrontier() (model.Position, bool) {
+	belief := x.runner.belief
+	start := x.runner.rover.GetPosition()
+	seen := map[model.Position]bool{start: true}
+	queue := []model.Position{start}
+	for len(queue) > 0 {
+		cell := queue[0]
+		queue = queue[1:]
+		if !x.unreachable[cell] && x.isFrontier(cell) {
+			return cell, true
+		}
+		for _, next := range x.neighbors(cell) {
+			if !seen[next] && belief.IsKnown(next) && belief.CanMove(next) == environment.Success {
+				seen[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return model.Position{}, false
+}
+
+func (x *explorer) isFrontier(cell model.Position) bool {
+	for _, next := range x.neighbors(cell) {
+		if !x.runner.belief.IsKnown(next) {
+			return true
+		}
+	}
+	return false
+}
+
+// neighbors are the grid cells one orthogonal move from cell, across a wrapping edge too.
+func (x *explorer) neighbors(cell model.Position) []model.Position {
+	neighbors := make([]model.Position, 0, 4)
+	for _, step := range []model.Position{{X: 0, Y: 1}, {X: 1, Y: 0}, {X: 0, Y: -1}, {X: -1, Y: 0}} {
+		if next, ok := x.env.Normalize(model.Position{X: cell.X + step.X, Y: cell.Y + step.Y}); ok {
+			neighbors = append(neighbors, next)
+		}
+	}
+	return neighbors
+}
+
+// moveTarget is the cell the M ending chunk enters once the turns before it are done, plans only turn with L and R.
+func (x *explorer) moveTarget(chunk string) model.Position {
+	pose := poseOf(x.runner.rover)
+	ghost := x.roverFactory(pose.X, pose.Y, pose.Direction)
+	for _, cmd := range chunk[:len(chunk)-1] {
+		if cmd == 'L' {
+			ghost.TurnLeft()
+		} else {
+			ghost.TurnRight()
+		}
+	}
+	target, _ := x.env.Normalize(ghost.GetTryMovePosition())
+	return target
+}
+
+// chunkCost is the charge of the turns of chunk and of the move onto target that ends it.
+func (r *roverRunner) chunkCost(env environment.Environment, chunk string, target model.Position) float64 {
+	cost := r.moveCost(env, 'M', target)
+	for _, cmd := range chunk[:len(chunk)-1] {
+		cost += r.turnCost(cmd)
+	}
+	return cost
+}
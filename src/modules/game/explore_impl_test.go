@@ -0,0 +1,138 @@
+package game
+
+import (
+	"mars-rover-navigation/src/model"
+	"mars-rover-navigation/src/modules/environment"
+	"strings"
+	"testing"
+)
+
+func TestExplore(t *testing.T) {
+	tests := []struct {
+		name             string
+		width            int
+		height           int
+		obstacles        []model.Position
+		maxMoves         int
+		options          Options
+		expectedStop     ExploreStop
+		expectedExplored float64
+		expectedMoves    int
+	}{
+		{
+			name:             "Open grid is explored",
+			width:            4,
+			height:           4,
+			expectedStop:     ExploreStopExplored,
+			expectedExplored: 1,
+		},
+		{
+			name:             "A wall hides the far side",
+			width:            5,
+			height:           5,
+			obstacles:        []model.Position{{X: 2, Y: 0}, {X: 2, Y: 1}, {X: 2, Y: 2}, {X: 2, Y: 3}, {X: 2, Y: 4}},
+			expectedStop:     ExploreStopExplored,
+			expectedExplored: 0.6,
+		},
+		{
+			name:             "Wider sensors see over the wall, the rover cannot get closer",
+			width:            5,
+			height:           5,
+			obstacles:        []model.Position{{X: 2, Y: 0}, {X: 2, Y: 1}, {X: 2, Y: 2}, {X: 2, Y: 3}, {X: 2, Y: 4}},
+			options:          Options{SensorRadius: 3},
+			expectedStop:     ExploreStopExplored,
+			expectedExplored: 0.72,
+		},
+		{
+			name:             "Torus wraps the frontier",
+			width:            3,
+			height:           6,
+			options:          Options{Topology: environment.Torus},
+			expectedStop:     ExploreStopExplored,
+			expectedExplored: 1,
+		},
+		{
+			name:          "Move budget",
+			width:         10,
+			height:        10,
+			maxMoves:      3,
+			expectedStop:  ExploreStopMoveBudget,
+			expectedMoves: 3,
+		},
+		{
+			name:         "Energy budget",
+			width:        30,
+			height:       30,
+			options:      Options{Energy: &EnergyProfile{Name: "scout", Charge: 10, Move: 1, Turn: 0.5}},
+			expectedStop: ExploreStopEnergyBudget,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := model.Position{X: 0, Y: 0}
+			result := NewGame().Explore(tt.width, tt.height, tt.obstacles, start, model.North, tt.maxMoves, tt.options)
+
+			if result.Status != StatusSuccess {
+				t.Fatalf("Expected status %v, got %v", StatusSuccess, result.Status)
+			}
+			if result.Stop != tt.expectedStop {
+				t.Errorf("Expected stop %v, got %v", tt.expectedStop, result.Stop)
+			}
+			if tt.expectedExplored != 0 && (result.Explored == nil || *result.Explored != tt.expectedExplored) {
+				t.Errorf("Expected explored %v, got %v", tt.expectedExplored, deref(result.Explored))
+			}
+			if moves := strings.Count(result.Commands, "M"); moves != result.Moves {
+				t.Errorf("Expected %d moves in %q, got %d", result.Moves, result.Commands, moves)
+			}
+			if tt.expectedMoves != 0 && result.Moves != tt.expectedMoves {
+				t.Errorf("Expected %d moves, got %d", tt.expectedMoves, result.Moves)
+			}
+
+			// The commands replay the exploration without a blocked move
+			replay := NewGame().NavigateRoverWithOptions(tt.width, tt.height, tt.obstacles, start, model.North, result.Commands, tt.options)
+			if replay.Status != StatusSuccess || replay.FinalPosition != result.FinalPosition || replay.FinalDirection != result.FinalDirection {
+				t.Errorf("Expected the replay to end %v at %v %v, got %v at %v %v", StatusSuccess, result.FinalPosition, result.FinalDirection,
+					replay.Status, replay.FinalPosition, replay.FinalDirection)
+			}
+			if tt.options.Energy != nil && (replay.RemainingCharge == nil || *replay.RemainingCharge != *result.RemainingCharge) {
+				t.Errorf("Expected the replay to leave %v charge, got %v", deref(result.RemainingCharge), deref(replay.RemainingCharge))
+			}
+		})
+	}
+}
+
+func TestExplore_Invalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		direction model.Direction
+		maxMoves  int
+		options   Options
+		expected  ValidationError
+	}{
+		{
+			name:      "Negative move budget",
+			direction: model.North,
+			maxMoves:  -1,
+			expected:  ValidationError{Code: CodeInvalidOption, Field: "max_moves", Value: "-1", Message: "move budget must not be negative"},
+		},
+		{
+			name:      "Diagonal start",
+			direction: model.NorthEast,
+			options:   Options{Compass: CompassEight},
+			expected:  ValidationError{Code: CodeInvalidDirection, Field: "direction", Value: "NE", Message: "exploration starts heading N, E, S or W"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NewGame().Explore(5, 5, nil, model.Position{X: 0, Y: 0}, tt.direction, tt.maxMoves, tt.options)
+			if result.Status != StatusInvalidInput || result.Commands != "" || result.Stop != "" {
+				t.Errorf("Expected a rejected exploration, got %+v", result)
+			}
+			if len(result.Errors) != 1 || result.Errors[0] != tt.expected {
+				t.Errorf("Expected errors [%+v], got %+v", tt.expected, result.Errors)
+			}
+		})
+	}
+}
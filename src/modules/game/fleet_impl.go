@@ -0,0 +1,146 @@
+package game
+
+import (
+	"mars-rover-navigation/src/model"
+	"mars-rover-navigation/src/modules/environment"
+)
+
+type FleetMode string
+
+const (
+	// FleetModeSequential runs every command of a rover before the next rover starts
+	FleetModeSequential FleetMode = "sequential"
+	// FleetModeInterleaved runs one command of each rover per turn
+	FleetModeInterleaved FleetMode = "interleaved"
+)
+
+type RoverMission struct {
+	ID        string
+	Start     model.Position
+	Direction model.Direction
+	Commands  string
+	// Energy replaces Options.Energy for this rover, e.g. for a fleet of mixed profiles
+	Energy *EnergyProfile
+}
+
+type RoverResult struct {
+	ID string `json:"id,omitempty"`
+	ExtendedResult
+}
+
+type FleetResult struct {
+	Mode   FleetMode     `json:"mode"`
+	Rovers []RoverResult `json:"rovers"`
+}
+
+func (e *gameImpl) NavigateFleet(width, height int, obstacles []model.Position, rovers []RoverMission, mode FleetMode, options Options) FleetResult {
+	grid := model.Size{Width: width, Height: height}
+	if mode == "" {
+		mode = FleetModeSequential
+	}
+
+	results := make([]RoverResult, len(rovers))
+	// runners[i] stays nil for a rover rejected before it starts
+	runners := make([]*roverRunner, len(rovers))
+	var starts []model.Position
+
+	for i, m := range rovers {
+		results[i].ID = m.ID
+
+		roverOptions := options
+		if m.Energy != nil {
+			roverOptions.Energy = m.Energy
+		}
+
+		errs := append(validateInputs(width, height, obstacles, m.Start, m.Direction, m.Commands), validateOptions(roverOptions, m.Direction, m.Commands)...)
+		if mode != FleetModeSequential && mode != FleetModeInterleaved {
+			errs = append(errs, NewValidationError(CodeInvalidOption, "fleet_mode", string(mode), "fleet mode must be sequential or interleaved"))
+		}
+		if len(errs) > 0 {
+			results[i].ExtendedResult = rejectedResult(errs.Status(), m.Start, m.Direction, grid, errs)
+			continue
+		}
+		if containsPosition(starts, m.Start) {
+			results[i].ExtendedResult = rejectedResult(StatusRoverCollision, m.Start, m.Direction, grid, nil)
+			continue
+		}
+
+		starts = append(starts, m.Start)
+		runners[i] = e.newRoverRunner(m.Start, m.Direction, m.Commands, roverOptions)
+		runners[i].id = m.ID
+	}
+
+	if len(starts) > 0 {
+		var env environment.Environment = e.newEnvironment(width, height, obstacles, options)
+		for _, runner := range runners {
+			if runner != nil {
+				runner.observe(env)
+			}
+		}
+		// Each rover moves under the passability rules of its own type once any rover has one
+		typed := false
+		for _, runner := range runners {
+			typed = typed || runner != nil && runner.options.roverType() != ""
+		}
+		prepare := func(i int) {
+			env.SetDynamicObstacles(otherRoverPositions(runners, i))
+			if typed {
+				env.SetRoverType(runners[i].options.roverType())
+			}
+		}
+
+		switch mode {
+		case FleetModeSequential:
+			for i, runner := range runners {
+				for runner != nil && !runner.done() {
+					prepare(i)
+					runner.step(env)
+				}
+			}
+		case FleetModeInterleaved:
+			for turn, moved := 1, true; moved; turn++ {
+				moved = false
+				for i, runner := range runners {
+					if runner == nil || runner.done() {
+						continue
+					}
+					prepare(i)
+					runner.round = turn
+					runner.step(env)
+					moved = true
+				}
+			}
+		}
+	}
+
+	for i, runner := range runners {
+		if runner != nil {
+			results[i].ExtendedResult = runner.result(grid)
+		}
+	}
+
+	return FleetResult{
+		Mode:   mode,
+		Rovers: results,
+	}
+}
+
+// otherRoverPositions lists the cells held by every started rover except rovers[self].
+func otherRoverPositions(runners []*roverRunner, self int) []model.Position {
+	positions := make([]model.Position, 0, len(runners))
+	for i, runner := range runners {
+		if runner != nil && i != self {
+			positions = append(positions, runner.rover.GetPosition())
+		}
+	}
+	return positions
+}
+
+func containsPosition(positions []model.Position, position model.Position) bool {
+	for _, p := range positions {
+		if p == position {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,223 @@
+package game
+
+import (
+	"mars-rover-navigation/src/model"
+	"mars-rover-navigation/src/modules/environment"
+	envMock "mars-rover-navigation/src/modules/environment/mock"
+	"mars-rover-navigation/src/modules/rover"
+	roverMock "mars-rover-navigation/src/modules/rover/mock"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+type expectedRover struct {
+	position  model.Position
+	direction model.Direction
+	status    Status
+}
+
+func assertFleetResult(t *testing.T, result FleetResult, expected []expectedRover) {
+	t.Helper()
+
+	if len(result.Rovers) != len(expected) {
+		t.Fatalf("Expected %d rover results, got %d", len(expected), len(result.Rovers))
+	}
+	for i, want := range expected {
+		got := result.Rovers[i]
+		if got.Status != want.status {
+			t.Errorf("rover %d: expected status %v, got %v", i, want.status, got.Status)
+		}
+		if got.FinalPosition != want.position {
+			t.Errorf("rover %d: expected position %v, got %v", i, want.position, got.FinalPosition)
+		}
+		if got.FinalDirection != want.direction {
+			t.Errorf("rover %d: expected direction %v, got %v", i, want.direction, got.FinalDirection)
+		}
+	}
+}
+
+func TestNavigateFleet_Sequential(t *testing.T) {
+	game := NewGame()
+
+	rovers := []RoverMission{
+		{ID: "a", Start: model.Position{X: 0, Y: 0}, Direction: model.North, Commands: "MM"},
+		{ID: "b", Start: model.Position{X: 0, Y: 4}, Direction: model.South, Commands: "MMM"},
+	}
+	result := game.NavigateFleet(5, 5, []model.Position{}, rovers, FleetModeSequential, Options{})
+
+	if result.Mode != FleetModeSequential {
+		t.Errorf("Expected mode %v, got %v", FleetModeSequential, result.Mode)
+	}
+	if result.Rovers[0].ID != "a" || result.Rovers[1].ID != "b" {
+		t.Errorf("Expected rover ids a, b, got %s, %s", result.Rovers[0].ID, result.Rovers[1].ID)
+	}
+	assertFleetResult(t, result, []expectedRover{
+		{model.Position{X: 0, Y: 2}, model.North, StatusSuccess},
+		{model.Position{X: 0, Y: 3}, model.South, StatusRoverCollision},
+	})
+}
+
+func TestNavigateFleet_SequentialWaitingRoverBlocks(t *testing.T) {
+	game := NewGame()
+
+	rovers := []RoverMission{
+		{Start: model.Position{X: 0, Y: 0}, Direction: model.East, Commands: "MMMM"},
+		{Start: model.Position{X: 4, Y: 0}, Direction: model.West, Commands: "MMMM"},
+	}
+	result := game.NavigateFleet(5, 5, nil, rovers, FleetModeSequential, Options{})
+
+	assertFleetResult(t, result, []expectedRover{
+		{model.Position{X: 3, Y: 0}, model.East, StatusRoverCollision},
+		{model.Position{X: 4, Y: 0}, model.West, StatusRoverCollision},
+	})
+}
+
+func TestNavigateFleet_Interleaved(t *testing.T) {
+	game := NewGame()
+
+	rovers := []RoverMission{
+		{Start: model.Position{X: 0, Y: 0}, Direction: model.East, Commands: "MMMM"},
+		{Start: model.Position{X: 4, Y: 0}, Direction: model.West, Commands: "MMMM"},
+		{Start: model.Position{X: 0, Y: 4}, Direction: model.South, Commands: "MRM"},
+	}
+	result := game.NavigateFleet(5, 5, []model.Position{{X: 1, Y: 3}}, rovers, FleetModeInterleaved, Options{})
+
+	assertFleetResult(t, result, []expectedRover{
+		{model.Position{X: 2, Y: 0}, model.East, StatusRoverCollision},
+		{model.Position{X: 3, Y: 0}, model.West, StatusRoverCollision},
+		{model.Position{X: 0, Y: 3}, model.West, StatusOutOfBounds},
+	})
+}
+
+func TestNavigateFleet_InterleavedTrace(t *testing.T) {
+	game := NewGame()
+
+	rovers := []RoverMission{
+		{ID: "a", Start: model.Position{X: 0, Y: 0}, Direction: model.North, Commands: "MRM"},
+		{ID: "b", Start: model.Position{X: 2, Y: 2}, Direction: model.South, Commands: "L"},
+	}
+	result := game.NavigateFleet(3, 3, nil, rovers, FleetModeInterleaved, Options{Trace: true})
+
+	if len(result.Rovers[0].Trace) != 3 {
+		t.Errorf("Expected 3 trace steps for rover 0, got %d", len(result.Rovers[0].Trace))
+	}
+	if len(result.Rovers[1].Trace) != 1 {
+		t.Errorf("Expected 1 trace step for rover 1, got %d", len(result.Rovers[1].Trace))
+	}
+	assertFleetResult(t, result, []expectedRover{
+		{model.Position{X: 1, Y: 1}, model.East, StatusSuccess},
+		{model.Position{X: 2, Y: 2}, model.East, StatusSuccess},
+	})
+	// Each step names its rover and the turn it ran in
+	for i, turn := range []int{1, 2, 3} {
+		if step := result.Rovers[0].Trace[i]; step.Rover != "a" || step.Turn != turn {
+			t.Errorf("rover 0 step %d ran as rover %q turn %d, want a turn %d", i, step.Rover, step.Turn, turn)
+		}
+	}
+	if step := result.Rovers[1].Trace[0]; step.Rover != "b" || step.Turn != 1 {
+		t.Errorf("rover 1 step ran as rover %q turn %d, want b turn 1", step.Rover, step.Turn)
+	}
+}
+
+func TestNavigateFleet_SequentialTrace(t *testing.T) {
+	game := NewGame()
+
+	rovers := []RoverMission{
+		{ID: "a", Start: model.Position{X: 0, Y: 0}, Direction: model.North, Commands: "MR"},
+		{ID: "b", Start: model.Position{X: 2, Y: 2}, Direction: model.South, Commands: "L"},
+	}
+	result := game.NavigateFleet(3, 3, nil, rovers, FleetModeSequential, Options{Trace: true})
+
+	// A sequential fleet runs in rover order, the steps carry no turn
+	for i, id := range []string{"a", "b"} {
+		for _, step := range result.Rovers[i].Trace {
+			if step.Rover != id || step.Turn != 0 {
+				t.Errorf("rover %d step %d ran as rover %q turn %d, want %s without a turn", i, step.Index, step.Rover, step.Turn, id)
+			}
+		}
+	}
+}
+
+func TestNavigateFleet_RejectedRovers(t *testing.T) {
+	game := NewGame()
+
+	rovers := []RoverMission{
+		{Start: model.Position{X: 1, Y: 0}, Direction: model.North, Commands: "MX"},
+		{Start: model.Position{X: 0, Y: 0}, Direction: model.East, Commands: "MM"},
+		{Start: model.Position{X: 0, Y: 0}, Direction: model.North, Commands: "M"},
+		{Start: model.Position{X: 2, Y: 2}, Direction: model.West, Commands: "M"},
+	}
+	result := game.NavigateFleet(5, 5, []model.Position{{X: 2, Y: 2}}, rovers, "", Options{})
+
+	if result.Mode != FleetModeSequential {
+		t.Errorf("Expected default mode %v, got %v", FleetModeSequential, result.Mode)
+	}
+	assertFleetResult(t, result, []expectedRover{
+		{model.Position{X: 0, Y: 0}, model.North, StatusInvalidInput},
+		// The rejected rover at (1,0) does not block the path
+		{model.Position{X: 2, Y: 0}, model.East, StatusSuccess},
+		{model.Position{X: 0, Y: 0}, model.North, StatusRoverCollision},
+		{model.Position{X: 2, Y: 2}, model.West, StatusStartOnObstacle},
+	})
+}
+
+func TestNavigateFleet_UnknownMode(t *testing.T) {
+	game := NewGame()
+
+	rovers := []RoverMission{
+		{Start: model.Position{X: 0, Y: 0}, Direction: model.North, Commands: "M"},
+	}
+	result := game.NavigateFleet(5, 5, nil, rovers, FleetMode("random"), Options{})
+
+	assertFleetResult(t, result, []expectedRover{
+		{model.Position{X: 0, Y: 0}, model.North, StatusInvalidInput},
+	})
+}
+
+func TestNavigateFleet_SetsDynamicObstacles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEnv := envMock.NewMockEnvironment(ctrl)
+	expectBoundedNormalize(mockEnv)
+	mockRoverA := roverMock.NewMockRover(ctrl)
+	mockRoverB := roverMock.NewMockRover(ctrl)
+
+	mockRoverA.EXPECT().GetPosition().Return(model.Position{X: 0, Y: 0}).AnyTimes()
+	mockRoverA.EXPECT().GetDirection().Return(model.North).AnyTimes()
+	mockRoverB.EXPECT().GetPosition().Return(model.Position{X: 0, Y: 1}).AnyTimes()
+	mockRoverB.EXPECT().GetDirection().Return(model.South).AnyTimes()
+
+	gomock.InOrder(
+		mockEnv.EXPECT().SetDynamicObstacles([]model.Position{{X: 0, Y: 1}}),
+		mockRoverA.EXPECT().GetTryMovePosition().Return(model.Position{X: 0, Y: 1}),
+		mockEnv.EXPECT().CanMove(model.Position{X: 0, Y: 1}).Return(environment.RoverCollision),
+		mockEnv.EXPECT().SetDynamicObstacles([]model.Position{{X: 0, Y: 0}}),
+		mockRoverB.EXPECT().TurnLeft(),
+	)
+
+	envFactory := func(int, int, []model.Position) environment.Environment {
+		return mockEnv
+	}
+	roverFactory := func(x, y int, direction model.Direction) rover.Rover {
+		if direction == model.North {
+			return mockRoverA
+		}
+		return mockRoverB
+	}
+
+	game := NewGameWithFactories(envFactory, roverFactory)
+	rovers := []RoverMission{
+		{Start: model.Position{X: 0, Y: 0}, Direction: model.North, Commands: "M"},
+		{Start: model.Position{X: 0, Y: 1}, Direction: model.South, Commands: "L"},
+	}
+	result := game.NavigateFleet(5, 5, nil, rovers, FleetModeInterleaved, Options{})
+
+	if result.Rovers[0].Status != StatusRoverCollision {
+		t.Errorf("Expected status %v, got %v", StatusRoverCollision, result.Rovers[0].Status)
+	}
+	if result.Rovers[1].Status != StatusSuccess {
+		t.Errorf("Expected status %v, got %v", StatusSuccess, result.Rovers[1].Status)
+	}
+}
@@ -7,5 +7,9 @@ import (
 )
 
 type Game interface {
-	NavigateRover(size int, obstacles []model.Position, commands string) Result
+	NavigateRover(width, height int, obstacles []model.Position, start model.Position, direction model.Direction, commands string) Result
+	NavigateRoverWithOptions(width, height int, obstacles []model.Position, start model.Position, direction model.Direction, commands string, options Options) ExtendedResult
+	NavigateFleet(width, height int, obstacles []model.Position, rovers []RoverMission, mode FleetMode, options Options) FleetResult
+	// Explore drives the rover to the nearest unexplored cells until the map is explored or a budget is spent
+	Explore(width, height int, obstacles []model.Position, start model.Position, direction model.Direction, maxMoves int, options Options) ExploreResult
 }
@@ -3,12 +3,16 @@ package game
 import (
 	"mars-rover-navigation/src/model"
 	"mars-rover-navigation/src/modules/environment"
+	"mars-rover-navigation/src/modules/knowledge"
+	"mars-rover-navigation/src/modules/planner"
 	"mars-rover-navigation/src/modules/rover"
+	"strings"
 )
 
 type gameImpl struct {
-	envFactory   func(int, []model.Position) environment.Environment
+	envFactory   func(int, int, []model.Position) environment.Environment
 	roverFactory func(int, int, model.Direction) rover.Rover
+	planner      planner.Planner
 }
 
 type Status string
@@ -18,100 +22,335 @@ const (
 	StatusObstacleEncountered Status = "Obstacle encountered"
 	StatusOutOfBounds         Status = "Out of bounds"
 	StatusInvalidInput        Status = "Invalid input"
+	StatusStartOnObstacle     Status = "Start position on obstacle"
+	StatusRoverCollision      Status = "Rover collision"
+	StatusBatteryDepleted     Status = "Battery depleted"
+	StatusImpassableTerrain   Status = "Impassable terrain"
 )
 
+// Statuses lists every Status a rover can end with.
+var Statuses = []Status{
+	StatusSuccess, StatusObstacleEncountered, StatusOutOfBounds, StatusInvalidInput,
+	StatusStartOnObstacle, StatusRoverCollision, StatusBatteryDepleted, StatusImpassableTerrain,
+}
+
 type Result struct {
 	FinalPosition  model.Position  `json:"final_position"`
 	FinalDirection model.Direction `json:"final_direction"`
 	Status         Status          `json:"status"`
+	Grid           model.Size      `json:"grid"`
+	// RemainingCharge is set when the rover runs on an EnergyProfile
+	RemainingCharge *float64 `json:"remaining_charge,omitempty"`
+	// Explored is the fraction of the grid the rover sensed, set when Options.SensorRadius is positive
+	Explored *float64 `json:"explored,omitempty"`
+	// Errors lists every problem of a rover rejected with StatusInvalidInput or StatusStartOnObstacle
+	Errors ValidationErrors `json:"errors,omitempty"`
+}
+
+// Compass is the set of headings a rover can take.
+type Compass string
+
+const (
+	// CompassFour keeps to the N/E/S/W headings and 90 degree turns
+	CompassFour Compass = "four"
+	// CompassEight adds the NE/SE/SW/NW headings, the A and C 45 degree turns and diagonal moves
+	CompassEight Compass = "eight"
+)
+
+type Options struct {
+	// Trace records every executed command in ExtendedResult.Trace
+	Trace bool
+	// Policy decides what a blocked move does, PolicyAbort when empty
+	Policy ObstaclePolicy
+	// SkipLimit is how many blocked moves PolicySkipWithLimit skips before aborting
+	SkipLimit int
+	// Topology connects the grid edges, environment.Bounded when nil
+	Topology environment.Topology
+	// Compass enables the diagonal headings, CompassFour when empty
+	Compass Compass
+	// CornerCutting lets a diagonal move pass between two obstacles touching its corners
+	CornerCutting bool
+	// Energy is the battery of the rover, unlimited when nil
+	Energy *EnergyProfile
+	// Terrain names the terrain class of cells, the rest is plain ground ("")
+	Terrain map[model.Position]string
+	// TerrainClasses replaces environment.TerrainClasses when set
+	TerrainClasses map[string]environment.TerrainClass
+	// SensorRadius gives the rover a belief map of the cells it sensed within this radius, see knowledge.Belief.
+	// The rover knows the whole grid when 0
+	SensorRadius int
+}
+
+type Step struct {
+	Index      int                       `json:"index"`
+	Command    string                    `json:"command"`
+	Before     model.Pose                `json:"before"`
+	After      model.Pose                `json:"after"`
+	MoveStatus environment.CanMoveStatus `json:"move_status,omitempty"`
+	// Terrain is the terrain class of the cell a move entered
+	Terrain string `json:"terrain,omitempty"`
+	// Replan marks a command inserted by PolicyReplan, Index is then the blocked command
+	Replan bool `json:"replan,omitempty"`
+	// Rover is the id of the fleet rover that ran the step
+	Rover string `json:"rover,omitempty"`
+	// Turn is the FleetModeInterleaved turn the step ran in, counted from 1
+	Turn int `json:"turn,omitempty"`
+}
+
+type ExtendedResult struct {
+	Result
+	Trace   []Step        `json:"trace,omitempty"`
+	Blocked []BlockedMove `json:"blocked,omitempty"`
 }
 
 func NewGame() *gameImpl {
 	return &gameImpl{
-		envFactory: func(size int, obstacles []model.Position) environment.Environment {
-			return environment.NewEnvironment(size, obstacles)
+		envFactory: func(width, height int, obstacles []model.Position) environment.Environment {
+			return environment.New(width, height, obstacles)
 		},
 		roverFactory: func(x, y int, direction model.Direction) rover.Rover {
 			return rover.NewRover(x, y, direction)
 		},
+		planner: planner.NewPlanner(planner.DefaultCosts),
 	}
 }
 
-func NewGameWithFactories(envFactory func(int, []model.Position) environment.Environment, roverFactory func(int, int, model.Direction) rover.Rover) *gameImpl {
+func NewGameWithFactories(envFactory func(int, int, []model.Position) environment.Environment, roverFactory func(int, int, model.Direction) rover.Rover) *gameImpl {
 	return &gameImpl{
 		envFactory:   envFactory,
 		roverFactory: roverFactory,
+		planner:      planner.NewPlanner(planner.DefaultCosts),
 	}
 }
 
-func isValidInputs(size int, obstacles []model.Position, commands string) bool {
-	// Check if size is valid (positive)
-	if size <= 0 {
-		return false
+func isWithinBounds(position model.Position, width, height int) bool {
+	return position.X >= 0 && position.X < width && position.Y >= 0 && position.Y < height
+}
+
+func isValidDirection(direction model.Direction) bool {
+	switch direction {
+	case model.North, model.East, model.South, model.West:
+		return true
 	}
+	return direction.IsDiagonal()
+}
 
-	// Check if obstacles are within bounds
-	for _, obstacle := range obstacles {
-		if obstacle.X < 0 || obstacle.X >= size || obstacle.Y < 0 || obstacle.Y >= size {
-			return false
-		}
+// isValidCompass rejects diagonal headings and half turns outside CompassEight.
+func isValidCompass(options Options, direction model.Direction, commands string) bool {
+	switch options.Compass {
+	case CompassEight:
+		return true
+	case "", CompassFour:
+		return !direction.IsDiagonal() && !strings.ContainsAny(commands, "AC")
 	}
+	return false
+}
 
-	// Check if commands contain only valid characters
-	for _, cmd := range commands {
-		if cmd != 'L' && cmd != 'R' && cmd != 'M' {
-			return false
-		}
+func (e *gameImpl) NavigateRover(width, height int, obstacles []model.Position, start model.Position, direction model.Direction, commands string) Result {
+	return e.NavigateRoverWithOptions(width, height, obstacles, start, direction, commands, Options{}).Result
+}
+
+func (e *gameImpl) NavigateRoverWithOptions(width, height int, obstacles []model.Position, start model.Position, direction model.Direction, commands string, options Options) ExtendedResult {
+	grid := model.Size{Width: width, Height: height}
+
+	errs := append(validateInputs(width, height, obstacles, start, direction, commands), validateOptions(options, direction, commands)...)
+	if len(errs) > 0 {
+		return rejectedResult(errs.Status(), start, direction, grid, errs)
+	}
+
+	var env environment.Environment = e.newEnvironment(width, height, obstacles, options)
+	runner := e.newRoverRunner(start, direction, commands, options)
+	runner.observe(env)
+
+	for !runner.done() {
+		runner.step(env)
 	}
 
-	return true
+	return runner.result(grid)
 }
 
-func (e *gameImpl) NavigateRover(size int, obstacles []model.Position, commands string) Result {
+func (e *gameImpl) newEnvironment(width, height int, obstacles []model.Position, options Options) environment.Environment {
+	env := e.envFactory(width, height, obstacles)
+	if options.Topology != nil {
+		env.SetTopology(options.Topology)
+	}
+	if options.CornerCutting {
+		env.SetCornerCutting(true)
+	}
+	if len(options.Terrain) > 0 {
+		env.SetTerrain(options.Terrain)
+	}
+	if options.TerrainClasses != nil {
+		env.SetTerrainClasses(options.TerrainClasses)
+	}
+	if roverType := options.roverType(); roverType != "" {
+		env.SetRoverType(roverType)
+	}
+	return env
+}
 
-	if !isValidInputs(size, obstacles, commands) {
-		return Result{
+// rejectedResult is returned for a rover that never started moving.
+func rejectedResult(status Status, start model.Position, direction model.Direction, grid model.Size, errs ValidationErrors) ExtendedResult {
+	if status == StatusInvalidInput {
+		return ExtendedResult{Result: Result{
 			FinalPosition:  model.Position{X: 0, Y: 0},
 			FinalDirection: model.Direction("N"),
-			Status:         StatusInvalidInput,
-		}
+			Status:         status,
+			Grid:           grid,
+			Errors:         errs,
+		}}
 	}
 
-	var env environment.Environment = e.envFactory(size, obstacles)
-	var rover rover.Rover = e.roverFactory(0, 0, "N")
+	// The start pose was valid but the cell is taken
+	return ExtendedResult{Result: Result{
+		FinalPosition:  start,
+		FinalDirection: direction,
+		Status:         status,
+		Grid:           grid,
+		Errors:         errs,
+	}}
+}
 
-	for _, cmd := range commands {
-		switch cmd {
-		case 'M':
-			expectNewPosition := rover.GetTryMovePosition()
-			canMoveStatus := env.CanMove(expectNewPosition)
+// roverRunner executes the commands of a single rover one step at a time.
+type roverRunner struct {
+	rover    rover.Rover
+	commands string
+	options  Options
+	replan   replanFunc
+	// id and round tag the trace steps of a fleet rover, round is the current interleaved turn
+	id    string
+	round int
 
-			switch canMoveStatus {
-			case environment.Success:
-				rover.Move()
-			case environment.ObstacleEncountered:
-				return Result{
-					FinalPosition:  rover.GetPosition(),
-					FinalDirection: rover.GetDirection(),
-					Status:         StatusObstacleEncountered,
-				}
-			case environment.OutOfBounds:
-				return Result{
-					FinalPosition:  rover.GetPosition(),
-					FinalDirection: rover.GetDirection(),
-					Status:         StatusOutOfBounds,
+	next   int
+	status Status
+	trace  []Step
+	charge float64
+
+	blocked []BlockedMove
+	skipped int
+	// belief is the map of the sensed cells, nil when the rover knows the whole grid
+	belief knowledge.Belief
+	// pending holds the replanned commands that run before commands[next]
+	pending     string
+	replanIndex int
+}
+
+func (e *gameImpl) newRoverRunner(start model.Position, direction model.Direction, commands string, options Options) *roverRunner {
+	runner := &roverRunner{
+		rover:    e.roverFactory(start.X, start.Y, direction),
+		commands: commands,
+		options:  options,
+		replan:   e.replanToNextWaypoint,
+	}
+	if options.Energy != nil {
+		runner.charge = options.Energy.Charge
+	}
+	return runner
+}
+
+func (r *roverRunner) done() bool {
+	return r.status != "" || (r.next >= len(r.commands) && r.pending == "")
+}
+
+func (r *roverRunner) step(env environment.Environment) {
+	var index int
+	var cmd rune
+	replanned := r.pending != ""
+	if replanned {
+		index, cmd = r.replanIndex, rune(r.pending[0])
+		r.pending = r.pending[1:]
+	} else {
+		index, cmd = r.next, rune(r.commands[r.next])
+		r.next++
+	}
+
+	var step Step
+	if r.options.Trace {
+		step = Step{Index: index, Command: string(cmd), Before: poseOf(r.rover), Replan: replanned, Rover: r.id, Turn: r.round}
+	}
+
+	switch cmd {
+	case 'M', 'B':
+		var tryPosition model.Position
+		if cmd == 'B' {
+			tryPosition = r.rover.GetTryMoveBackwardPosition()
+		} else {
+			tryPosition = r.rover.GetTryMovePosition()
+		}
+		// A wrapping topology brings the move back onto the grid
+		expectNewPosition, _ := env.Normalize(tryPosition)
+		var canMoveStatus environment.CanMoveStatus
+		if r.options.Compass == CompassEight {
+			// Only the eight-direction compass has diagonal steps to check corners for
+			canMoveStatus = env.CanMoveFrom(r.rover.GetPosition(), tryPosition)
+		} else {
+			canMoveStatus = env.CanMove(expectNewPosition)
+		}
+		step.MoveStatus = canMoveStatus
+
+		if canMoveStatus == environment.Success {
+			if r.drain(r.moveCost(env, cmd, expectNewPosition)) {
+				r.rover.MoveTo(expectNewPosition)
+				r.sense()
+				if r.options.Trace {
+					step.Terrain = env.TerrainAt(expectNewPosition)
 				}
+			} else {
+				// The battery refused the move, the rover did not leave its cell
+				step.MoveStatus = ""
 			}
-		case 'L':
-			rover.TurnLeft()
-		case 'R':
-			rover.TurnRight()
+		} else {
+			r.block(env, BlockedMove{Index: index, Command: string(cmd), Position: expectNewPosition, Status: canMoveStatus}, replanned)
 		}
+	default:
+		if r.drain(r.turnCost(cmd)) {
+			r.turn(cmd)
+		}
+	}
+
+	if r.options.Trace {
+		step.After = poseOf(r.rover)
+		r.trace = append(r.trace, step)
 	}
+}
 
-	return Result{
-		FinalPosition:  rover.GetPosition(),
-		FinalDirection: rover.GetDirection(),
-		Status:         StatusSuccess,
+func (r *roverRunner) turn(cmd rune) {
+	switch cmd {
+	case 'L':
+		r.rover.TurnLeft()
+	case 'R':
+		r.rover.TurnRight()
+	case 'U':
+		r.rover.UTurn()
+	case 'A':
+		r.rover.TurnHalfLeft()
+	case 'C':
+		r.rover.TurnHalfRight()
 	}
 }
+
+func (r *roverRunner) result(grid model.Size) ExtendedResult {
+	status := r.status
+	if status == "" {
+		status = StatusSuccess
+	}
+
+	return ExtendedResult{
+		Result: Result{
+			FinalPosition:  r.rover.GetPosition(),
+			FinalDirection: r.rover.GetDirection(),
+			Status:         status,
+			Grid:           grid,
+
+			RemainingCharge: r.remainingCharge(),
+			Explored:        r.explored(),
+		},
+		Trace:   r.trace,
+		Blocked: r.blocked,
+	}
+}
+
+func poseOf(rover rover.Rover) model.Pose {
+	position := rover.GetPosition()
+	return model.Pose{X: position.X, Y: position.Y, Direction: rover.GetDirection()}
+}
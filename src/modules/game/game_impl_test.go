@@ -22,10 +22,13 @@ func TestNewGame(t *testing.T) {
 	if game.roverFactory == nil {
 		t.Error("roverFactory should not be nil")
 	}
+	if game.planner == nil {
+		t.Error("planner should not be nil")
+	}
 
 	// Test that factories actually work and create proper instances
 	testObstacles := []model.Position{{X: 1, Y: 1}}
-	env := game.envFactory(5, testObstacles)
+	env := game.envFactory(5, 5, testObstacles)
 	if env == nil {
 		t.Error("envFactory should create a non-nil environment")
 	}
@@ -48,8 +51,8 @@ func TestNewGame_FactoriesCreateDifferentInstances(t *testing.T) {
 	game := NewGame()
 
 	// Create two environments with same parameters
-	env1 := game.envFactory(5, []model.Position{{X: 1, Y: 1}})
-	env2 := game.envFactory(5, []model.Position{{X: 1, Y: 1}})
+	env1 := game.envFactory(5, 5, []model.Position{{X: 1, Y: 1}})
+	env2 := game.envFactory(5, 5, []model.Position{{X: 1, Y: 1}})
 
 	// They should be different instances
 	if env1 == env2 {
@@ -70,7 +73,7 @@ func TestNewGameWithFactories(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	envFactory := func(int, []model.Position) environment.Environment {
+	envFactory := func(int, int, []model.Position) environment.Environment {
 		return envMock.NewMockEnvironment(ctrl)
 	}
 	roverFactory := func(int, int, model.Direction) rover.Rover {
@@ -89,91 +92,198 @@ func TestNewGameWithFactories(t *testing.T) {
 	}
 }
 
-func TestIsValidInputs(t *testing.T) {
+func TestValidateInputs(t *testing.T) {
+	origin := model.Position{X: 0, Y: 0}
+
 	tests := []struct {
-		name      string
-		size      int
-		obstacles []model.Position
-		commands  string
-		expected  bool
+		name           string
+		size           int
+		obstacles      []model.Position
+		start          model.Position
+		direction      model.Direction
+		commands       string
+		expected       bool
+		expectedStatus Status
 	}{
 		{
-			name:      "valid inputs",
-			size:      5,
-			obstacles: []model.Position{{X: 1, Y: 1}, {X: 2, Y: 2}},
-			commands:  "LRMM",
-			expected:  true,
+			name:           "valid inputs",
+			size:           5,
+			obstacles:      []model.Position{{X: 1, Y: 1}, {X: 2, Y: 2}},
+			start:          origin,
+			direction:      model.North,
+			commands:       "LRMM",
+			expected:       true,
+			expectedStatus: StatusSuccess,
 		},
 		{
-			name:      "zero size",
-			size:      0,
-			obstacles: []model.Position{},
-			commands:  "LRM",
-			expected:  false,
+			name:           "backward and u-turn commands",
+			size:           5,
+			start:          origin,
+			direction:      model.North,
+			commands:       "MBUM",
+			expected:       true,
+			expectedStatus: StatusSuccess,
 		},
 		{
-			name:      "negative size",
-			size:      -1,
-			obstacles: []model.Position{},
-			commands:  "LRM",
-			expected:  false,
+			name:           "zero size",
+			size:           0,
+			obstacles:      []model.Position{},
+			start:          origin,
+			direction:      model.North,
+			commands:       "LRM",
+			expected:       false,
+			expectedStatus: StatusInvalidInput,
 		},
 		{
-			name:      "obstacle out of bounds - negative X",
-			size:      5,
-			obstacles: []model.Position{{X: -1, Y: 1}},
-			commands:  "LRM",
-			expected:  false,
+			name:           "negative size",
+			size:           -1,
+			obstacles:      []model.Position{},
+			start:          origin,
+			direction:      model.North,
+			commands:       "LRM",
+			expected:       false,
+			expectedStatus: StatusInvalidInput,
 		},
 		{
-			name:      "obstacle out of bounds - negative Y",
-			size:      5,
-			obstacles: []model.Position{{X: 1, Y: -1}},
-			commands:  "LRM",
-			expected:  false,
+			name:           "obstacle out of bounds - negative X",
+			size:           5,
+			obstacles:      []model.Position{{X: -1, Y: 1}},
+			start:          origin,
+			direction:      model.North,
+			commands:       "LRM",
+			expected:       false,
+			expectedStatus: StatusInvalidInput,
 		},
 		{
-			name:      "obstacle out of bounds - X >= size",
-			size:      5,
-			obstacles: []model.Position{{X: 5, Y: 1}},
-			commands:  "LRM",
-			expected:  false,
+			name:           "obstacle out of bounds - negative Y",
+			size:           5,
+			obstacles:      []model.Position{{X: 1, Y: -1}},
+			start:          origin,
+			direction:      model.North,
+			commands:       "LRM",
+			expected:       false,
+			expectedStatus: StatusInvalidInput,
 		},
 		{
-			name:      "obstacle out of bounds - Y >= size",
-			size:      5,
-			obstacles: []model.Position{{X: 1, Y: 5}},
-			commands:  "LRM",
-			expected:  false,
+			name:           "obstacle out of bounds - X >= size",
+			size:           5,
+			obstacles:      []model.Position{{X: 5, Y: 1}},
+			start:          origin,
+			direction:      model.North,
+			commands:       "LRM",
+			expected:       false,
+			expectedStatus: StatusInvalidInput,
 		},
 		{
-			name:      "invalid command character",
-			size:      5,
-			obstacles: []model.Position{},
-			commands:  "LRMX",
-			expected:  false,
+			name:           "obstacle out of bounds - Y >= size",
+			size:           5,
+			obstacles:      []model.Position{{X: 1, Y: 5}},
+			start:          origin,
+			direction:      model.North,
+			commands:       "LRM",
+			expected:       false,
+			expectedStatus: StatusInvalidInput,
 		},
 		{
-			name:      "empty commands",
-			size:      5,
-			obstacles: []model.Position{},
-			commands:  "",
-			expected:  true,
+			name:           "invalid command character",
+			size:           5,
+			obstacles:      []model.Position{},
+			start:          origin,
+			direction:      model.North,
+			commands:       "LRMX",
+			expected:       false,
+			expectedStatus: StatusInvalidInput,
 		},
 		{
-			name:      "no obstacles",
-			size:      5,
-			obstacles: []model.Position{},
-			commands:  "LRMM",
-			expected:  true,
+			name:           "empty commands",
+			size:           5,
+			obstacles:      []model.Position{},
+			start:          origin,
+			direction:      model.North,
+			commands:       "",
+			expected:       true,
+			expectedStatus: StatusSuccess,
+		},
+		{
+			name:           "no obstacles",
+			size:           5,
+			obstacles:      []model.Position{},
+			start:          origin,
+			direction:      model.North,
+			commands:       "LRMM",
+			expected:       true,
+			expectedStatus: StatusSuccess,
+		},
+		{
+			name:           "start inside grid",
+			size:           5,
+			obstacles:      []model.Position{{X: 1, Y: 1}},
+			start:          model.Position{X: 4, Y: 2},
+			direction:      model.West,
+			commands:       "MM",
+			expected:       true,
+			expectedStatus: StatusSuccess,
+		},
+		{
+			name:           "start out of bounds - negative X",
+			size:           5,
+			obstacles:      []model.Position{},
+			start:          model.Position{X: -1, Y: 0},
+			direction:      model.North,
+			commands:       "M",
+			expected:       false,
+			expectedStatus: StatusInvalidInput,
+		},
+		{
+			name:           "start out of bounds - Y >= size",
+			size:           5,
+			obstacles:      []model.Position{},
+			start:          model.Position{X: 0, Y: 5},
+			direction:      model.North,
+			commands:       "M",
+			expected:       false,
+			expectedStatus: StatusInvalidInput,
+		},
+		{
+			name:           "unknown direction",
+			size:           5,
+			obstacles:      []model.Position{},
+			start:          origin,
+			direction:      model.Direction("X"),
+			commands:       "M",
+			expected:       false,
+			expectedStatus: StatusInvalidInput,
+		},
+		{
+			name:           "start on obstacle",
+			size:           5,
+			obstacles:      []model.Position{{X: 2, Y: 3}},
+			start:          model.Position{X: 2, Y: 3},
+			direction:      model.North,
+			commands:       "M",
+			expected:       false,
+			expectedStatus: StatusStartOnObstacle,
+		},
+		{
+			name:           "invalid command wins over start on obstacle",
+			size:           5,
+			obstacles:      []model.Position{{X: 2, Y: 3}},
+			start:          model.Position{X: 2, Y: 3},
+			direction:      model.North,
+			commands:       "MX",
+			expected:       false,
+			expectedStatus: StatusInvalidInput,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := isValidInputs(tt.size, tt.obstacles, tt.commands)
-			if result != tt.expected {
-				t.Errorf("isValidInputs() = %v, expected %v", result, tt.expected)
+			errs := validateInputs(tt.size, tt.size, tt.obstacles, tt.start, tt.direction, tt.commands)
+			if result := len(errs) == 0; result != tt.expected {
+				t.Errorf("validateInputs() = %v, expected valid %v", errs, tt.expected)
+			}
+			if status := errs.Status(); len(errs) > 0 && status != tt.expectedStatus {
+				t.Errorf("validateInputs() status = %v, expected %v", status, tt.expectedStatus)
 			}
 		})
 	}
@@ -184,6 +294,7 @@ func TestNavigateRover_Success(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockEnv := envMock.NewMockEnvironment(ctrl)
+	expectBoundedNormalize(mockEnv)
 	mockRov := roverMock.NewMockRover(ctrl)
 
 	// Set up expectations
@@ -191,11 +302,11 @@ func TestNavigateRover_Success(t *testing.T) {
 	mockRov.EXPECT().TurnRight().Times(1)
 	mockRov.EXPECT().GetTryMovePosition().Return(model.Position{X: 0, Y: 1}).Times(2)
 	mockEnv.EXPECT().CanMove(model.Position{X: 0, Y: 1}).Return(environment.Success).Times(2)
-	mockRov.EXPECT().Move().Times(2)
+	mockRov.EXPECT().MoveTo(model.Position{X: 0, Y: 1}).Times(2)
 	mockRov.EXPECT().GetPosition().Return(model.Position{X: 0, Y: 1})
 	mockRov.EXPECT().GetDirection().Return(model.Direction("N"))
 
-	envFactory := func(int, []model.Position) environment.Environment {
+	envFactory := func(int, int, []model.Position) environment.Environment {
 		return mockEnv
 	}
 	roverFactory := func(int, int, model.Direction) rover.Rover {
@@ -203,7 +314,7 @@ func TestNavigateRover_Success(t *testing.T) {
 	}
 
 	game := NewGameWithFactories(envFactory, roverFactory)
-	result := game.NavigateRover(5, []model.Position{}, "LRMM")
+	result := game.NavigateRover(5, 5, []model.Position{}, model.Position{X: 0, Y: 0}, model.North, "LRMM")
 
 	if result.Status != StatusSuccess {
 		t.Errorf("Expected status %v, got %v", StatusSuccess, result.Status)
@@ -215,6 +326,7 @@ func TestNavigateRover_ObstacleEncountered(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockEnv := envMock.NewMockEnvironment(ctrl)
+	expectBoundedNormalize(mockEnv)
 	mockRov := roverMock.NewMockRover(ctrl)
 
 	// Set up expectations
@@ -223,7 +335,7 @@ func TestNavigateRover_ObstacleEncountered(t *testing.T) {
 	mockRov.EXPECT().GetPosition().Return(model.Position{X: 1, Y: 1})
 	mockRov.EXPECT().GetDirection().Return(model.Direction("E"))
 
-	envFactory := func(int, []model.Position) environment.Environment {
+	envFactory := func(int, int, []model.Position) environment.Environment {
 		return mockEnv
 	}
 	roverFactory := func(int, int, model.Direction) rover.Rover {
@@ -231,7 +343,7 @@ func TestNavigateRover_ObstacleEncountered(t *testing.T) {
 	}
 
 	game := NewGameWithFactories(envFactory, roverFactory)
-	result := game.NavigateRover(5, []model.Position{{X: 2, Y: 1}}, "M")
+	result := game.NavigateRover(5, 5, []model.Position{{X: 2, Y: 1}}, model.Position{X: 0, Y: 0}, model.North, "M")
 
 	if result.Status != StatusObstacleEncountered {
 		t.Errorf("Expected status %v, got %v", StatusObstacleEncountered, result.Status)
@@ -251,6 +363,7 @@ func TestNavigateRover_OutOfBounds(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockEnv := envMock.NewMockEnvironment(ctrl)
+	expectBoundedNormalize(mockEnv)
 	mockRov := roverMock.NewMockRover(ctrl)
 
 	// Set up expectations
@@ -259,7 +372,7 @@ func TestNavigateRover_OutOfBounds(t *testing.T) {
 	mockRov.EXPECT().GetPosition().Return(model.Position{X: 4, Y: 4})
 	mockRov.EXPECT().GetDirection().Return(model.Direction("N"))
 
-	envFactory := func(int, []model.Position) environment.Environment {
+	envFactory := func(int, int, []model.Position) environment.Environment {
 		return mockEnv
 	}
 	roverFactory := func(int, int, model.Direction) rover.Rover {
@@ -267,7 +380,7 @@ func TestNavigateRover_OutOfBounds(t *testing.T) {
 	}
 
 	game := NewGameWithFactories(envFactory, roverFactory)
-	result := game.NavigateRover(5, []model.Position{}, "M")
+	result := game.NavigateRover(5, 5, []model.Position{}, model.Position{X: 0, Y: 0}, model.North, "M")
 
 	if result.Status != StatusOutOfBounds {
 		t.Errorf("Expected status %v, got %v", StatusOutOfBounds, result.Status)
@@ -287,13 +400,14 @@ func TestNavigateRover_EmptyCommands(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockEnv := envMock.NewMockEnvironment(ctrl)
+	expectBoundedNormalize(mockEnv)
 	mockRov := roverMock.NewMockRover(ctrl)
 
 	// Set up expectations - no commands should be called
 	mockRov.EXPECT().GetPosition().Return(model.Position{X: 0, Y: 0})
 	mockRov.EXPECT().GetDirection().Return(model.Direction("N"))
 
-	envFactory := func(int, []model.Position) environment.Environment {
+	envFactory := func(int, int, []model.Position) environment.Environment {
 		return mockEnv
 	}
 	roverFactory := func(int, int, model.Direction) rover.Rover {
@@ -301,7 +415,7 @@ func TestNavigateRover_EmptyCommands(t *testing.T) {
 	}
 
 	game := NewGameWithFactories(envFactory, roverFactory)
-	result := game.NavigateRover(5, []model.Position{}, "")
+	result := game.NavigateRover(5, 5, []model.Position{}, model.Position{X: 0, Y: 0}, model.North, "")
 
 	if result.Status != StatusSuccess {
 		t.Errorf("Expected status %v, got %v", StatusSuccess, result.Status)
@@ -313,6 +427,7 @@ func TestNavigateRover_ComplexScenario(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockEnv := envMock.NewMockEnvironment(ctrl)
+	expectBoundedNormalize(mockEnv)
 	mockRov := roverMock.NewMockRover(ctrl)
 
 	// Set up expectations for "LLRRMMM"
@@ -320,11 +435,11 @@ func TestNavigateRover_ComplexScenario(t *testing.T) {
 	mockRov.EXPECT().TurnRight().Times(2)
 	mockRov.EXPECT().GetTryMovePosition().Return(model.Position{X: 2, Y: 1}).Times(3)
 	mockEnv.EXPECT().CanMove(model.Position{X: 2, Y: 1}).Return(environment.Success).Times(3)
-	mockRov.EXPECT().Move().Times(3)
+	mockRov.EXPECT().MoveTo(model.Position{X: 2, Y: 1}).Times(3)
 	mockRov.EXPECT().GetPosition().Return(model.Position{X: 2, Y: 2})
 	mockRov.EXPECT().GetDirection().Return(model.Direction("S"))
 
-	envFactory := func(int, []model.Position) environment.Environment {
+	envFactory := func(int, int, []model.Position) environment.Environment {
 		return mockEnv
 	}
 	roverFactory := func(int, int, model.Direction) rover.Rover {
@@ -332,7 +447,7 @@ func TestNavigateRover_ComplexScenario(t *testing.T) {
 	}
 
 	game := NewGameWithFactories(envFactory, roverFactory)
-	result := game.NavigateRover(10, []model.Position{{X: 1, Y: 1}}, "LLRRMMM")
+	result := game.NavigateRover(10, 10, []model.Position{{X: 1, Y: 1}}, model.Position{X: 0, Y: 0}, model.North, "LLRRMMM")
 
 	if result.Status != StatusSuccess {
 		t.Errorf("Expected status %v, got %v", StatusSuccess, result.Status)
@@ -344,6 +459,7 @@ func TestNavigateRover_StopOnFirstObstacle(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockEnv := envMock.NewMockEnvironment(ctrl)
+	expectBoundedNormalize(mockEnv)
 	mockRov := roverMock.NewMockRover(ctrl)
 
 	// Set up expectations for "MMLR" - should stop after second M hits obstacle
@@ -351,7 +467,7 @@ func TestNavigateRover_StopOnFirstObstacle(t *testing.T) {
 		// First M - success
 		mockRov.EXPECT().GetTryMovePosition().Return(model.Position{X: 1, Y: 2}),
 		mockEnv.EXPECT().CanMove(model.Position{X: 1, Y: 2}).Return(environment.Success),
-		mockRov.EXPECT().Move(),
+		mockRov.EXPECT().MoveTo(model.Position{X: 1, Y: 2}),
 		// Second M - obstacle encountered
 		mockRov.EXPECT().GetTryMovePosition().Return(model.Position{X: 1, Y: 3}),
 		mockEnv.EXPECT().CanMove(model.Position{X: 1, Y: 3}).Return(environment.ObstacleEncountered),
@@ -360,7 +476,7 @@ func TestNavigateRover_StopOnFirstObstacle(t *testing.T) {
 		mockRov.EXPECT().GetDirection().Return(model.Direction("N")),
 	)
 
-	envFactory := func(int, []model.Position) environment.Environment {
+	envFactory := func(int, int, []model.Position) environment.Environment {
 		return mockEnv
 	}
 	roverFactory := func(int, int, model.Direction) rover.Rover {
@@ -368,7 +484,7 @@ func TestNavigateRover_StopOnFirstObstacle(t *testing.T) {
 	}
 
 	game := NewGameWithFactories(envFactory, roverFactory)
-	result := game.NavigateRover(5, []model.Position{{X: 1, Y: 3}}, "MMLR")
+	result := game.NavigateRover(5, 5, []model.Position{{X: 1, Y: 3}}, model.Position{X: 0, Y: 0}, model.North, "MMLR")
 
 	if result.Status != StatusObstacleEncountered {
 		t.Errorf("Expected status %v, got %v", StatusObstacleEncountered, result.Status)
@@ -386,37 +502,59 @@ func TestNavigateRover_InvalidInput(t *testing.T) {
 		name      string
 		size      int
 		obstacles []model.Position
+		start     model.Position
+		direction model.Direction
 		commands  string
 	}{
 		{
 			name:      "negative size",
 			size:      -1,
 			obstacles: []model.Position{},
+			direction: model.North,
 			commands:  "LRM",
 		},
 		{
 			name:      "zero size",
 			size:      0,
 			obstacles: []model.Position{},
+			direction: model.North,
 			commands:  "LRM",
 		},
 		{
 			name:      "obstacle out of bounds",
 			size:      5,
 			obstacles: []model.Position{{X: 5, Y: 1}},
+			direction: model.North,
 			commands:  "LRM",
 		},
 		{
 			name:      "invalid command character",
 			size:      5,
 			obstacles: []model.Position{},
+			direction: model.North,
 			commands:  "LRMX",
 		},
+		{
+			name:      "start out of bounds",
+			size:      5,
+			obstacles: []model.Position{},
+			start:     model.Position{X: 2, Y: 7},
+			direction: model.East,
+			commands:  "M",
+		},
+		{
+			name:      "invalid direction",
+			size:      5,
+			obstacles: []model.Position{},
+			start:     model.Position{X: 2, Y: 2},
+			direction: model.Direction("Q"),
+			commands:  "M",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := game.NavigateRover(tt.size, tt.obstacles, tt.commands)
+			result := game.NavigateRover(tt.size, tt.size, tt.obstacles, tt.start, tt.direction, tt.commands)
 
 			if result.Status != StatusInvalidInput {
 				t.Errorf("Expected status %v, got %v", StatusInvalidInput, result.Status)
@@ -432,3 +570,325 @@ func TestNavigateRover_InvalidInput(t *testing.T) {
 		})
 	}
 }
+
+func TestNavigateRover_StartPosition(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEnv := envMock.NewMockEnvironment(ctrl)
+	expectBoundedNormalize(mockEnv)
+	mockRov := roverMock.NewMockRover(ctrl)
+
+	mockRov.EXPECT().GetTryMovePosition().Return(model.Position{X: 2, Y: 3})
+	mockEnv.EXPECT().CanMove(model.Position{X: 2, Y: 3}).Return(environment.Success)
+	mockRov.EXPECT().MoveTo(model.Position{X: 2, Y: 3})
+	mockRov.EXPECT().GetPosition().Return(model.Position{X: 2, Y: 3})
+	mockRov.EXPECT().GetDirection().Return(model.West)
+
+	envFactory := func(int, int, []model.Position) environment.Environment {
+		return mockEnv
+	}
+
+	var gotX, gotY int
+	var gotDirection model.Direction
+	roverFactory := func(x, y int, direction model.Direction) rover.Rover {
+		gotX, gotY, gotDirection = x, y, direction
+		return mockRov
+	}
+
+	game := NewGameWithFactories(envFactory, roverFactory)
+	result := game.NavigateRover(5, 5, []model.Position{}, model.Position{X: 3, Y: 3}, model.West, "M")
+
+	if gotX != 3 || gotY != 3 || gotDirection != model.West {
+		t.Errorf("Expected rover created at (3,3) facing W, got (%d,%d) facing %v", gotX, gotY, gotDirection)
+	}
+	if result.Status != StatusSuccess {
+		t.Errorf("Expected status %v, got %v", StatusSuccess, result.Status)
+	}
+}
+
+func TestNavigateRover_StartOnObstacle(t *testing.T) {
+	game := NewGame()
+
+	start := model.Position{X: 1, Y: 2}
+	result := game.NavigateRover(5, 5, []model.Position{{X: 1, Y: 2}}, start, model.South, "M")
+
+	if result.Status != StatusStartOnObstacle {
+		t.Errorf("Expected status %v, got %v", StatusStartOnObstacle, result.Status)
+	}
+	if result.FinalPosition != start {
+		t.Errorf("Expected position %v, got %v", start, result.FinalPosition)
+	}
+	if result.FinalDirection != model.South {
+		t.Errorf("Expected direction %v, got %v", model.South, result.FinalDirection)
+	}
+}
+
+func TestValidateInputs_Rectangular(t *testing.T) {
+	tests := []struct {
+		name      string
+		width     int
+		height    int
+		obstacles []model.Position
+		start     model.Position
+		expected  bool
+	}{
+		{
+			name:      "wide grid with obstacle near east edge",
+			width:     20,
+			height:    5,
+			obstacles: []model.Position{{X: 19, Y: 2}},
+			start:     model.Position{X: 15, Y: 4},
+			expected:  true,
+		},
+		{
+			name:      "obstacle Y beyond height",
+			width:     20,
+			height:    5,
+			obstacles: []model.Position{{X: 3, Y: 5}},
+			expected:  false,
+		},
+		{
+			name:     "start X beyond width",
+			width:    2,
+			height:   10,
+			start:    model.Position{X: 2, Y: 0},
+			expected: false,
+		},
+		{
+			name:     "zero height",
+			width:    5,
+			height:   0,
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validateInputs(tt.width, tt.height, tt.obstacles, tt.start, model.North, "M")
+			if result := len(errs) == 0; result != tt.expected {
+				t.Errorf("validateInputs() = %v, expected valid %v", errs, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNavigateRover_RectangularGrid(t *testing.T) {
+	game := NewGame()
+
+	result := game.NavigateRover(20, 5, []model.Position{}, model.Position{X: 0, Y: 0}, model.East, "MMMMMMMMMMMMMMMMMMM")
+	if result.Status != StatusSuccess {
+		t.Errorf("Expected status %v, got %v", StatusSuccess, result.Status)
+	}
+	if result.FinalPosition != (model.Position{X: 19, Y: 0}) {
+		t.Errorf("Expected position (19,0), got %v", result.FinalPosition)
+	}
+	if result.Grid != (model.Size{Width: 20, Height: 5}) {
+		t.Errorf("Expected grid 20x5, got %v", result.Grid)
+	}
+
+	result = game.NavigateRover(20, 5, []model.Position{}, model.Position{X: 0, Y: 0}, model.North, "MMMMM")
+	if result.Status != StatusOutOfBounds {
+		t.Errorf("Expected status %v, got %v", StatusOutOfBounds, result.Status)
+	}
+	if result.FinalPosition != (model.Position{X: 0, Y: 4}) {
+		t.Errorf("Expected position (0,4), got %v", result.FinalPosition)
+	}
+}
+
+func TestNavigateRoverWithOptions_Trace(t *testing.T) {
+	game := NewGame()
+
+	result := game.NavigateRoverWithOptions(5, 5, []model.Position{{X: 1, Y: 2}}, model.Position{X: 0, Y: 0}, model.North, "MMRM", Options{Trace: true})
+
+	if result.Status != StatusObstacleEncountered {
+		t.Errorf("Expected status %v, got %v", StatusObstacleEncountered, result.Status)
+	}
+
+	expectedTrace := []Step{
+		{Index: 0, Command: "M", Before: model.Pose{X: 0, Y: 0, Direction: model.North}, After: model.Pose{X: 0, Y: 1, Direction: model.North}, MoveStatus: environment.Success},
+		{Index: 1, Command: "M", Before: model.Pose{X: 0, Y: 1, Direction: model.North}, After: model.Pose{X: 0, Y: 2, Direction: model.North}, MoveStatus: environment.Success},
+		{Index: 2, Command: "R", Before: model.Pose{X: 0, Y: 2, Direction: model.North}, After: model.Pose{X: 0, Y: 2, Direction: model.East}},
+		{Index: 3, Command: "M", Before: model.Pose{X: 0, Y: 2, Direction: model.East}, After: model.Pose{X: 0, Y: 2, Direction: model.East}, MoveStatus: environment.ObstacleEncountered},
+	}
+	if len(result.Trace) != len(expectedTrace) {
+		t.Fatalf("Expected %d trace steps, got %d: %+v", len(expectedTrace), len(result.Trace), result.Trace)
+	}
+	for i, step := range result.Trace {
+		if step != expectedTrace[i] {
+			t.Errorf("Trace[%d] = %+v, expected %+v", i, step, expectedTrace[i])
+		}
+	}
+}
+
+func TestNavigateRoverWithOptions_TraceOutOfBounds(t *testing.T) {
+	game := NewGame()
+
+	result := game.NavigateRoverWithOptions(2, 2, []model.Position{}, model.Position{X: 1, Y: 1}, model.East, "LM", Options{Trace: true})
+
+	if result.Status != StatusOutOfBounds {
+		t.Errorf("Expected status %v, got %v", StatusOutOfBounds, result.Status)
+	}
+	last := result.Trace[len(result.Trace)-1]
+	if last.Index != 1 || last.MoveStatus != environment.OutOfBounds {
+		t.Errorf("Expected last step to be index 1 out of bounds, got %+v", last)
+	}
+}
+
+func TestNavigateRoverWithOptions_NoTrace(t *testing.T) {
+	game := NewGame()
+
+	result := game.NavigateRoverWithOptions(5, 5, []model.Position{}, model.Position{X: 0, Y: 0}, model.North, "MMRM", Options{})
+
+	if result.Status != StatusSuccess {
+		t.Errorf("Expected status %v, got %v", StatusSuccess, result.Status)
+	}
+	if result.Trace != nil {
+		t.Errorf("Expected no trace when disabled, got %+v", result.Trace)
+	}
+}
+
+func TestNavigateRoverWithOptions_InvalidInputHasNoTrace(t *testing.T) {
+	game := NewGame()
+
+	result := game.NavigateRoverWithOptions(5, 5, []model.Position{}, model.Position{X: 0, Y: 0}, model.North, "MX", Options{Trace: true})
+
+	if result.Status != StatusInvalidInput {
+		t.Errorf("Expected status %v, got %v", StatusInvalidInput, result.Status)
+	}
+	if len(result.Trace) != 0 {
+		t.Errorf("Expected empty trace for invalid input, got %+v", result.Trace)
+	}
+}
+
+// expectBoundedNormalize lets a mocked environment keep every position as is.
+func expectBoundedNormalize(mockEnv *envMock.MockEnvironment) {
+	mockEnv.EXPECT().Normalize(gomock.Any()).DoAndReturn(func(position model.Position) (model.Position, bool) {
+		return position, true
+	}).AnyTimes()
+}
+
+func TestNavigateRoverWithOptions_Topology(t *testing.T) {
+	tests := []struct {
+		name              string
+		topology          environment.Topology
+		commands          string
+		expectedPosition  model.Position
+		expectedDirection model.Direction
+		expectedStatus    Status
+	}{
+		{"Bounded stops at the edge", nil, "LM", model.Position{X: 0, Y: 0}, model.West, StatusOutOfBounds},
+		{"WrapX enters the east edge", environment.WrapX, "LMM", model.Position{X: 3, Y: 0}, model.West, StatusSuccess},
+		{"WrapX keeps hard Y edges", environment.WrapX, "RRM", model.Position{X: 0, Y: 0}, model.South, StatusOutOfBounds},
+		{"WrapX stops on a wrapped obstacle", environment.WrapX, "MLM", model.Position{X: 0, Y: 1}, model.West, StatusObstacleEncountered},
+		{"Torus wraps both axes", environment.Torus, "RRMLM", model.Position{X: 1, Y: 2}, model.East, StatusSuccess},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NewGame().NavigateRoverWithOptions(5, 3, []model.Position{{X: 4, Y: 1}}, model.Position{X: 0, Y: 0}, model.North, tt.commands,
+				Options{Topology: tt.topology})
+
+			if result.FinalPosition != tt.expectedPosition || result.FinalDirection != tt.expectedDirection || result.Status != tt.expectedStatus {
+				t.Errorf("Expected %v %v %v, got %v %v %v", tt.expectedPosition, tt.expectedDirection, tt.expectedStatus,
+					result.FinalPosition, result.FinalDirection, result.Status)
+			}
+		})
+	}
+}
+
+func TestNavigateRover_BackwardAndUTurn(t *testing.T) {
+	obstacles := []model.Position{{X: 4, Y: 4}}
+
+	tests := []struct {
+		name              string
+		start             model.Position
+		direction         model.Direction
+		commands          string
+		expectedPosition  model.Position
+		expectedDirection model.Direction
+		expectedStatus    Status
+	}{
+		{"Backward keeps the heading", model.Position{X: 2, Y: 2}, model.North, "B", model.Position{X: 2, Y: 1}, model.North, StatusSuccess},
+		{"U-turn then move", model.Position{X: 2, Y: 2}, model.East, "UM", model.Position{X: 1, Y: 2}, model.West, StatusSuccess},
+		{"Backward and forward cancel out", model.Position{X: 2, Y: 2}, model.South, "BBMM", model.Position{X: 2, Y: 2}, model.South, StatusSuccess},
+		{"Backward into an obstacle", model.Position{X: 3, Y: 4}, model.West, "B", model.Position{X: 3, Y: 4}, model.West, StatusObstacleEncountered},
+		{"Backward out of bounds", model.Position{X: 0, Y: 0}, model.North, "B", model.Position{X: 0, Y: 0}, model.North, StatusOutOfBounds},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NewGame().NavigateRover(5, 5, obstacles, tt.start, tt.direction, tt.commands)
+
+			if result.FinalPosition != tt.expectedPosition || result.FinalDirection != tt.expectedDirection || result.Status != tt.expectedStatus {
+				t.Errorf("Expected %v %v %v, got %v %v %v", tt.expectedPosition, tt.expectedDirection, tt.expectedStatus,
+					result.FinalPosition, result.FinalDirection, result.Status)
+			}
+		})
+	}
+}
+
+func TestNavigateRover_BackwardWithMocks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEnv := envMock.NewMockEnvironment(ctrl)
+	expectBoundedNormalize(mockEnv)
+	mockRov := roverMock.NewMockRover(ctrl)
+
+	gomock.InOrder(
+		mockRov.EXPECT().GetTryMoveBackwardPosition().Return(model.Position{X: 1, Y: 0}),
+		mockEnv.EXPECT().CanMove(model.Position{X: 1, Y: 0}).Return(environment.Success),
+		mockRov.EXPECT().MoveTo(model.Position{X: 1, Y: 0}),
+		mockRov.EXPECT().UTurn(),
+	)
+	mockRov.EXPECT().GetPosition().Return(model.Position{X: 1, Y: 0})
+	mockRov.EXPECT().GetDirection().Return(model.South)
+
+	game := NewGameWithFactories(
+		func(int, int, []model.Position) environment.Environment { return mockEnv },
+		func(int, int, model.Direction) rover.Rover { return mockRov },
+	)
+	result := game.NavigateRover(5, 5, nil, model.Position{X: 1, Y: 1}, model.North, "BU")
+
+	if result.Status != StatusSuccess || result.FinalPosition != (model.Position{X: 1, Y: 0}) || result.FinalDirection != model.South {
+		t.Errorf("Expected (1,0) S Success, got %+v", result)
+	}
+}
+
+func TestNavigateRoverWithOptions_Compass(t *testing.T) {
+	// (1,2) and (2,1) touch the corners of the NE step from the start cell
+	obstacles := []model.Position{{X: 1, Y: 2}, {X: 2, Y: 1}}
+
+	tests := []struct {
+		name              string
+		options           Options
+		direction         model.Direction
+		commands          string
+		expectedPosition  model.Position
+		expectedDirection model.Direction
+		expectedStatus    Status
+	}{
+		{"Four rejects half turns", Options{}, model.North, "CM", model.Position{X: 0, Y: 0}, model.North, StatusInvalidInput},
+		{"Four rejects a diagonal start", Options{Compass: CompassFour}, model.NorthEast, "M", model.Position{X: 0, Y: 0}, model.North, StatusInvalidInput},
+		{"Unknown compass", Options{Compass: "six"}, model.North, "M", model.Position{X: 0, Y: 0}, model.North, StatusInvalidInput},
+		{"Eight keeps orthogonal moves", Options{Compass: CompassEight}, model.North, "LM", model.Position{X: 0, Y: 1}, model.West, StatusSuccess},
+		{"Eight cannot cut corners", Options{Compass: CompassEight}, model.North, "CM", model.Position{X: 1, Y: 1}, model.NorthEast, StatusObstacleEncountered},
+		{"Eight cuts corners when allowed", Options{Compass: CompassEight, CornerCutting: true}, model.North, "CM", model.Position{X: 2, Y: 2}, model.NorthEast, StatusSuccess},
+		{"Eight passes a single corner", Options{Compass: CompassEight}, model.North, "AM", model.Position{X: 0, Y: 2}, model.NorthWest, StatusSuccess},
+		{"Eight half turns all the way round", Options{Compass: CompassEight}, model.North, "CCCM", model.Position{X: 2, Y: 0}, model.SouthEast, StatusSuccess},
+		{"Eight reverses diagonally", Options{Compass: CompassEight}, model.NorthEast, "B", model.Position{X: 0, Y: 0}, model.NorthEast, StatusSuccess},
+		{"Eight diagonal off the grid", Options{Compass: CompassEight}, model.SouthWest, "MM", model.Position{X: 0, Y: 0}, model.SouthWest, StatusOutOfBounds},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NewGame().NavigateRoverWithOptions(5, 5, obstacles, model.Position{X: 1, Y: 1}, tt.direction, tt.commands, tt.options)
+
+			if result.FinalPosition != tt.expectedPosition || result.FinalDirection != tt.expectedDirection || result.Status != tt.expectedStatus {
+				t.Errorf("Expected %v %v %v, got %v %v %v", tt.expectedPosition, tt.expectedDirection, tt.expectedStatus,
+					result.FinalPosition, result.FinalDirection, result.Status)
+			}
+		})
+	}
+}
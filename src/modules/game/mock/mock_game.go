@@ -35,16 +35,58 @@ func (m *MockGame) EXPECT() *MockGameMockRecorder {
 	return m.recorder
 }
 
+// Explore mocks base method.
+func (m *MockGame) Explore(width, height int, obstacles []model.Position, start model.Position, direction model.Direction, maxMoves int, options game.Options) game.ExploreResult {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Explore", width, height, obstacles, start, direction, maxMoves, options)
+	ret0, _ := ret[0].(game.ExploreResult)
+	return ret0
+}
+
+// Explore indicates an expected call of Explore.
+func (mr *MockGameMockRecorder) Explore(width, height, obstacles, start, direction, maxMoves, options interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Explore", reflect.TypeOf((*MockGame)(nil).Explore), width, height, obstacles, start, direction, maxMoves, options)
+}
+
+// NavigateFleet mocks base method.
+func (m *MockGame) NavigateFleet(width, height int, obstacles []model.Position, rovers []game.RoverMission, mode game.FleetMode, options game.Options) game.FleetResult {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NavigateFleet", width, height, obstacles, rovers, mode, options)
+	ret0, _ := ret[0].(game.FleetResult)
+	return ret0
+}
+
+// NavigateFleet indicates an expected call of NavigateFleet.
+func (mr *MockGameMockRecorder) NavigateFleet(width, height, obstacles, rovers, mode, options interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NavigateFleet", reflect.TypeOf((*MockGame)(nil).NavigateFleet), width, height, obstacles, rovers, mode, options)
+}
+
 // NavigateRover mocks base method.
-func (m *MockGame) NavigateRover(size int, obstacles []model.Position, commands string) game.Result {
+func (m *MockGame) NavigateRover(width, height int, obstacles []model.Position, start model.Position, direction model.Direction, commands string) game.Result {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "NavigateRover", size, obstacles, commands)
+	ret := m.ctrl.Call(m, "NavigateRover", width, height, obstacles, start, direction, commands)
 	ret0, _ := ret[0].(game.Result)
 	return ret0
 }
 
 // NavigateRover indicates an expected call of NavigateRover.
-func (mr *MockGameMockRecorder) NavigateRover(size, obstacles, commands interface{}) *gomock.Call {
+func (mr *MockGameMockRecorder) NavigateRover(width, height, obstacles, start, direction, commands interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NavigateRover", reflect.TypeOf((*MockGame)(nil).NavigateRover), width, height, obstacles, start, direction, commands)
+}
+
+// NavigateRoverWithOptions mocks base method.
+func (m *MockGame) NavigateRoverWithOptions(width, height int, obstacles []model.Position, start model.Position, direction model.Direction, commands string, options game.Options) game.ExtendedResult {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NavigateRoverWithOptions", width, height, obstacles, start, direction, commands, options)
+	ret0, _ := ret[0].(game.ExtendedResult)
+	return ret0
+}
+
+// NavigateRoverWithOptions indicates an expected call of NavigateRoverWithOptions.
+func (mr *MockGameMockRecorder) NavigateRoverWithOptions(width, height, obstacles, start, direction, commands, options interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NavigateRover", reflect.TypeOf((*MockGame)(nil).NavigateRover), size, obstacles, commands)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NavigateRoverWithOptions", reflect.TypeOf((*MockGame)(nil).NavigateRoverWithOptions), width, height, obstacles, start, direction, commands, options)
 }
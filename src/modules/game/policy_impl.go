@@ -0,0 +1,130 @@
+package game
+
+import (
+	"mars-rover-navigation/src/model"
+	"mars-rover-navigation/src/modules/environment"
+	"mars-rover-navigation/src/modules/planner"
+)
+
+type ObstaclePolicy string
+
+const (
+	// PolicyAbort stops the rover at the first blocked move
+	PolicyAbort ObstaclePolicy = "abort"
+	// PolicySkip drops every blocked move and carries on with the next command
+	PolicySkip ObstaclePolicy = "skip"
+	// PolicySkipWithLimit skips up to Options.SkipLimit blocked moves, then aborts
+	PolicySkipWithLimit ObstaclePolicy = "skip_with_limit"
+	// PolicyReplan plans a detour to the next waypoint, the pose the commands reach at the end of a run of moves
+	PolicyReplan ObstaclePolicy = "replan"
+)
+
+// BlockedMove is a move the environment refused, Position is the cell the rover tried to enter.
+type BlockedMove struct {
+	Index    int                       `json:"index"`
+	Command  string                    `json:"command"`
+	Position model.Position            `json:"position"`
+	Status   environment.CanMoveStatus `json:"status"`
+	// Replan holds the detour taken by PolicyReplan
+	Replan string `json:"replan,omitempty"`
+}
+
+// replanFunc returns the detour from the rover pose to a waypoint of commands[from:]
+// and the index of the command that follows the waypoint.
+type replanFunc func(env environment.Environment, pose model.Pose, commands string, from int) (string, int, bool)
+
+func isValidPolicy(options Options) bool {
+	switch options.Policy {
+	case "", PolicyAbort, PolicySkip, PolicyReplan:
+		return true
+	case PolicySkipWithLimit:
+		return options.SkipLimit >= 0
+	}
+	return false
+}
+
+// block applies the obstacle policy to a refused move.
+func (r *roverRunner) block(env environment.Environment, blocked BlockedMove, replanned bool) {
+	abort := true
+
+	switch r.options.Policy {
+	case PolicySkip:
+		abort = false
+	case PolicySkipWithLimit:
+		if r.skipped < r.options.SkipLimit {
+			r.skipped++
+			abort = false
+		}
+	case PolicyReplan:
+		// A detour is only blocked by a moving rover, replanning again could loop
+		if replanned {
+			break
+		}
+		// A rover with sensors plans on what it knows, unknown cells are believed free
+		if detour, next, ok := r.replan(r.view(env), poseOf(r.rover), r.commands, blocked.Index); ok {
+			blocked.Replan = detour
+			r.pending, r.replanIndex, r.next = detour, blocked.Index, next
+			abort = false
+		}
+	}
+
+	r.blocked = append(r.blocked, blocked)
+	if abort {
+		r.status = statusOf(blocked.Status)
+	}
+}
+
+func statusOf(canMoveStatus environment.CanMoveStatus) Status {
+	switch canMoveStatus {
+	case environment.ObstacleEncountered:
+		return StatusObstacleEncountered
+	case environment.OutOfBounds:
+		return StatusOutOfBounds
+	case environment.RoverCollision:
+		return StatusRoverCollision
+	case environment.ImpassableTerrain:
+		return StatusImpassableTerrain
+	}
+	return Status(canMoveStatus)
+}
+
+// replanToNextWaypoint dead-reckons commands[from:] from the rover pose and plans a path to
+// the first reachable waypoint.
+func (e *gameImpl) replanToNextWaypoint(env environment.Environment, pose model.Pose, commands string, from int) (string, int, bool) {
+	ghost := e.roverFactory(pose.X, pose.Y, pose.Direction)
+
+	for i := from; i < len(commands); i++ {
+		switch commands[i] {
+		case 'M':
+			ghost.Move()
+		case 'B':
+			ghost.MoveBackward()
+		case 'L':
+			ghost.TurnLeft()
+		case 'R':
+			ghost.TurnRight()
+		case 'U':
+			ghost.UTurn()
+		case 'A':
+			ghost.TurnHalfLeft()
+		case 'C':
+			ghost.TurnHalfRight()
+		}
+
+		if !isMove(commands[i]) || (i+1 < len(commands) && isMove(commands[i+1])) {
+			continue
+		}
+
+		waypoint, _ := env.Normalize(ghost.GetPosition())
+		plan := e.planner.Plan(env, pose, planner.Goal{Position: waypoint, Direction: ghost.GetDirection()})
+		if plan.Status == planner.StatusSuccess {
+			return plan.Commands, i + 1, true
+		}
+	}
+
+	return "", 0, false
+}
+
+func isMove(cmd byte) bool {
+	return cmd == 'M' || cmd == 'B'
+}
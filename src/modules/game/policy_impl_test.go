@@ -0,0 +1,231 @@
+package game
+
+import (
+	"mars-rover-navigation/src/model"
+	"mars-rover-navigation/src/modules/environment"
+	"reflect"
+	"testing"
+)
+
+func TestNavigateRoverWithOptions_Policies(t *testing.T) {
+	tests := []struct {
+		name              string
+		width             int
+		height            int
+		obstacles         []model.Position
+		commands          string
+		options           Options
+		expectedPosition  model.Position
+		expectedDirection model.Direction
+		expectedStatus    Status
+		expectedBlocked   []BlockedMove
+	}{
+		{
+			name:              "Default policy aborts",
+			width:             5,
+			height:            5,
+			obstacles:         []model.Position{{X: 0, Y: 2}},
+			commands:          "MMMRM",
+			expectedPosition:  model.Position{X: 0, Y: 1},
+			expectedDirection: model.North,
+			expectedStatus:    StatusObstacleEncountered,
+			expectedBlocked: []BlockedMove{
+				{Index: 1, Command: "M", Position: model.Position{X: 0, Y: 2}, Status: environment.ObstacleEncountered},
+			},
+		},
+		{
+			name:              "Skip drops every blocked move",
+			width:             5,
+			height:            5,
+			obstacles:         []model.Position{{X: 0, Y: 2}},
+			commands:          "MMMRM",
+			options:           Options{Policy: PolicySkip},
+			expectedPosition:  model.Position{X: 1, Y: 1},
+			expectedDirection: model.East,
+			expectedStatus:    StatusSuccess,
+			expectedBlocked: []BlockedMove{
+				{Index: 1, Command: "M", Position: model.Position{X: 0, Y: 2}, Status: environment.ObstacleEncountered},
+				{Index: 2, Command: "M", Position: model.Position{X: 0, Y: 2}, Status: environment.ObstacleEncountered},
+			},
+		},
+		{
+			name:              "Skip out of bounds",
+			width:             2,
+			height:            2,
+			commands:          "LMRM",
+			options:           Options{Policy: PolicySkip},
+			expectedPosition:  model.Position{X: 0, Y: 1},
+			expectedDirection: model.North,
+			expectedStatus:    StatusSuccess,
+			expectedBlocked: []BlockedMove{
+				{Index: 1, Command: "M", Position: model.Position{X: -1, Y: 0}, Status: environment.OutOfBounds},
+			},
+		},
+		{
+			name:              "Skip a blocked backward move",
+			width:             3,
+			height:            3,
+			commands:          "BMUB",
+			options:           Options{Policy: PolicySkip},
+			expectedPosition:  model.Position{X: 0, Y: 2},
+			expectedDirection: model.South,
+			expectedStatus:    StatusSuccess,
+			expectedBlocked: []BlockedMove{
+				{Index: 0, Command: "B", Position: model.Position{X: 0, Y: -1}, Status: environment.OutOfBounds},
+			},
+		},
+		{
+			name:              "Skip with limit aborts once the limit is used",
+			width:             5,
+			height:            5,
+			obstacles:         []model.Position{{X: 0, Y: 2}},
+			commands:          "MMMRM",
+			options:           Options{Policy: PolicySkipWithLimit, SkipLimit: 1},
+			expectedPosition:  model.Position{X: 0, Y: 1},
+			expectedDirection: model.North,
+			expectedStatus:    StatusObstacleEncountered,
+			expectedBlocked: []BlockedMove{
+				{Index: 1, Command: "M", Position: model.Position{X: 0, Y: 2}, Status: environment.ObstacleEncountered},
+				{Index: 2, Command: "M", Position: model.Position{X: 0, Y: 2}, Status: environment.ObstacleEncountered},
+			},
+		},
+		{
+			name:              "Skip with limit within the limit",
+			width:             5,
+			height:            5,
+			obstacles:         []model.Position{{X: 0, Y: 2}},
+			commands:          "MMMRM",
+			options:           Options{Policy: PolicySkipWithLimit, SkipLimit: 2},
+			expectedPosition:  model.Position{X: 1, Y: 1},
+			expectedDirection: model.East,
+			expectedStatus:    StatusSuccess,
+			expectedBlocked: []BlockedMove{
+				{Index: 1, Command: "M", Position: model.Position{X: 0, Y: 2}, Status: environment.ObstacleEncountered},
+				{Index: 2, Command: "M", Position: model.Position{X: 0, Y: 2}, Status: environment.ObstacleEncountered},
+			},
+		},
+		{
+			name:              "Replan around the obstacle to the end of the run",
+			width:             5,
+			height:            5,
+			obstacles:         []model.Position{{X: 0, Y: 2}},
+			commands:          "MMMMRM",
+			options:           Options{Policy: PolicyReplan},
+			expectedPosition:  model.Position{X: 1, Y: 4},
+			expectedDirection: model.East,
+			expectedStatus:    StatusSuccess,
+			expectedBlocked: []BlockedMove{
+				{Index: 1, Command: "M", Position: model.Position{X: 0, Y: 2}, Status: environment.ObstacleEncountered, Replan: "RMLMMMLMR"},
+			},
+		},
+		{
+			name:              "Replan skips unreachable waypoints",
+			width:             3,
+			height:            3,
+			obstacles:         []model.Position{{X: 0, Y: 1}},
+			commands:          "MMMRRM",
+			options:           Options{Policy: PolicyReplan},
+			expectedPosition:  model.Position{X: 0, Y: 2},
+			expectedDirection: model.South,
+			expectedStatus:    StatusSuccess,
+			expectedBlocked: []BlockedMove{
+				{Index: 0, Command: "M", Position: model.Position{X: 0, Y: 1}, Status: environment.ObstacleEncountered, Replan: "RMLMMLML"},
+			},
+		},
+		{
+			name:              "Replan aborts without a reachable waypoint",
+			width:             3,
+			height:            3,
+			obstacles:         []model.Position{{X: 0, Y: 1}, {X: 1, Y: 1}, {X: 2, Y: 1}},
+			commands:          "MM",
+			options:           Options{Policy: PolicyReplan},
+			expectedPosition:  model.Position{X: 0, Y: 0},
+			expectedDirection: model.North,
+			expectedStatus:    StatusObstacleEncountered,
+			expectedBlocked: []BlockedMove{
+				{Index: 0, Command: "M", Position: model.Position{X: 0, Y: 1}, Status: environment.ObstacleEncountered},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NewGame().NavigateRoverWithOptions(tt.width, tt.height, tt.obstacles, model.Position{X: 0, Y: 0}, model.North, tt.commands, tt.options)
+
+			if result.FinalPosition != tt.expectedPosition {
+				t.Errorf("Expected position %v, got %v", tt.expectedPosition, result.FinalPosition)
+			}
+			if result.FinalDirection != tt.expectedDirection {
+				t.Errorf("Expected direction %v, got %v", tt.expectedDirection, result.FinalDirection)
+			}
+			if result.Status != tt.expectedStatus {
+				t.Errorf("Expected status %v, got %v", tt.expectedStatus, result.Status)
+			}
+			if !reflect.DeepEqual(result.Blocked, tt.expectedBlocked) {
+				t.Errorf("Expected blocked %+v, got %+v", tt.expectedBlocked, result.Blocked)
+			}
+		})
+	}
+}
+
+func TestNavigateRoverWithOptions_InvalidPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		options Options
+	}{
+		{name: "Unknown policy", options: Options{Policy: "retry"}},
+		{name: "Negative skip limit", options: Options{Policy: PolicySkipWithLimit, SkipLimit: -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NewGame().NavigateRoverWithOptions(5, 5, nil, model.Position{X: 1, Y: 1}, model.East, "M", tt.options)
+			if result.Status != StatusInvalidInput {
+				t.Errorf("Expected status %v, got %v", StatusInvalidInput, result.Status)
+			}
+		})
+	}
+}
+
+func TestNavigateRoverWithOptions_ReplanTrace(t *testing.T) {
+	result := NewGame().NavigateRoverWithOptions(3, 2, []model.Position{{X: 1, Y: 0}}, model.Position{X: 0, Y: 0}, model.East, "MM",
+		Options{Trace: true, Policy: PolicyReplan})
+
+	if result.Status != StatusSuccess || result.FinalPosition != (model.Position{X: 2, Y: 0}) {
+		t.Fatalf("Expected Success at (2,0), got %v at %v", result.Status, result.FinalPosition)
+	}
+
+	// The blocked move is followed by the detour, all reported at the blocked command index
+	if len(result.Trace) < 2 {
+		t.Fatalf("Expected a replanned trace, got %+v", result.Trace)
+	}
+	if result.Trace[0].Replan || result.Trace[0].MoveStatus != environment.ObstacleEncountered {
+		t.Errorf("Expected the first step to be the blocked move, got %+v", result.Trace[0])
+	}
+	for _, step := range result.Trace[1:] {
+		if !step.Replan || step.Index != 0 {
+			t.Errorf("Expected a replanned step for command 0, got %+v", step)
+		}
+	}
+	if got := len(result.Trace) - 1; got != len(result.Blocked[0].Replan) {
+		t.Errorf("Expected %d replanned steps, got %d", len(result.Blocked[0].Replan), got)
+	}
+}
+
+func TestNavigateFleet_SkipPolicy(t *testing.T) {
+	rovers := []RoverMission{
+		{ID: "a", Start: model.Position{X: 0, Y: 0}, Direction: model.East, Commands: "MMLM"},
+		{ID: "b", Start: model.Position{X: 2, Y: 0}, Direction: model.North, Commands: ""},
+	}
+
+	result := NewGame().NavigateFleet(3, 3, nil, rovers, FleetModeSequential, Options{Policy: PolicySkip})
+
+	a := result.Rovers[0]
+	if a.Status != StatusSuccess || a.FinalPosition != (model.Position{X: 1, Y: 1}) {
+		t.Errorf("Expected rover a to end at (1,1) with Success, got %v at %v", a.Status, a.FinalPosition)
+	}
+	expectedBlocked := []BlockedMove{{Index: 1, Command: "M", Position: model.Position{X: 2, Y: 0}, Status: environment.RoverCollision}}
+	if !reflect.DeepEqual(a.Blocked, expectedBlocked) {
+		t.Errorf("Expected blocked %+v, got %+v", expectedBlocked, a.Blocked)
+	}
+}
@@ -0,0 +1,42 @@
+package game
+
+import (
+	"mars-rover-navigation/src/modules/environment"
+	"mars-rover-navigation/src/modules/knowledge"
+)
+
+// isValidSensor keeps the sensor radius within knowledge.MaxRadius, 0 turns the sensors off.
+func isValidSensor(options Options) bool {
+	return options.SensorRadius >= 0 && options.SensorRadius <= knowledge.MaxRadius
+}
+
+// observe gives the rover its belief of env once the environment exists and senses the start cell.
+func (r *roverRunner) observe(env environment.Environment) {
+	if r.options.SensorRadius == 0 {
+		return
+	}
+	r.belief = knowledge.NewBelief(env, r.options.SensorRadius)
+	r.sense()
+}
+
+func (r *roverRunner) sense() {
+	if r.belief != nil {
+		r.belief.Sense(r.rover.GetPosition())
+	}
+}
+
+// view is the environment the rover decides on, its belief when it has sensors and the truth otherwise.
+func (r *roverRunner) view(env environment.Environment) environment.Environment {
+	if r.belief == nil {
+		return env
+	}
+	return r.belief
+}
+
+func (r *roverRunner) explored() *float64 {
+	if r.belief == nil {
+		return nil
+	}
+	explored := r.belief.Explored()
+	return &explored
+}
@@ -0,0 +1,123 @@
+package game
+
+import (
+	"mars-rover-navigation/src/model"
+	"testing"
+)
+
+func TestNavigateRoverWithOptions_Sensors(t *testing.T) {
+	tests := []struct {
+		name             string
+		obstacles        []model.Position
+		commands         string
+		options          Options
+		expectedPosition model.Position
+		expectedStatus   Status
+		expectedExplored *float64
+	}{
+		{
+			name:             "Sensors off",
+			commands:         "MMM",
+			expectedPosition: model.Position{X: 0, Y: 3},
+			expectedStatus:   StatusSuccess,
+		},
+		{
+			name:             "Every move reveals the cells around the rover",
+			commands:         "MMM",
+			options:          Options{SensorRadius: 1},
+			expectedPosition: model.Position{X: 0, Y: 3},
+			expectedStatus:   StatusSuccess,
+			expectedExplored: floatPtr(0.36),
+		},
+		{
+			name:             "Turns reveal nothing",
+			commands:         "RLRL",
+			options:          Options{SensorRadius: 1},
+			expectedPosition: model.Position{X: 0, Y: 0},
+			expectedStatus:   StatusSuccess,
+			expectedExplored: floatPtr(0.12),
+		},
+		{
+			name:             "A blocked move still reports what was sensed",
+			obstacles:        []model.Position{{X: 0, Y: 2}},
+			commands:         "MMM",
+			options:          Options{SensorRadius: 2},
+			expectedPosition: model.Position{X: 0, Y: 1},
+			expectedStatus:   StatusObstacleEncountered,
+			expectedExplored: floatPtr(0.36),
+		},
+		{
+			name:             "Sensor radius out of range",
+			commands:         "M",
+			options:          Options{SensorRadius: -1},
+			expectedPosition: model.Position{X: 0, Y: 0},
+			expectedStatus:   StatusInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := NewGame().NavigateRoverWithOptions(5, 5, tt.obstacles, model.Position{X: 0, Y: 0}, model.North, tt.commands, tt.options)
+
+			if result.FinalPosition != tt.expectedPosition {
+				t.Errorf("Expected position %v, got %v", tt.expectedPosition, result.FinalPosition)
+			}
+			if result.Status != tt.expectedStatus {
+				t.Errorf("Expected status %v, got %v", tt.expectedStatus, result.Status)
+			}
+			if (result.Explored == nil) != (tt.expectedExplored == nil) ||
+				(result.Explored != nil && *result.Explored != *tt.expectedExplored) {
+				t.Errorf("Expected explored %v, got %v", deref(tt.expectedExplored), deref(result.Explored))
+			}
+		})
+	}
+}
+
+// TestNavigateRoverWithOptions_SensorsReplan shows the replan policy planning on the belief: the detour runs into an
+// obstacle the rover could not see and aborts, a rover without sensors plans around it.
+func TestNavigateRoverWithOptions_SensorsReplan(t *testing.T) {
+	obstacles := []model.Position{{X: 0, Y: 2}, {X: 1, Y: 3}}
+
+	blind := NewGame().NavigateRoverWithOptions(5, 5, obstacles, model.Position{X: 0, Y: 0}, model.North, "MMMM",
+		Options{Policy: PolicyReplan, SensorRadius: 1})
+	if blind.Status != StatusObstacleEncountered {
+		t.Errorf("Expected the detour to hit the unseen obstacle, got %v at %v", blind.Status, blind.FinalPosition)
+	}
+	if len(blind.Blocked) != 2 || blind.Blocked[1].Position != (model.Position{X: 1, Y: 3}) {
+		t.Errorf("Expected the detour to be blocked at (1, 3), got %+v", blind.Blocked)
+	}
+
+	sighted := NewGame().NavigateRoverWithOptions(5, 5, obstacles, model.Position{X: 0, Y: 0}, model.North, "MMMM",
+		Options{Policy: PolicyReplan})
+	if sighted.Status != StatusSuccess || sighted.FinalPosition != (model.Position{X: 0, Y: 4}) {
+		t.Errorf("Expected Success at (0, 4), got %v at %v", sighted.Status, sighted.FinalPosition)
+	}
+}
+
+func TestNavigateFleet_Sensors(t *testing.T) {
+	rovers := []RoverMission{
+		{ID: "a", Start: model.Position{X: 0, Y: 0}, Direction: model.East, Commands: "MM"},
+		{ID: "b", Start: model.Position{X: 4, Y: 4}, Direction: model.West, Commands: ""},
+	}
+
+	result := NewGame().NavigateFleet(5, 5, nil, rovers, FleetModeSequential, Options{SensorRadius: 1})
+
+	// Every rover keeps its own belief, b sensed its corner only
+	expected := map[string]float64{"a": 0.28, "b": 0.12}
+	for _, rover := range result.Rovers {
+		if rover.Explored == nil || *rover.Explored != expected[rover.ID] {
+			t.Errorf("Expected rover %s to explore %v, got %v", rover.ID, expected[rover.ID], deref(rover.Explored))
+		}
+	}
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
+func deref(f *float64) any {
+	if f == nil {
+		return nil
+	}
+	return *f
+}
@@ -0,0 +1,206 @@
+package game
+
+import (
+	"fmt"
+	"mars-rover-navigation/src/model"
+	"mars-rover-navigation/src/modules/command"
+	"mars-rover-navigation/src/modules/knowledge"
+	"sort"
+	"strings"
+)
+
+// ValidationCode is the kind of problem a ValidationError reports.
+type ValidationCode string
+
+const (
+	// CodeInvalidGrid is a width or height below 1
+	CodeInvalidGrid ValidationCode = "invalid_grid"
+	// CodeInvalidFormat is an input that cannot be parsed, e.g. a malformed obstacle list
+	CodeInvalidFormat ValidationCode = "invalid_format"
+	// CodeOutOfBounds is an obstacle or start position off the grid
+	CodeOutOfBounds ValidationCode = "out_of_bounds"
+	// CodeInvalidDirection is an unknown heading, or a diagonal one without CompassEight
+	CodeInvalidDirection ValidationCode = "invalid_direction"
+	// CodeInvalidCommand is an unknown command, or a half turn without CompassEight
+	CodeInvalidCommand ValidationCode = "invalid_command"
+	// CodeStartOnObstacle is a start position on an obstacle, the only problem reported as StatusStartOnObstacle
+	CodeStartOnObstacle ValidationCode = "start_on_obstacle"
+	// CodeInvalidOption is an Options field or fleet mode out of range
+	CodeInvalidOption ValidationCode = "invalid_option"
+)
+
+// ValidationError is one problem of the input of a rejected rover.
+type ValidationError struct {
+	Code ValidationCode `json:"code"`
+	// Field is the rejected input, e.g. grid, obstacles, start, direction, commands or an option name
+	Field string `json:"field"`
+	// Value is the offending value as given
+	Value string `json:"value"`
+	// Index is the position of the value in a list field, the obstacle or command index
+	Index   *int   `json:"index,omitempty"`
+	Message string `json:"message"`
+}
+
+func (e ValidationError) Error() string {
+	field := e.Field
+	if e.Index != nil {
+		field = fmt.Sprintf("%s[%d]", field, *e.Index)
+	}
+	return fmt.Sprintf("%s: %s", field, e.Message)
+}
+
+// ValidationErrors holds every problem of an input, in the order the fields are checked.
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Status is StatusStartOnObstacle when the start cell is the only problem and StatusInvalidInput otherwise.
+func (e ValidationErrors) Status() Status {
+	for _, err := range e {
+		if err.Code != CodeStartOnObstacle {
+			return StatusInvalidInput
+		}
+	}
+	return StatusStartOnObstacle
+}
+
+// NewValidationError builds a ValidationError of a field that is not a list.
+func NewValidationError(code ValidationCode, field, value, message string) ValidationError {
+	return ValidationError{Code: code, Field: field, Value: value, Message: message}
+}
+
+// NewIndexedValidationError builds a ValidationError of the element index of a list field.
+func NewIndexedValidationError(code ValidationCode, field string, index int, value, message string) ValidationError {
+	return ValidationError{Code: code, Field: field, Value: value, Index: &index, Message: message}
+}
+
+// validateInputs reports every problem of the grid, obstacles, start pose and commands of a rover.
+func validateInputs(width, height int, obstacles []model.Position, start model.Position, direction model.Direction, commands string) ValidationErrors {
+	var errs ValidationErrors
+
+	gridValid := width > 0 && height > 0
+	if !gridValid {
+		errs = append(errs, NewValidationError(CodeInvalidGrid, "grid", fmt.Sprintf("%dx%d", width, height),
+			"width and height must be positive"))
+	}
+
+	// Bounds are only meaningful on a valid grid
+	if gridValid {
+		for i, obstacle := range obstacles {
+			if !isWithinBounds(obstacle, width, height) {
+				errs = append(errs, NewIndexedValidationError(CodeOutOfBounds, "obstacles", i, positionValue(obstacle),
+					fmt.Sprintf("obstacle is outside the %dx%d grid", width, height)))
+			}
+		}
+		if !isWithinBounds(start, width, height) {
+			errs = append(errs, NewValidationError(CodeOutOfBounds, "start", positionValue(start),
+				fmt.Sprintf("start is outside the %dx%d grid", width, height)))
+		}
+	}
+
+	if !isValidDirection(direction) {
+		errs = append(errs, NewValidationError(CodeInvalidDirection, "direction", string(direction),
+			"direction must be N, E, S, W, NE, SE, SW or NW"))
+	}
+
+	for i, cmd := range commands {
+		if !strings.ContainsRune(command.Primitives, cmd) {
+			errs = append(errs, NewIndexedValidationError(CodeInvalidCommand, "commands", i, string(cmd),
+				fmt.Sprintf("command must be one of %s", command.Primitives)))
+		}
+	}
+
+	for _, obstacle := range obstacles {
+		if obstacle == start {
+			errs = append(errs, NewValidationError(CodeStartOnObstacle, "start", positionValue(start),
+				"start is on an obstacle"))
+			break
+		}
+	}
+
+	return errs
+}
+
+// validateOptions reports every option out of range for a rover starting with direction and commands.
+func validateOptions(options Options, direction model.Direction, commands string) ValidationErrors {
+	var errs ValidationErrors
+
+	if !isValidPolicy(options) {
+		if options.Policy == PolicySkipWithLimit {
+			errs = append(errs, NewValidationError(CodeInvalidOption, "skip_limit", fmt.Sprint(options.SkipLimit),
+				"skip limit must not be negative"))
+		} else {
+			errs = append(errs, NewValidationError(CodeInvalidOption, "policy", string(options.Policy),
+				"policy must be abort, skip, skip_with_limit or replan"))
+		}
+	}
+
+	if !isValidCompass(options, direction, commands) {
+		switch options.Compass {
+		case "", CompassFour:
+			if direction.IsDiagonal() {
+				errs = append(errs, NewValidationError(CodeInvalidDirection, "direction", string(direction),
+					"diagonal headings need the eight compass"))
+			}
+			for i, cmd := range commands {
+				if cmd == 'A' || cmd == 'C' {
+					errs = append(errs, NewIndexedValidationError(CodeInvalidCommand, "commands", i, string(cmd),
+						"half turns need the eight compass"))
+				}
+			}
+		default:
+			errs = append(errs, NewValidationError(CodeInvalidOption, "compass", string(options.Compass),
+				"compass must be four or eight"))
+		}
+	}
+
+	if options.Policy == PolicyReplan && options.Compass == CompassEight {
+		// Detours are planned with quarter turns, the planner cannot start or end one on a diagonal heading
+		diagonal := direction.IsDiagonal()
+		for i, cmd := range commands {
+			switch {
+			case cmd == 'A' || cmd == 'C':
+				diagonal = !diagonal
+			case isMove(byte(cmd)) && diagonal:
+				errs = append(errs, NewIndexedValidationError(CodeInvalidCommand, "commands", i, string(cmd),
+					"replan needs moves heading N, E, S or W"))
+			}
+		}
+	}
+
+	if !isValidEnergy(options.Energy) {
+		errs = append(errs, NewValidationError(CodeInvalidOption, "energy", fmt.Sprintf("%+v", *options.Energy),
+			"charge, costs and terrain multipliers must not be negative"))
+	}
+
+	if !isValidSensor(options) {
+		errs = append(errs, NewValidationError(CodeInvalidOption, "sensor_radius", fmt.Sprint(options.SensorRadius),
+			fmt.Sprintf("sensor radius must be between 0 and %d", knowledge.MaxRadius)))
+	}
+
+	if !isValidTerrain(options) {
+		names := make([]string, 0, len(options.TerrainClasses))
+		for name := range options.TerrainClasses {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if class := options.TerrainClasses[name]; class.Cost < 1 {
+				errs = append(errs, NewValidationError(CodeInvalidOption, "terrain_classes."+name, fmt.Sprint(class.Cost),
+					"terrain cost must be at least 1"))
+			}
+		}
+	}
+
+	return errs
+}
+
+func positionValue(position model.Position) string {
+	return fmt.Sprintf("(%d, %d)", position.X, position.Y)
+}
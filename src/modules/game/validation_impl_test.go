@@ -0,0 +1,274 @@
+package game
+
+import (
+	"encoding/json"
+	"mars-rover-navigation/src/model"
+	"mars-rover-navigation/src/modules/environment"
+	"reflect"
+	"testing"
+)
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func TestValidateInputs_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		width     int
+		height    int
+		obstacles []model.Position
+		start     model.Position
+		direction model.Direction
+		commands  string
+		expected  ValidationErrors
+	}{
+		{
+			name:      "valid",
+			width:     5,
+			height:    5,
+			obstacles: []model.Position{{X: 1, Y: 1}},
+			direction: model.North,
+			commands:  "MRM",
+		},
+		{
+			name:      "every problem at once",
+			width:     5,
+			height:    5,
+			obstacles: []model.Position{{X: 1, Y: 1}, {X: 7, Y: 0}, {X: 2, Y: -1}},
+			start:     model.Position{X: 1, Y: 1},
+			direction: model.Direction("Q"),
+			commands:  "MXMZ",
+			expected: ValidationErrors{
+				{Code: CodeOutOfBounds, Field: "obstacles", Value: "(7, 0)", Index: intPtr(1), Message: "obstacle is outside the 5x5 grid"},
+				{Code: CodeOutOfBounds, Field: "obstacles", Value: "(2, -1)", Index: intPtr(2), Message: "obstacle is outside the 5x5 grid"},
+				{Code: CodeInvalidDirection, Field: "direction", Value: "Q", Message: "direction must be N, E, S, W, NE, SE, SW or NW"},
+				{Code: CodeInvalidCommand, Field: "commands", Value: "X", Index: intPtr(1), Message: "command must be one of LRMBUAC"},
+				{Code: CodeInvalidCommand, Field: "commands", Value: "Z", Index: intPtr(3), Message: "command must be one of LRMBUAC"},
+				{Code: CodeStartOnObstacle, Field: "start", Value: "(1, 1)", Message: "start is on an obstacle"},
+			},
+		},
+		{
+			name:      "invalid grid skips the bounds checks",
+			width:     0,
+			height:    3,
+			obstacles: []model.Position{{X: 4, Y: 4}},
+			start:     model.Position{X: 9, Y: 9},
+			direction: model.North,
+			commands:  "M",
+			expected: ValidationErrors{
+				{Code: CodeInvalidGrid, Field: "grid", Value: "0x3", Message: "width and height must be positive"},
+			},
+		},
+		{
+			name:      "start out of bounds",
+			width:     3,
+			height:    2,
+			start:     model.Position{X: 1, Y: 2},
+			direction: model.East,
+			expected: ValidationErrors{
+				{Code: CodeOutOfBounds, Field: "start", Value: "(1, 2)", Message: "start is outside the 3x2 grid"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validateInputs(tt.width, tt.height, tt.obstacles, tt.start, tt.direction, tt.commands)
+			if !reflect.DeepEqual(errs, tt.expected) {
+				t.Errorf("validateInputs() = %+v, expected %+v", errs, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidateOptions(t *testing.T) {
+	tests := []struct {
+		name      string
+		options   Options
+		direction model.Direction
+		commands  string
+		expected  ValidationErrors
+	}{
+		{
+			name:      "defaults",
+			direction: model.North,
+			commands:  "MRM",
+		},
+		{
+			name:      "unknown policy",
+			options:   Options{Policy: ObstaclePolicy("bounce")},
+			direction: model.North,
+			expected: ValidationErrors{
+				{Code: CodeInvalidOption, Field: "policy", Value: "bounce", Message: "policy must be abort, skip, skip_with_limit or replan"},
+			},
+		},
+		{
+			name:      "negative skip limit",
+			options:   Options{Policy: PolicySkipWithLimit, SkipLimit: -1},
+			direction: model.North,
+			expected: ValidationErrors{
+				{Code: CodeInvalidOption, Field: "skip_limit", Value: "-1", Message: "skip limit must not be negative"},
+			},
+		},
+		{
+			name:      "diagonals on the four compass",
+			direction: model.NorthEast,
+			commands:  "MAMC",
+			expected: ValidationErrors{
+				{Code: CodeInvalidDirection, Field: "direction", Value: "NE", Message: "diagonal headings need the eight compass"},
+				{Code: CodeInvalidCommand, Field: "commands", Value: "A", Index: intPtr(1), Message: "half turns need the eight compass"},
+				{Code: CodeInvalidCommand, Field: "commands", Value: "C", Index: intPtr(3), Message: "half turns need the eight compass"},
+			},
+		},
+		{
+			name:      "replan on a diagonal heading",
+			options:   Options{Policy: PolicyReplan, Compass: CompassEight},
+			direction: model.NorthEast,
+			commands:  "MAMCB",
+			expected: ValidationErrors{
+				{Code: CodeInvalidCommand, Field: "commands", Value: "M", Index: intPtr(0), Message: "replan needs moves heading N, E, S or W"},
+				{Code: CodeInvalidCommand, Field: "commands", Value: "B", Index: intPtr(4), Message: "replan needs moves heading N, E, S or W"},
+			},
+		},
+		{
+			name:      "replan with paired half turns",
+			options:   Options{Policy: PolicyReplan, Compass: CompassEight},
+			direction: model.North,
+			commands:  "MAAMCCM",
+		},
+		{
+			name:      "unknown compass",
+			options:   Options{Compass: Compass("six")},
+			direction: model.North,
+			expected: ValidationErrors{
+				{Code: CodeInvalidOption, Field: "compass", Value: "six", Message: "compass must be four or eight"},
+			},
+		},
+		{
+			name:      "sensor radius beyond the cap",
+			options:   Options{SensorRadius: 257},
+			direction: model.North,
+			expected: ValidationErrors{
+				{Code: CodeInvalidOption, Field: "sensor_radius", Value: "257", Message: "sensor radius must be between 0 and 256"},
+			},
+		},
+		{
+			name: "cheap terrain classes in name order",
+			options: Options{TerrainClasses: map[string]environment.TerrainClass{
+				"sand": {Cost: 0.5}, "ice": {Cost: 0}, "rock": {Cost: 3},
+			}},
+			direction: model.North,
+			expected: ValidationErrors{
+				{Code: CodeInvalidOption, Field: "terrain_classes.ice", Value: "0", Message: "terrain cost must be at least 1"},
+				{Code: CodeInvalidOption, Field: "terrain_classes.sand", Value: "0.5", Message: "terrain cost must be at least 1"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validateOptions(tt.options, tt.direction, tt.commands)
+			if !reflect.DeepEqual(errs, tt.expected) {
+				t.Errorf("validateOptions() = %+v, expected %+v", errs, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidationErrors_Status(t *testing.T) {
+	tests := []struct {
+		name     string
+		errs     ValidationErrors
+		expected Status
+	}{
+		{
+			name:     "start on obstacle only",
+			errs:     ValidationErrors{{Code: CodeStartOnObstacle}},
+			expected: StatusStartOnObstacle,
+		},
+		{
+			name:     "start on obstacle and an invalid command",
+			errs:     ValidationErrors{{Code: CodeInvalidCommand}, {Code: CodeStartOnObstacle}},
+			expected: StatusInvalidInput,
+		},
+		{
+			name:     "invalid grid",
+			errs:     ValidationErrors{{Code: CodeInvalidGrid}},
+			expected: StatusInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.errs.Status(); got != tt.expected {
+				t.Errorf("Status() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidationErrors_Error(t *testing.T) {
+	errs := ValidationErrors{
+		NewValidationError(CodeInvalidGrid, "grid", "0x3", "width and height must be positive"),
+		NewIndexedValidationError(CodeInvalidCommand, "commands", 2, "X", "command must be one of LRMBUAC"),
+	}
+
+	expected := "grid: width and height must be positive; commands[2]: command must be one of LRMBUAC"
+	if got := errs.Error(); got != expected {
+		t.Errorf("Error() = %q, expected %q", got, expected)
+	}
+}
+
+func TestNavigateRover_ValidationErrors(t *testing.T) {
+	game := NewGame()
+
+	result := game.NavigateRover(5, 5, []model.Position{{X: 9, Y: 9}}, model.Position{X: 0, Y: 0}, model.North, "MX")
+	if result.Status != StatusInvalidInput {
+		t.Errorf("Expected status %v, got %v", StatusInvalidInput, result.Status)
+	}
+
+	encoded, err := json.Marshal(result.Errors)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	expected := `[{"code":"out_of_bounds","field":"obstacles","value":"(9, 9)","index":0,"message":"obstacle is outside the 5x5 grid"},` +
+		`{"code":"invalid_command","field":"commands","value":"X","index":1,"message":"command must be one of LRMBUAC"}]`
+	if string(encoded) != expected {
+		t.Errorf("Errors = %s, expected %s", encoded, expected)
+	}
+
+	result = game.NavigateRover(5, 5, nil, model.Position{X: 0, Y: 0}, model.North, "M")
+	if result.Errors != nil {
+		t.Errorf("Expected no errors for a valid rover, got %v", result.Errors)
+	}
+}
+
+func TestNavigateFleet_ValidationErrors(t *testing.T) {
+	game := NewGame()
+
+	rovers := []RoverMission{
+		{ID: "a", Start: model.Position{X: 0, Y: 0}, Direction: model.North, Commands: "M"},
+		{ID: "b", Start: model.Position{X: 0, Y: 0}, Direction: model.North, Commands: "M"},
+		{ID: "c", Start: model.Position{X: 8, Y: 0}, Direction: model.North, Commands: "M"},
+	}
+	result := game.NavigateFleet(5, 5, nil, rovers, FleetModeSequential, Options{})
+
+	if result.Rovers[0].Errors != nil {
+		t.Errorf("rover a: expected no errors, got %v", result.Rovers[0].Errors)
+	}
+	// A taken start cell is a collision, not an input problem
+	if result.Rovers[1].Status != StatusRoverCollision || result.Rovers[1].Errors != nil {
+		t.Errorf("rover b: expected %v without errors, got %v %v", StatusRoverCollision, result.Rovers[1].Status, result.Rovers[1].Errors)
+	}
+	expected := ValidationErrors{{Code: CodeOutOfBounds, Field: "start", Value: "(8, 0)", Message: "start is outside the 5x5 grid"}}
+	if !reflect.DeepEqual(result.Rovers[2].Errors, expected) {
+		t.Errorf("rover c: expected errors %+v, got %+v", expected, result.Rovers[2].Errors)
+	}
+
+	result = game.NavigateFleet(5, 5, nil, rovers[:1], FleetMode("parallel"), Options{})
+	expected = ValidationErrors{{Code: CodeInvalidOption, Field: "fleet_mode", Value: "parallel", Message: "fleet mode must be sequential or interleaved"}}
+	if !reflect.DeepEqual(result.Rovers[0].Errors, expected) {
+		t.Errorf("fleet mode: expected errors %+v, got %+v", expected, result.Rovers[0].Errors)
+	}
+}
@@ -0,0 +1,61 @@
+//go:generate go run github.com/golang/mock/mockgen -source=generator.go -destination=./mock/mock_generator.go -package=mock
+
+package generator
+
+import (
+	"mars-rover-navigation/src/model"
+	"mars-rover-navigation/src/modules/environment"
+)
+
+type Generator interface {
+	// Generate builds the map of params, the same params always give the same map
+	Generate(params Params) (*Map, error)
+}
+
+// Params describe a map, the features are laid in field order and corridors are carved last.
+type Params struct {
+	Seed int64
+	Size model.Size
+	// Obstacles are placed before the generated ones, a corridor still clears them
+	Obstacles []model.Position
+	// Density is the chance of every cell to hold a scattered boulder, 0 to 1
+	Density float64
+	// Boulders is the number of boulder fields, clusters of BoulderRadius cells half filled with boulders
+	Boulders      int
+	BoulderRadius int
+	// Craters is the number of crater rings of CraterRadius, a ring adds its rim and leaves the boulders inside it
+	Craters      int
+	CraterRadius int
+	// Corridors are kept free of obstacles, a rover can always drive between their ends
+	Corridors []Corridor
+	// Keep are cells left free, e.g. the start of a rover
+	Keep []model.Position
+}
+
+// Corridor is a clear path between two cells moving one cell north, east, south or west at a time.
+type Corridor struct {
+	From model.Position `json:"from"`
+	To   model.Position `json:"to"`
+}
+
+// Map is a generated grid, Obstacles are ordered by x then y.
+type Map struct {
+	Size      model.Size
+	Obstacles []model.Position
+}
+
+const (
+	// DefaultBoulderRadius is the radius of a boulder field when Params.BoulderRadius is 0
+	DefaultBoulderRadius = 2
+	// DefaultCraterRadius is the radius of a crater ring when Params.CraterRadius is 0
+	DefaultCraterRadius = 3
+	// MaxRadius caps the radius of boulder fields and craters
+	MaxRadius = 64
+	// MaxObstacles caps the obstacles of a map
+	MaxObstacles = 1 << 20
+)
+
+// Environment returns the environment of the map, see environment.New.
+func (m *Map) Environment() environment.Environment {
+	return environment.New(m.Size.Width, m.Size.Height, m.Obstacles)
+}
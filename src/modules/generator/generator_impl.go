@@ -0,0 +1,201 @@
+package generator
+
+import (
+	"fmt"
+	"mars-rover-navigation/src/model"
+	"mars-rover-navigation/src/modules/environment"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// boulderFill is the share of the cells of a boulder field holding a boulder
+const boulderFill = 0.5
+
+type generatorImpl struct{}
+
+func NewGenerator() *generatorImpl {
+	return &generatorImpl{}
+}
+
+func (g *generatorImpl) Generate(params Params) (*Map, error) {
+	if params.BoulderRadius == 0 {
+		params.BoulderRadius = DefaultBoulderRadius
+	}
+	if params.CraterRadius == 0 {
+		params.CraterRadius = DefaultCraterRadius
+	}
+	if err := validate(params); err != nil {
+		return nil, err
+	}
+
+	// math/rand keeps the sequence of a seeded source stable across releases
+	b := &builder{params: params, rng: rand.New(rand.NewSource(params.Seed)), cells: map[model.Position]bool{}}
+	for _, obstacle := range params.Obstacles {
+		b.cells[obstacle] = true
+	}
+	if err := b.scatter(); err != nil {
+		return nil, err
+	}
+	for i := 0; i < params.Boulders; i++ {
+		b.boulderField()
+	}
+	for i := 0; i < params.Craters; i++ {
+		b.crater()
+	}
+	for _, corridor := range params.Corridors {
+		b.carve(corridor)
+	}
+	for _, keep := range params.Keep {
+		delete(b.cells, keep)
+	}
+
+	if len(b.cells) > MaxObstacles {
+		return nil, fmt.Errorf("map holds %d obstacles, more than %d", len(b.cells), MaxObstacles)
+	}
+	return &Map{Size: params.Size, Obstacles: sortedPositions(b.cells)}, nil
+}
+
+func validate(params Params) error {
+	size := params.Size
+	switch {
+	case size.Width <= 0 || size.Height <= 0:
+		return fmt.Errorf("grid width and height must be positive, got %dx%d", size.Width, size.Height)
+	case params.Density < 0 || params.Density > 1 || math.IsNaN(params.Density):
+		return fmt.Errorf("density must be between 0 and 1, got %g", params.Density)
+	case params.Boulders < 0:
+		return fmt.Errorf("boulder fields must not be negative, got %d", params.Boulders)
+	case params.Craters < 0:
+		return fmt.Errorf("craters must not be negative, got %d", params.Craters)
+	case params.BoulderRadius < 1 || params.BoulderRadius > MaxRadius:
+		return fmt.Errorf("boulder radius must be between 1 and %d, got %d", MaxRadius, params.BoulderRadius)
+	case params.CraterRadius < 1 || params.CraterRadius > MaxRadius:
+		return fmt.Errorf("crater radius must be between 1 and %d, got %d", MaxRadius, params.CraterRadius)
+	}
+
+	for i, obstacle := range params.Obstacles {
+		if !isWithin(obstacle, size) {
+			return fmt.Errorf("obstacle %d (%d, %d) is outside the grid", i, obstacle.X, obstacle.Y)
+		}
+	}
+	for i, corridor := range params.Corridors {
+		if !isWithin(corridor.From, size) || !isWithin(corridor.To, size) {
+			return fmt.Errorf("corridor %d from (%d, %d) to (%d, %d) leaves the grid", i,
+				corridor.From.X, corridor.From.Y, corridor.To.X, corridor.To.Y)
+		}
+	}
+	return nil
+}
+
+// builder draws every random number from one source in a fixed order, which keeps a seed reproducible.
+type builder struct {
+	params Params
+	rng    *rand.Rand
+	cells  map[model.Position]bool
+}
+
+// scatter rolls every cell of a dense grid, a larger grid draws the expected number of cells instead.
+func (b *builder) scatter() error {
+	if b.params.Density == 0 {
+		return nil
+	}
+
+	size := b.params.Size
+	if size.Width <= environment.DenseCellLimit/size.Height {
+		for x := 0; x < size.Width; x++ {
+			for y := 0; y < size.Height; y++ {
+				if b.rng.Float64() < b.params.Density {
+					b.cells[model.Position{X: x, Y: y}] = true
+				}
+			}
+		}
+		return nil
+	}
+
+	count := b.params.Density * float64(size.Width) * float64(size.Height)
+	if count > MaxObstacles {
+		return fmt.Errorf("density %g scatters about %.0f obstacles, more than %d", b.params.Density, count, MaxObstacles)
+	}
+	for i := 0; i < int(count); i++ {
+		b.cells[b.randomCell()] = true
+	}
+	return nil
+}
+
+func (b *builder) boulderField() {
+	center := b.randomCell()
+	r := b.params.BoulderRadius
+	for dx := -r; dx <= r; dx++ {
+		for dy := -r; dy <= r; dy++ {
+			cell := model.Position{X: center.X + dx, Y: center.Y + dy}
+			// The roll is drawn for cells off the grid too, a field is the same wherever it lands
+			if dx*dx+dy*dy <= r*r && b.rng.Float64() < boulderFill && isWithin(cell, b.params.Size) {
+				b.cells[cell] = true
+			}
+		}
+	}
+}
+
+// crater lays the cells whose distance to a random center rounds to the radius.
+func (b *builder) crater() {
+	center := b.randomCell()
+	r := b.params.CraterRadius
+	for dx := -r - 1; dx <= r+1; dx++ {
+		for dy := -r - 1; dy <= r+1; dy++ {
+			cell := model.Position{X: center.X + dx, Y: center.Y + dy}
+			if math.Abs(math.Hypot(float64(dx), float64(dy))-float64(r)) < 0.5 && isWithin(cell, b.params.Size) {
+				b.cells[cell] = true
+			}
+		}
+	}
+}
+
+// carve clears a path of orthogonal steps from the start to the end of corridor, the axis of every step is random.
+func (b *builder) carve(corridor Corridor) {
+	cell := corridor.From
+	delete(b.cells, cell)
+	for cell != corridor.To {
+		stepX, stepY := sign(corridor.To.X-cell.X), sign(corridor.To.Y-cell.Y)
+		if stepX != 0 && stepY != 0 {
+			if b.rng.Intn(2) == 0 {
+				stepY = 0
+			} else {
+				stepX = 0
+			}
+		}
+		cell = model.Position{X: cell.X + stepX, Y: cell.Y + stepY}
+		delete(b.cells, cell)
+	}
+}
+
+func (b *builder) randomCell() model.Position {
+	return model.Position{X: b.rng.Intn(b.params.Size.Width), Y: b.rng.Intn(b.params.Size.Height)}
+}
+
+func sortedPositions(cells map[model.Position]bool) []model.Position {
+	positions := make([]model.Position, 0, len(cells))
+	for cell := range cells {
+		positions = append(positions, cell)
+	}
+	sort.Slice(positions, func(i, j int) bool {
+		if positions[i].X != positions[j].X {
+			return positions[i].X < positions[j].X
+		}
+		return positions[i].Y < positions[j].Y
+	})
+	return positions
+}
+
+func isWithin(position model.Position, size model.Size) bool {
+	return position.X >= 0 && position.X < size.Width && position.Y >= 0 && position.Y < size.Height
+}
+
+func sign(v int) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,239 @@
+package generator
+
+import (
+	"mars-rover-navigation/src/model"
+	"mars-rover-navigation/src/modules/environment"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGenerate_SameSeedSameMap(t *testing.T) {
+	params := Params{
+		Seed: 7, Size: model.Size{Width: 40, Height: 30}, Density: 0.1, Boulders: 3, Craters: 2,
+		Corridors: []Corridor{{From: model.Position{X: 0, Y: 0}, To: model.Position{X: 39, Y: 29}}},
+	}
+
+	first, err := NewGenerator().Generate(params)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	second, _ := NewGenerator().Generate(params)
+	if !reflect.DeepEqual(first, second) {
+		t.Error("Generate() gave two maps for the same seed")
+	}
+
+	params.Seed = 8
+	other, _ := NewGenerator().Generate(params)
+	if reflect.DeepEqual(first.Obstacles, other.Obstacles) {
+		t.Error("Generate() gave the same map for another seed")
+	}
+}
+
+// TestGenerate_Stable pins the map of a seed, a change here breaks every map generated before.
+func TestGenerate_Stable(t *testing.T) {
+	m, err := NewGenerator().Generate(Params{
+		Seed: 42, Size: model.Size{Width: 12, Height: 8}, Density: 0.1, Boulders: 1, Craters: 1,
+		Corridors: []Corridor{{From: model.Position{X: 0, Y: 0}, To: model.Position{X: 11, Y: 7}}},
+	})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	expected := []model.Position{
+		{X: 0, Y: 4}, {X: 2, Y: 7}, {X: 3, Y: 1}, {X: 3, Y: 7}, {X: 4, Y: 2}, {X: 4, Y: 5}, {X: 4, Y: 7}, {X: 5, Y: 3},
+		{X: 5, Y: 5}, {X: 6, Y: 4}, {X: 7, Y: 2}, {X: 7, Y: 4}, {X: 8, Y: 0}, {X: 8, Y: 4}, {X: 9, Y: 5}, {X: 10, Y: 6},
+	}
+	if !reflect.DeepEqual(m.Obstacles, expected) {
+		t.Errorf("Generate() obstacles = %v, want %v", m.Obstacles, expected)
+	}
+}
+
+func TestGenerate_Corridors(t *testing.T) {
+	corridors := []Corridor{
+		{From: model.Position{X: 0, Y: 0}, To: model.Position{X: 24, Y: 24}},
+		{From: model.Position{X: 24, Y: 0}, To: model.Position{X: 3, Y: 20}},
+		{From: model.Position{X: 12, Y: 12}, To: model.Position{X: 12, Y: 12}},
+	}
+
+	for seed := int64(0); seed < 20; seed++ {
+		m, err := NewGenerator().Generate(Params{Seed: seed, Size: model.Size{Width: 25, Height: 25}, Density: 0.6, Boulders: 4, Craters: 3, Corridors: corridors})
+		if err != nil {
+			t.Fatalf("seed %d: Generate() error = %v", seed, err)
+		}
+		env := m.Environment()
+		for _, corridor := range corridors {
+			if !isConnected(env, corridor.From, corridor.To) {
+				t.Errorf("seed %d: no path from %v to %v", seed, corridor.From, corridor.To)
+			}
+		}
+	}
+}
+
+// isConnected searches the cells reachable from one end of a corridor with orthogonal moves.
+func isConnected(env environment.Environment, from, to model.Position) bool {
+	seen := map[model.Position]bool{from: true}
+	queue := []model.Position{from}
+	for len(queue) > 0 {
+		cell := queue[0]
+		queue = queue[1:]
+		if cell == to {
+			return true
+		}
+		for _, next := range []model.Position{{X: cell.X + 1, Y: cell.Y}, {X: cell.X - 1, Y: cell.Y}, {X: cell.X, Y: cell.Y + 1}, {X: cell.X, Y: cell.Y - 1}} {
+			if !seen[next] && env.CanMove(next) == environment.Success {
+				seen[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return false
+}
+
+func TestGenerate_Features(t *testing.T) {
+	size := model.Size{Width: 50, Height: 50}
+	tests := []struct {
+		name   string
+		params Params
+		check  func(t *testing.T, m *Map)
+	}{
+		{
+			name:   "empty",
+			params: Params{Size: size},
+			check: func(t *testing.T, m *Map) {
+				if len(m.Obstacles) != 0 {
+					t.Errorf("expected no obstacles, got %d", len(m.Obstacles))
+				}
+			},
+		},
+		{
+			name:   "full density",
+			params: Params{Size: model.Size{Width: 4, Height: 3}, Density: 1, Keep: []model.Position{{X: 0, Y: 0}}},
+			check: func(t *testing.T, m *Map) {
+				if len(m.Obstacles) != 11 || m.Obstacles[0] != (model.Position{X: 0, Y: 1}) {
+					t.Errorf("expected every cell but the kept one, got %v", m.Obstacles)
+				}
+			},
+		},
+		{
+			name:   "density",
+			params: Params{Seed: 3, Size: size, Density: 0.2},
+			check: func(t *testing.T, m *Map) {
+				if n := len(m.Obstacles); n < 400 || n > 600 {
+					t.Errorf("expected about 500 obstacles, got %d", n)
+				}
+			},
+		},
+		{
+			name:   "boulder field stays within its radius",
+			params: Params{Seed: 5, Size: size, Boulders: 1, BoulderRadius: 4},
+			check: func(t *testing.T, m *Map) {
+				assertSpan(t, m, 9)
+			},
+		},
+		{
+			name:   "crater ring",
+			params: Params{Seed: 9, Size: model.Size{Width: 200, Height: 200}, Craters: 1, CraterRadius: 5},
+			check: func(t *testing.T, m *Map) {
+				assertSpan(t, m, 11)
+				if len(m.Obstacles) < 20 {
+					t.Errorf("expected a ring, got %v", m.Obstacles)
+				}
+			},
+		},
+		{
+			name:   "fixed obstacles are kept",
+			params: Params{Size: size, Obstacles: []model.Position{{X: 3, Y: 1}, {X: 1, Y: 2}}},
+			check: func(t *testing.T, m *Map) {
+				expected := []model.Position{{X: 1, Y: 2}, {X: 3, Y: 1}}
+				if !reflect.DeepEqual(m.Obstacles, expected) {
+					t.Errorf("expected %v, got %v", expected, m.Obstacles)
+				}
+			},
+		},
+		{
+			name: "corridor clears fixed obstacles",
+			params: Params{Size: model.Size{Width: 3, Height: 1}, Obstacles: []model.Position{{X: 1, Y: 0}},
+				Corridors: []Corridor{{From: model.Position{X: 0, Y: 0}, To: model.Position{X: 2, Y: 0}}}},
+			check: func(t *testing.T, m *Map) {
+				if len(m.Obstacles) != 0 {
+					t.Errorf("expected the corridor to be clear, got %v", m.Obstacles)
+				}
+			},
+		},
+		{
+			name:   "large grid draws its obstacles",
+			params: Params{Seed: 1, Size: model.Size{Width: 100000, Height: 100000}, Density: 0.00001},
+			check: func(t *testing.T, m *Map) {
+				if n := len(m.Obstacles); n < 99000 || n > 100000 {
+					t.Errorf("expected about 100000 obstacles, got %d", n)
+				}
+				if size := m.Environment().Size(); size != m.Size {
+					t.Errorf("expected an environment of %v, got %v", m.Size, size)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewGenerator().Generate(tt.params)
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+			tt.check(t, m)
+		})
+	}
+}
+
+// assertSpan checks that the obstacles fit in a square of span cells.
+func assertSpan(t *testing.T, m *Map, span int) {
+	t.Helper()
+	if len(m.Obstacles) == 0 {
+		t.Fatal("expected obstacles")
+	}
+	minX, maxX := m.Obstacles[0].X, m.Obstacles[len(m.Obstacles)-1].X
+	minY, maxY := m.Obstacles[0].Y, m.Obstacles[0].Y
+	for _, o := range m.Obstacles {
+		minY, maxY = min(minY, o.Y), max(maxY, o.Y)
+	}
+	if maxX-minX >= span || maxY-minY >= span {
+		t.Errorf("expected obstacles within %d cells, got x %d..%d y %d..%d", span, minX, maxX, minY, maxY)
+	}
+}
+
+func TestGenerate_Invalid(t *testing.T) {
+	size := model.Size{Width: 10, Height: 10}
+	tests := []struct {
+		name     string
+		params   Params
+		expected string
+	}{
+		{name: "empty grid", params: Params{}, expected: "grid width and height must be positive"},
+		{name: "density above 1", params: Params{Size: size, Density: 1.5}, expected: "density must be between 0 and 1"},
+		{name: "negative boulders", params: Params{Size: size, Boulders: -1}, expected: "boulder fields must not be negative"},
+		{name: "negative craters", params: Params{Size: size, Craters: -2}, expected: "craters must not be negative"},
+		{name: "huge boulder radius", params: Params{Size: size, BoulderRadius: 65}, expected: "boulder radius must be between 1 and 64"},
+		{name: "negative crater radius", params: Params{Size: size, CraterRadius: -1}, expected: "crater radius must be between 1 and 64"},
+		{name: "obstacle off the grid", params: Params{Size: size, Obstacles: []model.Position{{X: 10, Y: 0}}}, expected: "obstacle 0 (10, 0) is outside the grid"},
+		{
+			name:     "corridor off the grid",
+			params:   Params{Size: size, Corridors: []Corridor{{From: model.Position{X: 0, Y: 0}, To: model.Position{X: 0, Y: -1}}}},
+			expected: "corridor 0 from (0, 0) to (0, -1) leaves the grid",
+		},
+		{
+			name:     "too many obstacles",
+			params:   Params{Size: model.Size{Width: 100000, Height: 100000}, Density: 0.5},
+			expected: "more than 1048576",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewGenerator().Generate(tt.params)
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Generate() error = %v, want %q", err, tt.expected)
+			}
+		})
+	}
+}
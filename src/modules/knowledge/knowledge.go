@@ -0,0 +1,26 @@
+//go:generate go run github.com/golang/mock/mockgen -source=knowledge.go -destination=./mock/mock_knowledge.go -package=mock
+
+package knowledge
+
+import (
+	"mars-rover-navigation/src/model"
+	"mars-rover-navigation/src/modules/environment"
+)
+
+// Belief is the map one rover has of its environment, a layer over the true environment.Environment.
+//
+// A sensed cell reads through to the environment, a cell the rover has not sensed yet is believed to be plain,
+// free ground. Sensors see every cell within their radius, obstacles do not hide the cells behind them.
+type Belief interface {
+	environment.Environment
+	// Sense reveals the cells within the sensor radius of position and returns how many were not known before
+	Sense(position model.Position) int
+	IsKnown(position model.Position) bool
+	// Known is the number of grid cells sensed so far
+	Known() int
+	// Explored is the fraction of the grid cells sensed so far, from 0 to 1
+	Explored() float64
+}
+
+// MaxRadius caps the sensor radius.
+const MaxRadius = 256
@@ -0,0 +1,114 @@
+package knowledge
+
+import (
+	"mars-rover-navigation/src/model"
+	"mars-rover-navigation/src/modules/environment"
+)
+
+type beliefImpl struct {
+	// Environment is the truth the sensors read, every method Belief does not override reads it directly
+	environment.Environment
+	radius int
+	// known holds the sensed cells, the belief grows with what the rover saw and not with the grid
+	known map[model.Position]bool
+}
+
+// NewBelief starts a belief of env that knows no cell, radius is the reach of the sensors in cells.
+func NewBelief(env environment.Environment, radius int) *beliefImpl {
+	return &beliefImpl{
+		Environment: env,
+		radius:      radius,
+		known:       map[model.Position]bool{},
+	}
+}
+
+func (b *beliefImpl) Sense(position model.Position) int {
+	revealed := 0
+	for dx := -b.radius; dx <= b.radius; dx++ {
+		for dy := -b.radius; dy <= b.radius; dy++ {
+			if dx*dx+dy*dy > b.radius*b.radius {
+				continue
+			}
+			// A wrapping topology lets the sensors see across the edge
+			cell, ok := b.Normalize(model.Position{X: position.X + dx, Y: position.Y + dy})
+			if ok && !b.known[cell] {
+				b.known[cell] = true
+				revealed++
+			}
+		}
+	}
+	return revealed
+}
+
+func (b *beliefImpl) IsKnown(position model.Position) bool {
+	position, ok := b.Normalize(position)
+	return ok && b.known[position]
+}
+
+func (b *beliefImpl) Known() int {
+	return len(b.known)
+}
+
+func (b *beliefImpl) Explored() float64 {
+	size := b.Size()
+	return float64(len(b.known)) / (float64(size.Width) * float64(size.Height))
+}
+
+// GetGrid is a copy of the grid of the environment with every cell the rover has not sensed cleared,
+// a dense environment hands out its own grid.
+func (b *beliefImpl) GetGrid() [][]model.Cell {
+	truth := b.Environment.GetGrid()
+	grid := make([][]model.Cell, len(truth))
+	for x := range truth {
+		grid[x] = make([]model.Cell, len(truth[x]))
+		for y, cell := range truth[x] {
+			if b.known[cell.Position] {
+				grid[x][y] = cell
+			} else {
+				grid[x][y] = model.Cell{Position: cell.Position}
+			}
+		}
+	}
+	return grid
+}
+
+func (b *beliefImpl) Cell(position model.Position) model.Cell {
+	if !b.IsKnown(position) {
+		return model.Cell{Position: position}
+	}
+	return b.Environment.Cell(position)
+}
+
+// CanMove only refuses an unknown cell off a hard edge, the edges are known from the start.
+func (b *beliefImpl) CanMove(actorPosition model.Position) environment.CanMoveStatus {
+	if _, ok := b.Normalize(actorPosition); !ok {
+		return environment.OutOfBounds
+	}
+	if !b.IsKnown(actorPosition) {
+		return environment.Success
+	}
+	return b.Environment.CanMove(actorPosition)
+}
+
+// CanMoveFrom checks the corners of a diagonal step into a known cell only, they are next to the rover and sensed.
+func (b *beliefImpl) CanMoveFrom(from, to model.Position) environment.CanMoveStatus {
+	if !b.IsKnown(to) {
+		return b.CanMove(to)
+	}
+	return b.Environment.CanMoveFrom(from, to)
+}
+
+func (b *beliefImpl) TerrainAt(position model.Position) string {
+	if !b.IsKnown(position) {
+		return ""
+	}
+	return b.Environment.TerrainAt(position)
+}
+
+// TerrainCost of an unknown cell is the cost of plain ground.
+func (b *beliefImpl) TerrainCost(position model.Position) float64 {
+	if !b.IsKnown(position) {
+		return 1
+	}
+	return b.Environment.TerrainCost(position)
+}
@@ -0,0 +1,160 @@
+package knowledge
+
+import (
+	"mars-rover-navigation/src/model"
+	"mars-rover-navigation/src/modules/environment"
+	"testing"
+)
+
+func TestBelief_Sense(t *testing.T) {
+	tests := []struct {
+		name     string
+		radius   int
+		topology environment.Topology
+		sensed   []model.Position
+		revealed []int
+		known    []model.Position
+		unknown  []model.Position
+	}{
+		{
+			name:     "radius 1 is a cross",
+			radius:   1,
+			sensed:   []model.Position{{X: 2, Y: 2}},
+			revealed: []int{5},
+			known:    []model.Position{{X: 2, Y: 2}, {X: 1, Y: 2}, {X: 3, Y: 2}, {X: 2, Y: 1}, {X: 2, Y: 3}},
+			unknown:  []model.Position{{X: 1, Y: 1}, {X: 3, Y: 3}, {X: 0, Y: 2}},
+		},
+		{
+			name:     "radius 2 is a disk",
+			radius:   2,
+			sensed:   []model.Position{{X: 2, Y: 2}},
+			revealed: []int{13},
+			known:    []model.Position{{X: 1, Y: 1}, {X: 0, Y: 2}, {X: 4, Y: 2}, {X: 2, Y: 4}},
+			unknown:  []model.Position{{X: 0, Y: 0}, {X: 4, Y: 4}, {X: 0, Y: 1}},
+		},
+		{
+			name:     "a corner sees only the grid",
+			radius:   1,
+			sensed:   []model.Position{{X: 0, Y: 0}},
+			revealed: []int{3},
+			known:    []model.Position{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}},
+			unknown:  []model.Position{{X: -1, Y: 0}, {X: 4, Y: 0}},
+		},
+		{
+			name:     "torus sees across the edges",
+			radius:   1,
+			topology: environment.Torus,
+			sensed:   []model.Position{{X: 0, Y: 0}},
+			revealed: []int{5},
+			known:    []model.Position{{X: 4, Y: 0}, {X: 0, Y: 4}, {X: -1, Y: 0}},
+			unknown:  []model.Position{{X: 4, Y: 4}},
+		},
+		{
+			name:     "cells are revealed once",
+			radius:   1,
+			sensed:   []model.Position{{X: 2, Y: 2}, {X: 2, Y: 3}, {X: 2, Y: 2}},
+			revealed: []int{5, 3, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := environment.NewEnvironment(5, 5, nil)
+			if tt.topology != nil {
+				env.SetTopology(tt.topology)
+			}
+			belief := NewBelief(env, tt.radius)
+
+			for i, position := range tt.sensed {
+				if revealed := belief.Sense(position); revealed != tt.revealed[i] {
+					t.Errorf("Sense(%v) = %d, expected %d", position, revealed, tt.revealed[i])
+				}
+			}
+			for _, position := range tt.known {
+				if !belief.IsKnown(position) {
+					t.Errorf("IsKnown(%v) = false, expected true", position)
+				}
+			}
+			for _, position := range tt.unknown {
+				if belief.IsKnown(position) {
+					t.Errorf("IsKnown(%v) = true, expected false", position)
+				}
+			}
+		})
+	}
+}
+
+func TestBelief_Explored(t *testing.T) {
+	belief := NewBelief(environment.NewEnvironment(4, 5, nil), 1)
+	if belief.Explored() != 0 || belief.Known() != 0 {
+		t.Errorf("Expected nothing explored, got %v (%d cells)", belief.Explored(), belief.Known())
+	}
+
+	belief.Sense(model.Position{X: 1, Y: 1})
+	if belief.Known() != 5 || belief.Explored() != 0.25 {
+		t.Errorf("Expected 5 cells and 0.25 explored, got %d cells and %v", belief.Known(), belief.Explored())
+	}
+}
+
+func TestBelief_UnknownCellsAreFree(t *testing.T) {
+	env := environment.NewEnvironment(5, 5, []model.Position{{X: 1, Y: 0}, {X: 4, Y: 4}})
+	env.SetTerrain(map[model.Position]string{{X: 0, Y: 1}: "sand", {X: 3, Y: 4}: "sand"})
+	belief := NewBelief(env, 1)
+	belief.Sense(model.Position{X: 0, Y: 0})
+
+	tests := []struct {
+		name     string
+		position model.Position
+		status   environment.CanMoveStatus
+		obstacle bool
+		terrain  string
+		cost     float64
+	}{
+		{name: "known obstacle", position: model.Position{X: 1, Y: 0}, status: environment.ObstacleEncountered, obstacle: true, cost: 1},
+		{name: "known terrain", position: model.Position{X: 0, Y: 1}, status: environment.Success, terrain: "sand", cost: 2},
+		{name: "unknown obstacle", position: model.Position{X: 4, Y: 4}, status: environment.Success, cost: 1},
+		{name: "unknown terrain", position: model.Position{X: 3, Y: 4}, status: environment.Success, cost: 1},
+		{name: "off the grid", position: model.Position{X: -1, Y: 0}, status: environment.OutOfBounds, cost: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if status := belief.CanMove(tt.position); status != tt.status {
+				t.Errorf("CanMove() = %v, expected %v", status, tt.status)
+			}
+			if status := belief.CanMoveFrom(model.Position{X: 0, Y: 0}, tt.position); status != tt.status {
+				t.Errorf("CanMoveFrom() = %v, expected %v", status, tt.status)
+			}
+			if cell := belief.Cell(tt.position); cell.IsObstacle != tt.obstacle || cell.Terrain != tt.terrain {
+				t.Errorf("Cell() = %+v, expected obstacle %v terrain %q", cell, tt.obstacle, tt.terrain)
+			}
+			if terrain := belief.TerrainAt(tt.position); terrain != tt.terrain {
+				t.Errorf("TerrainAt() = %q, expected %q", terrain, tt.terrain)
+			}
+			if cost := belief.TerrainCost(tt.position); cost != tt.cost {
+				t.Errorf("TerrainCost() = %v, expected %v", cost, tt.cost)
+			}
+		})
+	}
+}
+
+func TestBelief_GetGrid(t *testing.T) {
+	env := environment.NewEnvironment(3, 3, []model.Position{{X: 0, Y: 1}, {X: 2, Y: 2}})
+	belief := NewBelief(env, 1)
+	belief.Sense(model.Position{X: 0, Y: 0})
+
+	grid := belief.GetGrid()
+	if !grid[0][1].IsObstacle {
+		t.Error("Expected the sensed obstacle at (0, 1)")
+	}
+	if grid[2][2].IsObstacle {
+		t.Error("Expected the unsensed obstacle at (2, 2) to be unknown")
+	}
+	if grid[2][2].Position != (model.Position{X: 2, Y: 2}) {
+		t.Errorf("Expected unknown cells to keep their position, got %v", grid[2][2].Position)
+	}
+	// The truth is untouched
+	if !env.GetGrid()[2][2].IsObstacle {
+		t.Error("Expected the environment to keep its obstacle at (2, 2)")
+	}
+}
@@ -0,0 +1,291 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: knowledge.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "mars-rover-navigation/src/model"
+	environment "mars-rover-navigation/src/modules/environment"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBelief is a mock of Belief interface.
+type MockBelief struct {
+	ctrl     *gomock.Controller
+	recorder *MockBeliefMockRecorder
+}
+
+// MockBeliefMockRecorder is the mock recorder for MockBelief.
+type MockBeliefMockRecorder struct {
+	mock *MockBelief
+}
+
+// NewMockBelief creates a new mock instance.
+func NewMockBelief(ctrl *gomock.Controller) *MockBelief {
+	mock := &MockBelief{ctrl: ctrl}
+	mock.recorder = &MockBeliefMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBelief) EXPECT() *MockBeliefMockRecorder {
+	return m.recorder
+}
+
+// CanMove mocks base method.
+func (m *MockBelief) CanMove(actorPosition model.Position) environment.CanMoveStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CanMove", actorPosition)
+	ret0, _ := ret[0].(environment.CanMoveStatus)
+	return ret0
+}
+
+// CanMove indicates an expected call of CanMove.
+func (mr *MockBeliefMockRecorder) CanMove(actorPosition interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanMove", reflect.TypeOf((*MockBelief)(nil).CanMove), actorPosition)
+}
+
+// CanMoveFrom mocks base method.
+func (m *MockBelief) CanMoveFrom(from, to model.Position) environment.CanMoveStatus {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CanMoveFrom", from, to)
+	ret0, _ := ret[0].(environment.CanMoveStatus)
+	return ret0
+}
+
+// CanMoveFrom indicates an expected call of CanMoveFrom.
+func (mr *MockBeliefMockRecorder) CanMoveFrom(from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanMoveFrom", reflect.TypeOf((*MockBelief)(nil).CanMoveFrom), from, to)
+}
+
+// Cell mocks base method.
+func (m *MockBelief) Cell(position model.Position) model.Cell {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Cell", position)
+	ret0, _ := ret[0].(model.Cell)
+	return ret0
+}
+
+// Cell indicates an expected call of Cell.
+func (mr *MockBeliefMockRecorder) Cell(position interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cell", reflect.TypeOf((*MockBelief)(nil).Cell), position)
+}
+
+// Distance mocks base method.
+func (m *MockBelief) Distance(from, to model.Position) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Distance", from, to)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Distance indicates an expected call of Distance.
+func (mr *MockBeliefMockRecorder) Distance(from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Distance", reflect.TypeOf((*MockBelief)(nil).Distance), from, to)
+}
+
+// Explored mocks base method.
+func (m *MockBelief) Explored() float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Explored")
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// Explored indicates an expected call of Explored.
+func (mr *MockBeliefMockRecorder) Explored() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Explored", reflect.TypeOf((*MockBelief)(nil).Explored))
+}
+
+// GetGrid mocks base method.
+func (m *MockBelief) GetGrid() [][]model.Cell {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGrid")
+	ret0, _ := ret[0].([][]model.Cell)
+	return ret0
+}
+
+// GetGrid indicates an expected call of GetGrid.
+func (mr *MockBeliefMockRecorder) GetGrid() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGrid", reflect.TypeOf((*MockBelief)(nil).GetGrid))
+}
+
+// IsKnown mocks base method.
+func (m *MockBelief) IsKnown(position model.Position) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsKnown", position)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsKnown indicates an expected call of IsKnown.
+func (mr *MockBeliefMockRecorder) IsKnown(position interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsKnown", reflect.TypeOf((*MockBelief)(nil).IsKnown), position)
+}
+
+// Known mocks base method.
+func (m *MockBelief) Known() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Known")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Known indicates an expected call of Known.
+func (mr *MockBeliefMockRecorder) Known() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Known", reflect.TypeOf((*MockBelief)(nil).Known))
+}
+
+// Normalize mocks base method.
+func (m *MockBelief) Normalize(position model.Position) (model.Position, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Normalize", position)
+	ret0, _ := ret[0].(model.Position)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Normalize indicates an expected call of Normalize.
+func (mr *MockBeliefMockRecorder) Normalize(position interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Normalize", reflect.TypeOf((*MockBelief)(nil).Normalize), position)
+}
+
+// Sense mocks base method.
+func (m *MockBelief) Sense(position model.Position) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sense", position)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Sense indicates an expected call of Sense.
+func (mr *MockBeliefMockRecorder) Sense(position interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sense", reflect.TypeOf((*MockBelief)(nil).Sense), position)
+}
+
+// SetCornerCutting mocks base method.
+func (m *MockBelief) SetCornerCutting(allowed bool) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetCornerCutting", allowed)
+}
+
+// SetCornerCutting indicates an expected call of SetCornerCutting.
+func (mr *MockBeliefMockRecorder) SetCornerCutting(allowed interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCornerCutting", reflect.TypeOf((*MockBelief)(nil).SetCornerCutting), allowed)
+}
+
+// SetDynamicObstacles mocks base method.
+func (m *MockBelief) SetDynamicObstacles(positions []model.Position) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetDynamicObstacles", positions)
+}
+
+// SetDynamicObstacles indicates an expected call of SetDynamicObstacles.
+func (mr *MockBeliefMockRecorder) SetDynamicObstacles(positions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDynamicObstacles", reflect.TypeOf((*MockBelief)(nil).SetDynamicObstacles), positions)
+}
+
+// SetRoverType mocks base method.
+func (m *MockBelief) SetRoverType(roverType string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetRoverType", roverType)
+}
+
+// SetRoverType indicates an expected call of SetRoverType.
+func (mr *MockBeliefMockRecorder) SetRoverType(roverType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRoverType", reflect.TypeOf((*MockBelief)(nil).SetRoverType), roverType)
+}
+
+// SetTerrain mocks base method.
+func (m *MockBelief) SetTerrain(terrain map[model.Position]string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTerrain", terrain)
+}
+
+// SetTerrain indicates an expected call of SetTerrain.
+func (mr *MockBeliefMockRecorder) SetTerrain(terrain interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTerrain", reflect.TypeOf((*MockBelief)(nil).SetTerrain), terrain)
+}
+
+// SetTerrainClasses mocks base method.
+func (m *MockBelief) SetTerrainClasses(classes map[string]environment.TerrainClass) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTerrainClasses", classes)
+}
+
+// SetTerrainClasses indicates an expected call of SetTerrainClasses.
+func (mr *MockBeliefMockRecorder) SetTerrainClasses(classes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTerrainClasses", reflect.TypeOf((*MockBelief)(nil).SetTerrainClasses), classes)
+}
+
+// SetTopology mocks base method.
+func (m *MockBelief) SetTopology(topology environment.Topology) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTopology", topology)
+}
+
+// SetTopology indicates an expected call of SetTopology.
+func (mr *MockBeliefMockRecorder) SetTopology(topology interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTopology", reflect.TypeOf((*MockBelief)(nil).SetTopology), topology)
+}
+
+// Size mocks base method.
+func (m *MockBelief) Size() model.Size {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Size")
+	ret0, _ := ret[0].(model.Size)
+	return ret0
+}
+
+// Size indicates an expected call of Size.
+func (mr *MockBeliefMockRecorder) Size() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Size", reflect.TypeOf((*MockBelief)(nil).Size))
+}
+
+// TerrainAt mocks base method.
+func (m *MockBelief) TerrainAt(position model.Position) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TerrainAt", position)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// TerrainAt indicates an expected call of TerrainAt.
+func (mr *MockBeliefMockRecorder) TerrainAt(position interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TerrainAt", reflect.TypeOf((*MockBelief)(nil).TerrainAt), position)
+}
+
+// TerrainCost mocks base method.
+func (m *MockBelief) TerrainCost(position model.Position) float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TerrainCost", position)
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// TerrainCost indicates an expected call of TerrainCost.
+func (mr *MockBeliefMockRecorder) TerrainCost(position interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TerrainCost", reflect.TypeOf((*MockBelief)(nil).TerrainCost), position)
+}
@@ -0,0 +1,121 @@
+//go:generate go run github.com/golang/mock/mockgen -source=mission.go -destination=./mock/mock_mission.go -package=mock
+
+package mission
+
+import (
+	_ "embed"
+
+	"mars-rover-navigation/src/model"
+	"mars-rover-navigation/src/modules/game"
+)
+
+// Schema is the published JSON Schema of the mission document.
+//
+//go:embed mission.schema.json
+var Schema []byte
+
+type Loader interface {
+	Load(path string) (*Mission, error)
+	Parse(data []byte, format Format) (*Mission, error)
+	// Compile replaces the command programs of m with primitive commands, Load and Parse already do it
+	Compile(m *Mission) error
+}
+
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+)
+
+type Mission struct {
+	// ID names the mission in batch results
+	ID        string           `json:"id,omitempty" yaml:"id,omitempty"`
+	Grid      Grid             `json:"grid" yaml:"grid"`
+	Obstacles []model.Position `json:"obstacles,omitempty" yaml:"obstacles,omitempty" validate:"dive"`
+	Start     Pose             `json:"start,omitzero" yaml:"start,omitempty"`
+	// Commands is a command program, see command.Compiler
+	Commands string `json:"commands,omitempty" yaml:"commands,omitempty" validate:"required_without=Rovers"`
+	// Macros are named programs that Commands calls as @name
+	Macros map[string]string `json:"macros,omitempty" yaml:"macros,omitempty"`
+	// Rovers declares a fleet, it replaces Start and Commands
+	Rovers []Rover `json:"rovers,omitempty" yaml:"rovers,omitempty" validate:"dive"`
+	// Terrain names the terrain class of cells, the rest is plain ground
+	Terrain []TerrainCell `json:"terrain,omitempty" yaml:"terrain,omitempty" validate:"dive"`
+	// TerrainClasses adds terrain classes to the built-in environment.TerrainClasses, a name here replaces a built-in one
+	TerrainClasses map[string]TerrainClass `json:"terrain_classes,omitempty" yaml:"terrain_classes,omitempty" validate:"dive"`
+	// Profiles adds rover profiles to the built-in game.Profiles, a name here replaces a built-in one
+	Profiles map[string]Profile `json:"profiles,omitempty" yaml:"profiles,omitempty" validate:"dive"`
+	Options  Options            `json:"options,omitzero" yaml:"options,omitempty"`
+}
+
+type Grid struct {
+	Width  int `json:"width" yaml:"width" validate:"gt=0"`
+	Height int `json:"height" yaml:"height" validate:"gt=0"`
+}
+
+type Pose struct {
+	X         int             `json:"x" yaml:"x" validate:"gte=0"`
+	Y         int             `json:"y" yaml:"y" validate:"gte=0"`
+	Direction model.Direction `json:"direction,omitempty" yaml:"direction,omitempty" validate:"omitempty,oneof=N E S W NE SE SW NW"`
+}
+
+type Rover struct {
+	ID       string `json:"id,omitempty" yaml:"id,omitempty"`
+	Start    Pose   `json:"start,omitzero" yaml:"start,omitempty"`
+	Commands string `json:"commands,omitempty" yaml:"commands,omitempty" validate:"required"`
+	// Profile replaces options.profile for this rover
+	Profile string `json:"profile,omitempty" yaml:"profile,omitempty"`
+}
+
+type TerrainCell struct {
+	X    int    `json:"x" yaml:"x" validate:"gte=0"`
+	Y    int    `json:"y" yaml:"y" validate:"gte=0"`
+	Type string `json:"type" yaml:"type" validate:"required"`
+}
+
+// TerrainClass is a kind of ground, see environment.TerrainClass.
+type TerrainClass struct {
+	// Cost defaults to 1, the cost of plain ground
+	Cost          float64  `json:"cost" yaml:"cost" validate:"omitempty,gte=1"`
+	ImpassableFor []string `json:"impassable_for,omitempty" yaml:"impassable_for,omitempty"`
+}
+
+// Profile is the battery of a kind of rover, see game.EnergyProfile.
+type Profile struct {
+	Charge  float64            `json:"charge" yaml:"charge" validate:"gte=0"`
+	Move    float64            `json:"move" yaml:"move" validate:"gte=0"`
+	Turn    float64            `json:"turn" yaml:"turn" validate:"gte=0"`
+	Reverse float64            `json:"reverse" yaml:"reverse" validate:"gte=0"`
+	Terrain map[string]float64 `json:"terrain,omitempty" yaml:"terrain,omitempty" validate:"dive,gte=0"`
+}
+
+// Options tune how the mission is executed, every field is optional.
+type Options struct {
+	Trace          bool   `json:"trace,omitempty" yaml:"trace,omitempty"`
+	FleetMode      string `json:"fleet_mode,omitempty" yaml:"fleet_mode,omitempty" validate:"omitempty,oneof=sequential interleaved"`
+	ObstaclePolicy string `json:"obstacle_policy,omitempty" yaml:"obstacle_policy,omitempty" validate:"omitempty,oneof=abort skip skip_with_limit replan"`
+	SkipLimit      int    `json:"skip_limit,omitempty" yaml:"skip_limit,omitempty" validate:"gte=0"`
+	Topology       string `json:"topology,omitempty" yaml:"topology,omitempty" validate:"omitempty,oneof=bounded wrap_x wrap_y torus"`
+	Compass        string `json:"compass,omitempty" yaml:"compass,omitempty" validate:"omitempty,oneof=four eight"`
+	CornerCutting  bool   `json:"corner_cutting,omitempty" yaml:"corner_cutting,omitempty"`
+	// Profile runs the rovers on a battery, unlimited when empty
+	Profile string `json:"profile,omitempty" yaml:"profile,omitempty"`
+	// SensorRadius runs every rover in fog of war, it sees the cells within this radius only
+	SensorRadius int `json:"sensor_radius,omitempty" yaml:"sensor_radius,omitempty" validate:"gte=0,lte=256"`
+}
+
+// Report holds the outcome of Navigate, Fleet is set for a fleet mission and Rover otherwise.
+type Report struct {
+	Rover *game.ExtendedResult
+	Fleet *game.FleetResult
+}
+
+type FieldError struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+type ValidationError struct {
+	Errors []FieldError `json:"errors"`
+}
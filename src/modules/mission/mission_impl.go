@@ -0,0 +1,353 @@
+package mission
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mars-rover-navigation/src/model"
+	"mars-rover-navigation/src/modules/command"
+	"mars-rover-navigation/src/modules/environment"
+	"mars-rover-navigation/src/modules/game"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"gopkg.in/yaml.v3"
+)
+
+type loaderImpl struct {
+	validate *validator.Validate
+	compiler command.Compiler
+}
+
+func NewLoader() *loaderImpl {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	// Report paths with the document field names instead of the Go ones
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	validate.RegisterStructValidation(validateMission, Mission{})
+
+	return &loaderImpl{
+		validate: validate,
+		compiler: command.NewCompiler(),
+	}
+}
+
+func (l *loaderImpl) Load(path string) (*Mission, error) {
+	format, err := FormatFromPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read mission file: %w", err)
+	}
+
+	return l.Parse(data, format)
+}
+
+func (l *loaderImpl) Parse(data []byte, format Format) (*Mission, error) {
+	var mission Mission
+
+	switch format {
+	case FormatJSON:
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&mission); err != nil {
+			return nil, fmt.Errorf("decode mission json: %w", err)
+		}
+	case FormatYAML:
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&mission); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("decode mission yaml: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported mission format: %q", format)
+	}
+
+	if err := l.validate.Struct(mission); err != nil {
+		return nil, toValidationError(err)
+	}
+
+	if mission.Start.Direction == "" {
+		mission.Start.Direction = model.North
+	}
+	for i := range mission.Rovers {
+		if mission.Rovers[i].ID == "" {
+			mission.Rovers[i].ID = fmt.Sprintf("rover-%d", i+1)
+		}
+		if mission.Rovers[i].Start.Direction == "" {
+			mission.Rovers[i].Start.Direction = model.North
+		}
+	}
+
+	if err := l.Compile(&mission); err != nil {
+		return nil, err
+	}
+
+	return &mission, nil
+}
+
+func (l *loaderImpl) Compile(m *Mission) error {
+	result := &ValidationError{}
+
+	compile := func(path, source string) string {
+		commands, err := l.compiler.Compile(source, m.Macros)
+		if err == nil {
+			return commands
+		}
+
+		var syntaxErr *command.SyntaxError
+		if errors.As(err, &syntaxErr) && syntaxErr.Macro != "" {
+			// The error sits in the macro body, not in the program that called it
+			path = "macros." + syntaxErr.Macro
+		}
+		result.Errors = append(result.Errors, FieldError{Path: path, Message: err.Error()})
+		return source
+	}
+
+	m.Commands = compile("commands", m.Commands)
+	for i := range m.Rovers {
+		m.Rovers[i].Commands = compile(fmt.Sprintf("rovers[%d].commands", i), m.Rovers[i].Commands)
+	}
+
+	if len(result.Errors) > 0 {
+		return result
+	}
+	return nil
+}
+
+// Navigate runs the mission with g, as a fleet when it declares rovers.
+func Navigate(g game.Game, m *Mission) Report {
+	options := game.Options{
+		Trace:     m.Options.Trace,
+		Policy:    game.ObstaclePolicy(m.Options.ObstaclePolicy),
+		SkipLimit: m.Options.SkipLimit,
+		Compass:   game.Compass(m.Options.Compass),
+
+		CornerCutting: m.Options.CornerCutting,
+		SensorRadius:  m.Options.SensorRadius,
+	}
+	// Loaded missions are validated, an unknown profile runs without a battery
+	options.Energy, _ = m.EnergyProfile(m.Options.Profile)
+	if len(m.Terrain) > 0 {
+		options.Terrain = make(map[model.Position]string, len(m.Terrain))
+		for _, cell := range m.Terrain {
+			options.Terrain[model.Position{X: cell.X, Y: cell.Y}] = cell.Type
+		}
+	}
+	if len(m.TerrainClasses) > 0 {
+		options.TerrainClasses = m.terrainClasses()
+	}
+	if m.Options.Topology != "" {
+		// Loaded missions are validated, an unknown name keeps the bounded default
+		options.Topology, _ = environment.ParseTopology(m.Options.Topology)
+	}
+
+	if len(m.Rovers) > 0 {
+		rovers := make([]game.RoverMission, 0, len(m.Rovers))
+		for _, r := range m.Rovers {
+			energy, _ := m.EnergyProfile(r.Profile)
+			rovers = append(rovers, game.RoverMission{
+				ID:        r.ID,
+				Start:     model.Position{X: r.Start.X, Y: r.Start.Y},
+				Direction: r.Start.Direction,
+				Commands:  r.Commands,
+				Energy:    energy,
+			})
+		}
+
+		fleet := g.NavigateFleet(m.Grid.Width, m.Grid.Height, m.Obstacles, rovers, game.FleetMode(m.Options.FleetMode), options)
+		return Report{Fleet: &fleet}
+	}
+
+	result := g.NavigateRoverWithOptions(m.Grid.Width, m.Grid.Height, m.Obstacles,
+		model.Position{X: m.Start.X, Y: m.Start.Y}, m.Start.Direction, m.Commands, options)
+	return Report{Rover: &result}
+}
+
+// EnergyProfile finds a profile of the mission or a built-in one, it is nil for an empty name.
+func (m *Mission) EnergyProfile(name string) (*game.EnergyProfile, bool) {
+	if name == "" {
+		return nil, true
+	}
+	if profile, ok := m.Profiles[name]; ok {
+		return &game.EnergyProfile{
+			Name:               name,
+			Charge:             profile.Charge,
+			Move:               profile.Move,
+			Turn:               profile.Turn,
+			Reverse:            profile.Reverse,
+			TerrainMultipliers: profile.Terrain,
+		}, true
+	}
+	if profile, ok := game.Profiles[name]; ok {
+		return &profile, true
+	}
+	return nil, false
+}
+
+// terrainClasses merges the classes of the mission over the built-in ones.
+func (m *Mission) terrainClasses() map[string]environment.TerrainClass {
+	classes := make(map[string]environment.TerrainClass, len(environment.TerrainClasses)+len(m.TerrainClasses))
+	for name, class := range environment.TerrainClasses {
+		classes[name] = class
+	}
+	for name, class := range m.TerrainClasses {
+		if class.Cost == 0 {
+			class.Cost = 1
+		}
+		classes[name] = environment.TerrainClass{Cost: class.Cost, ImpassableFor: class.ImpassableFor}
+	}
+	return classes
+}
+
+func FormatFromPath(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FormatJSON, nil
+	case ".yaml", ".yml":
+		return FormatYAML, nil
+	}
+	return "", fmt.Errorf("unsupported mission file extension: %q (use .json, .yaml or .yml)", filepath.Ext(path))
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, fieldErr := range e.Errors {
+		messages = append(messages, fieldErr.Path+": "+fieldErr.Message)
+	}
+	return "invalid mission: " + strings.Join(messages, "; ")
+}
+
+// validateMission checks the rules that span several fields.
+func validateMission(sl validator.StructLevel) {
+	mission := sl.Current().Interface().(Mission)
+
+	if len(mission.Rovers) > 0 {
+		if mission.Commands != "" {
+			sl.ReportError(mission.Commands, "commands", "Commands", "excluded_with", "rovers")
+		}
+		if mission.Start != (Pose{}) {
+			sl.ReportError(mission.Start, "start", "Start", "excluded_with", "rovers")
+		}
+	}
+
+	if game.Compass(mission.Options.Compass) != game.CompassEight {
+		if mission.Start.Direction.IsDiagonal() {
+			sl.ReportError(mission.Start.Direction, "start.direction", "Direction", "compass_eight", "")
+		}
+		for i, rover := range mission.Rovers {
+			if rover.Start.Direction.IsDiagonal() {
+				sl.ReportError(rover.Start.Direction, fmt.Sprintf("rovers[%d].start.direction", i), "Direction", "compass_eight", "")
+			}
+		}
+	}
+
+	if _, ok := mission.EnergyProfile(mission.Options.Profile); !ok {
+		sl.ReportError(mission.Options.Profile, "options.profile", "Profile", "profile", "")
+	}
+	for i, rover := range mission.Rovers {
+		if _, ok := mission.EnergyProfile(rover.Profile); !ok {
+			sl.ReportError(rover.Profile, fmt.Sprintf("rovers[%d].profile", i), "Profile", "profile", "")
+		}
+	}
+
+	if mission.Grid.Width <= 0 || mission.Grid.Height <= 0 {
+		return
+	}
+
+	classes := mission.terrainClasses()
+	for i, cell := range mission.Terrain {
+		if _, ok := classes[cell.Type]; cell.Type != "" && !ok {
+			sl.ReportError(cell.Type, fmt.Sprintf("terrain[%d].type", i), "Type", "terrain_class", "")
+		}
+		if !mission.Grid.contains(cell.X, cell.Y) {
+			sl.ReportError(cell, fmt.Sprintf("terrain[%d]", i), "Terrain", "in_grid", "")
+		}
+	}
+
+	for i, obstacle := range mission.Obstacles {
+		if !mission.Grid.contains(obstacle.X, obstacle.Y) {
+			sl.ReportError(obstacle, fmt.Sprintf("obstacles[%d]", i), "Obstacles", "in_grid", "")
+		}
+	}
+
+	if len(mission.Rovers) == 0 && !mission.Grid.contains(mission.Start.X, mission.Start.Y) {
+		sl.ReportError(mission.Start, "start", "Start", "in_grid", "")
+	}
+
+	for i, rover := range mission.Rovers {
+		if !mission.Grid.contains(rover.Start.X, rover.Start.Y) {
+			sl.ReportError(rover.Start, fmt.Sprintf("rovers[%d].start", i), "Start", "in_grid", "")
+		}
+	}
+}
+
+func (g Grid) contains(x, y int) bool {
+	return x >= 0 && x < g.Width && y >= 0 && y < g.Height
+}
+
+func toValidationError(err error) error {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return err
+	}
+
+	result := &ValidationError{}
+	for _, fieldErr := range validationErrors {
+		result.Errors = append(result.Errors, FieldError{
+			Path:    fieldPath(fieldErr.Namespace()),
+			Message: fieldMessage(fieldErr),
+		})
+	}
+	return result
+}
+
+// fieldPath strips the root struct name, "Mission.obstacles[1].x" -> "obstacles[1].x"
+func fieldPath(namespace string) string {
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return namespace
+}
+
+func fieldMessage(fieldErr validator.FieldError) string {
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "required_without":
+		return "is required when rovers is not set"
+	case "excluded_with":
+		return fmt.Sprintf("cannot be combined with %s", fieldErr.Param())
+	case "gt":
+		return fmt.Sprintf("must be greater than %s, got %v", fieldErr.Param(), fieldErr.Value())
+	case "gte":
+		return fmt.Sprintf("must be greater than or equal to %s, got %v", fieldErr.Param(), fieldErr.Value())
+	case "lte":
+		return fmt.Sprintf("must be less than or equal to %s, got %v", fieldErr.Param(), fieldErr.Value())
+	case "oneof":
+		return fmt.Sprintf("must be one of [%s], got %q", fieldErr.Param(), fieldErr.Value())
+	case "in_grid":
+		return "must be within the grid"
+	case "terrain_class":
+		return fmt.Sprintf("unknown terrain class %q, define it under terrain_classes", fieldErr.Value())
+	case "profile":
+		return fmt.Sprintf("unknown profile %q, define it under profiles", fieldErr.Value())
+	case "compass_eight":
+		return fmt.Sprintf("diagonal heading %v needs options.compass eight", fieldErr.Value())
+	}
+	return fmt.Sprintf("failed on %q validation", fieldErr.Tag())
+}
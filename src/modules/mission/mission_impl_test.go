@@ -0,0 +1,570 @@
+package mission
+
+import (
+	"encoding/json"
+	"errors"
+	"mars-rover-navigation/src/model"
+	"mars-rover-navigation/src/modules/environment"
+	"mars-rover-navigation/src/modules/game"
+	gameMock "mars-rover-navigation/src/modules/game/mock"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestNewLoader(t *testing.T) {
+	loader := NewLoader()
+	if loader == nil {
+		t.Fatal("NewLoader() returned nil")
+	}
+	if loader.validate == nil {
+		t.Error("validate should not be nil")
+	}
+	if loader.compiler == nil {
+		t.Error("compiler should not be nil")
+	}
+}
+
+func TestParse_JSON(t *testing.T) {
+	data := []byte(`{
+		"grid": {"width": 20, "height": 5},
+		"obstacles": [{"x": 1, "y": 2}, {"x": 3, "y": 3}],
+		"start": {"x": 4, "y": 0, "direction": "E"},
+		"commands": "MMLM",
+		"options": {}
+	}`)
+
+	mission, err := NewLoader().Parse(data, FormatJSON)
+	if err != nil {
+		t.Fatalf("Parse() error = %v, want nil", err)
+	}
+
+	expected := &Mission{
+		Grid:      Grid{Width: 20, Height: 5},
+		Obstacles: []model.Position{{X: 1, Y: 2}, {X: 3, Y: 3}},
+		Start:     Pose{X: 4, Y: 0, Direction: model.East},
+		Commands:  "MMLM",
+	}
+	if !reflect.DeepEqual(mission, expected) {
+		t.Errorf("Parse() = %+v, want %+v", mission, expected)
+	}
+}
+
+func TestParse_YAML(t *testing.T) {
+	data := []byte(`
+grid:
+  width: 5
+  height: 5
+obstacles:
+  - {x: 1, y: 2}
+  - x: 3
+    y: 3
+commands: MMMRM
+`)
+
+	mission, err := NewLoader().Parse(data, FormatYAML)
+	if err != nil {
+		t.Fatalf("Parse() error = %v, want nil", err)
+	}
+
+	expected := &Mission{
+		Grid:      Grid{Width: 5, Height: 5},
+		Obstacles: []model.Position{{X: 1, Y: 2}, {X: 3, Y: 3}},
+		Start:     Pose{X: 0, Y: 0, Direction: model.North},
+		Commands:  "MMMRM",
+	}
+	if !reflect.DeepEqual(mission, expected) {
+		t.Errorf("Parse() = %+v, want %+v", mission, expected)
+	}
+}
+
+func TestParse_Fleet(t *testing.T) {
+	data := []byte(`
+grid: {width: 5, height: 5}
+rovers:
+  - id: alpha
+    start: {x: 0, y: 0, direction: E}
+    commands: MM
+  - start: {x: 4, y: 4}
+    commands: LM
+options:
+  fleet_mode: interleaved
+`)
+
+	mission, err := NewLoader().Parse(data, FormatYAML)
+	if err != nil {
+		t.Fatalf("Parse() error = %v, want nil", err)
+	}
+
+	expected := []Rover{
+		{ID: "alpha", Start: Pose{X: 0, Y: 0, Direction: model.East}, Commands: "MM"},
+		{ID: "rover-2", Start: Pose{X: 4, Y: 4, Direction: model.North}, Commands: "LM"},
+	}
+	if !reflect.DeepEqual(mission.Rovers, expected) {
+		t.Errorf("Parse() rovers = %+v, want %+v", mission.Rovers, expected)
+	}
+	if mission.Options.FleetMode != "interleaved" {
+		t.Errorf("Parse() fleet mode = %q, want interleaved", mission.Options.FleetMode)
+	}
+}
+
+func TestParse_Profiles(t *testing.T) {
+	data := []byte(`
+grid: {width: 5, height: 5}
+terrain:
+  - {x: 1, y: 1, type: sand}
+profiles:
+  hauler: {charge: 50, move: 2, turn: 1, reverse: 3, terrain: {sand: 1.5}}
+rovers:
+  - {commands: M, profile: hauler}
+  - {start: {x: 4, y: 4}, commands: L}
+options:
+  profile: scout
+`)
+
+	mission, err := NewLoader().Parse(data, FormatYAML)
+	if err != nil {
+		t.Fatalf("Parse() error = %v, want nil", err)
+	}
+
+	if expected := []TerrainCell{{X: 1, Y: 1, Type: "sand"}}; !reflect.DeepEqual(mission.Terrain, expected) {
+		t.Errorf("Parse() terrain = %+v, want %+v", mission.Terrain, expected)
+	}
+	hauler, ok := mission.EnergyProfile("hauler")
+	expected := &game.EnergyProfile{Name: "hauler", Charge: 50, Move: 2, Turn: 1, Reverse: 3, TerrainMultipliers: map[string]float64{"sand": 1.5}}
+	if !ok || !reflect.DeepEqual(hauler, expected) {
+		t.Errorf("EnergyProfile(hauler) = %+v, want %+v", hauler, expected)
+	}
+	if scout, ok := mission.EnergyProfile("scout"); !ok || !reflect.DeepEqual(*scout, game.Profiles["scout"]) {
+		t.Errorf("EnergyProfile(scout) = %+v, want the built-in profile", scout)
+	}
+	if none, ok := mission.EnergyProfile(""); !ok || none != nil {
+		t.Errorf("EnergyProfile(\"\") = %+v, want nil", none)
+	}
+}
+
+func TestNavigate_TerrainClasses(t *testing.T) {
+	data := []byte(`
+grid: {width: 5, height: 5}
+terrain:
+  - {x: 0, y: 1, type: ice}
+  - {x: 0, y: 2, type: sand}
+terrain_classes:
+  ice: {impassable_for: [heavy]}
+  sand: {cost: 1.5}
+commands: MM
+`)
+
+	mission, err := NewLoader().Parse(data, FormatYAML)
+	if err != nil {
+		t.Fatalf("Parse() error = %v, want nil", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGame := gameMock.NewMockGame(ctrl)
+	mockGame.EXPECT().NavigateRoverWithOptions(5, 5, []model.Position(nil), model.Position{}, model.North, "MM", gomock.Any()).
+		DoAndReturn(func(_, _ int, _ []model.Position, _ model.Position, _ model.Direction, _ string, options game.Options) game.ExtendedResult {
+			if class := options.TerrainClasses["ice"]; class.Cost != 1 || !reflect.DeepEqual(class.ImpassableFor, []string{"heavy"}) {
+				t.Errorf("ice class = %+v, want cost 1 impassable for heavy", class)
+			}
+			if class := options.TerrainClasses["sand"]; class.Cost != 1.5 || class.ImpassableFor != nil {
+				t.Errorf("sand class = %+v, want the mission class replacing the built-in one", class)
+			}
+			if class := options.TerrainClasses["rock"]; class.Cost != environment.TerrainClasses["rock"].Cost {
+				t.Errorf("rock class = %+v, want the built-in class", class)
+			}
+			return game.ExtendedResult{}
+		})
+
+	Navigate(mockGame, mission)
+}
+
+func TestParse_DecodeErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		format Format
+	}{
+		{name: "json syntax", data: `{"grid": `, format: FormatJSON},
+		{name: "json unknown field", data: `{"grid": {"width": 1, "height": 1}, "commands": "M", "speed": 3}`, format: FormatJSON},
+		{name: "json wrong type", data: `{"grid": {"width": "five", "height": 1}, "commands": "M"}`, format: FormatJSON},
+		{name: "yaml unknown field", data: "grid: {width: 1, height: 1}\ncommands: M\nspeed: 3\n", format: FormatYAML},
+		{name: "yaml syntax", data: "grid: [\n", format: FormatYAML},
+		{name: "unsupported format", data: `{}`, format: Format("toml")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewLoader().Parse([]byte(tt.data), tt.format)
+			if err == nil {
+				t.Error("Parse() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestParse_ValidationErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected []FieldError
+	}{
+		{
+			name: "missing grid and commands",
+			data: `{}`,
+			expected: []FieldError{
+				{Path: "grid.width", Message: "must be greater than 0, got 0"},
+				{Path: "grid.height", Message: "must be greater than 0, got 0"},
+				{Path: "commands", Message: "is required when rovers is not set"},
+			},
+		},
+		{
+			name: "obstacle outside grid",
+			data: `{"grid": {"width": 5, "height": 5}, "obstacles": [{"x": 1, "y": 1}, {"x": 5, "y": 1}], "commands": "M"}`,
+			expected: []FieldError{
+				{Path: "obstacles[1]", Message: "must be within the grid"},
+			},
+		},
+		{
+			name: "bad start pose",
+			data: `{"grid": {"width": 5, "height": 2}, "start": {"x": -1, "y": 2, "direction": "Q"}, "commands": "M"}`,
+			expected: []FieldError{
+				{Path: "start.x", Message: "must be greater than or equal to 0, got -1"},
+				{Path: "start.direction", Message: `must be one of [N E S W NE SE SW NW], got "Q"`},
+				{Path: "start", Message: "must be within the grid"},
+			},
+		},
+		{
+			name: "fleet combined with single rover fields",
+			data: `{"grid": {"width": 5, "height": 5}, "start": {"x": 1}, "commands": "M", "rovers": [{"commands": "M"}]}`,
+			expected: []FieldError{
+				{Path: "commands", Message: "cannot be combined with rovers"},
+				{Path: "start", Message: "cannot be combined with rovers"},
+			},
+		},
+		{
+			name: "invalid fleet rovers",
+			data: `{"grid": {"width": 5, "height": 5}, "rovers": [{"id": "a", "commands": "M"}, {"start": {"x": 9, "y": 0}}], "options": {"fleet_mode": "random"}}`,
+			expected: []FieldError{
+				{Path: "rovers[1].commands", Message: "is required"},
+				{Path: "options.fleet_mode", Message: `must be one of [sequential interleaved], got "random"`},
+				{Path: "rovers[1].start", Message: "must be within the grid"},
+			},
+		},
+		{
+			name: "invalid obstacle policy",
+			data: `{"grid": {"width": 5, "height": 5}, "commands": "M", "options": {"obstacle_policy": "retry", "skip_limit": -1}}`,
+			expected: []FieldError{
+				{Path: "options.obstacle_policy", Message: `must be one of [abort skip skip_with_limit replan], got "retry"`},
+				{Path: "options.skip_limit", Message: "must be greater than or equal to 0, got -1"},
+			},
+		},
+		{
+			name: "sensor radius beyond the cap",
+			data: `{"grid": {"width": 5, "height": 5}, "commands": "M", "options": {"sensor_radius": 300}}`,
+			expected: []FieldError{
+				{Path: "options.sensor_radius", Message: "must be less than or equal to 256, got 300"},
+			},
+		},
+		{
+			name: "unknown topology",
+			data: `{"grid": {"width": 5, "height": 5}, "commands": "M", "options": {"topology": "sphere"}}`,
+			expected: []FieldError{
+				{Path: "options.topology", Message: `must be one of [bounded wrap_x wrap_y torus], got "sphere"`},
+			},
+		},
+		{
+			name: "invalid energy profiles and terrain",
+			data: `{"grid": {"width": 5, "height": 5}, "rovers": [{"commands": "M", "profile": "ghost"}], "terrain": [{"x": 5, "y": 0, "type": "sand"}, {"x": 1, "y": 1}],
+				"profiles": {"weak": {"charge": -1, "terrain": {"sand": -2}}}, "options": {"profile": "weak"}}`,
+			expected: []FieldError{
+				{Path: "terrain[1].type", Message: "is required"},
+				{Path: "profiles[weak].charge", Message: "must be greater than or equal to 0, got -1"},
+				{Path: "profiles[weak].terrain[sand]", Message: "must be greater than or equal to 0, got -2"},
+				{Path: "rovers[0].profile", Message: `unknown profile "ghost", define it under profiles`},
+				{Path: "terrain[0]", Message: "must be within the grid"},
+			},
+		},
+		{
+			name: "invalid terrain classes",
+			data: `{"grid": {"width": 5, "height": 5}, "commands": "M", "terrain": [{"x": 1, "y": 1, "type": "lava"}], "terrain_classes": {"road": {"cost": 0.5}}}`,
+			expected: []FieldError{
+				{Path: "terrain_classes[road].cost", Message: "must be greater than or equal to 1, got 0.5"},
+				{Path: "terrain[0].type", Message: `unknown terrain class "lava", define it under terrain_classes`},
+			},
+		},
+		{
+			name: "diagonal heading without the eight-direction compass",
+			data: `{"grid": {"width": 5, "height": 5}, "rovers": [{"commands": "M"}, {"start": {"direction": "SW"}, "commands": "M"}], "options": {"compass": "four"}}`,
+			expected: []FieldError{
+				{Path: "rovers[1].start.direction", Message: "diagonal heading SW needs options.compass eight"},
+			},
+		},
+		{
+			name: "unknown compass",
+			data: `{"grid": {"width": 5, "height": 5}, "start": {"direction": "NE"}, "commands": "M", "options": {"compass": "six"}}`,
+			expected: []FieldError{
+				{Path: "options.compass", Message: `must be one of [four eight], got "six"`},
+				{Path: "start.direction", Message: "diagonal heading NE needs options.compass eight"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewLoader().Parse([]byte(tt.data), FormatJSON)
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Parse() error = %v, want *ValidationError", err)
+			}
+			if !reflect.DeepEqual(validationErr.Errors, tt.expected) {
+				t.Errorf("Parse() errors = %+v, want %+v", validationErr.Errors, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParse_CommandProgram(t *testing.T) {
+	data := []byte(`
+grid: { width: 5, height: 5 }
+macros:
+  hop: 2M R
+rovers:
+  - { commands: "@hop # first" }
+  - { start: { x: 4, y: 4, direction: S }, commands: "(L@hop)2" }
+`)
+
+	mission, err := NewLoader().Parse(data, FormatYAML)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if mission.Rovers[0].Commands != "MMR" {
+		t.Errorf("rovers[0].commands = %q, want MMR", mission.Rovers[0].Commands)
+	}
+	if mission.Rovers[1].Commands != "LMMRLMMR" {
+		t.Errorf("rovers[1].commands = %q, want LMMRLMMR", mission.Rovers[1].Commands)
+	}
+}
+
+func TestParse_CommandSyntaxErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected []FieldError
+	}{
+		{
+			name: "commands",
+			data: `{"grid": {"width": 5, "height": 5}, "commands": "MM\n(R"}`,
+			expected: []FieldError{
+				{Path: "commands", Message: "line 2, column 1: ( is never closed"},
+			},
+		},
+		{
+			name: "fleet rover commands",
+			data: `{"grid": {"width": 5, "height": 5}, "rovers": [{"commands": "M"}, {"start": {"x": 1}, "commands": "MQ"}]}`,
+			expected: []FieldError{
+				{Path: "rovers[1].commands", Message: "line 1, column 2: unexpected character 'Q'"},
+			},
+		},
+		{
+			name: "macro body",
+			data: `{"grid": {"width": 5, "height": 5}, "macros": {"turn": "R)"}, "commands": "M@turn"}`,
+			expected: []FieldError{
+				{Path: "macros.turn", Message: "macro @turn: line 1, column 2: unexpected ) without a matching ("},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewLoader().Parse([]byte(tt.data), FormatJSON)
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Parse() error = %v, want *ValidationError", err)
+			}
+			if !reflect.DeepEqual(validationErr.Errors, tt.expected) {
+				t.Errorf("Parse() errors = %+v, want %+v", validationErr.Errors, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCompile(t *testing.T) {
+	mission := &Mission{Commands: "3M@turn", Macros: map[string]string{"turn": "RR"}}
+
+	if err := NewLoader().Compile(mission); err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	if mission.Commands != "MMMRR" {
+		t.Errorf("Compile() commands = %q, want MMMRR", mission.Commands)
+	}
+}
+
+func TestValidationError_Error(t *testing.T) {
+	err := &ValidationError{Errors: []FieldError{
+		{Path: "grid.width", Message: "must be greater than 0, got 0"},
+		{Path: "commands", Message: "is required"},
+	}}
+
+	expected := "invalid mission: grid.width: must be greater than 0, got 0; commands: is required"
+	if err.Error() != expected {
+		t.Errorf("Error() = %q, want %q", err.Error(), expected)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	jsonPath := filepath.Join(dir, "mission.json")
+	os.WriteFile(jsonPath, []byte(`{"grid": {"width": 3, "height": 4}, "commands": "M"}`), 0o600)
+
+	yamlPath := filepath.Join(dir, "mission.YML")
+	os.WriteFile(yamlPath, []byte("grid: {width: 3, height: 4}\ncommands: M\n"), 0o600)
+
+	for _, path := range []string{jsonPath, yamlPath} {
+		mission, err := NewLoader().Load(path)
+		if err != nil {
+			t.Fatalf("Load(%s) error = %v, want nil", path, err)
+		}
+		if mission.Grid != (Grid{Width: 3, Height: 4}) {
+			t.Errorf("Load(%s) grid = %+v, want 3x4", path, mission.Grid)
+		}
+	}
+
+	if _, err := NewLoader().Load(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("Load() of a missing file should return an error")
+	}
+	if _, err := NewLoader().Load(filepath.Join(dir, "mission.txt")); err == nil {
+		t.Error("Load() of an unsupported extension should return an error")
+	}
+}
+
+func TestFormatFromPath(t *testing.T) {
+	tests := []struct {
+		path      string
+		expected  Format
+		expectErr bool
+	}{
+		{path: "mission.json", expected: FormatJSON},
+		{path: "dir/mission.JSON", expected: FormatJSON},
+		{path: "mission.yaml", expected: FormatYAML},
+		{path: "mission.yml", expected: FormatYAML},
+		{path: "mission.toml", expectErr: true},
+		{path: "mission", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			format, err := FormatFromPath(tt.path)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("FormatFromPath(%s) error = %v, expectErr %v", tt.path, err, tt.expectErr)
+			}
+			if format != tt.expected {
+				t.Errorf("FormatFromPath(%s) = %q, want %q", tt.path, format, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSchema(t *testing.T) {
+	var schema map[string]any
+	if err := json.Unmarshal(Schema, &schema); err != nil {
+		t.Fatalf("Schema is not valid JSON: %v", err)
+	}
+	if schema["title"] != "Mars rover mission" {
+		t.Errorf("Schema title = %v, want Mars rover mission", schema["title"])
+	}
+}
+
+func TestNavigate_Rover(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expected := game.ExtendedResult{Result: game.Result{Status: game.StatusSuccess}}
+	mockGame := gameMock.NewMockGame(ctrl)
+	mockGame.EXPECT().NavigateRoverWithOptions(20, 5, []model.Position{{X: 1, Y: 1}},
+		model.Position{X: 2, Y: 3}, model.West, "MLM", game.Options{Trace: true, Policy: game.PolicySkipWithLimit, SkipLimit: 2, Topology: environment.WrapX,
+			Compass: game.CompassEight, CornerCutting: true, SensorRadius: 3}).Return(expected)
+
+	report := Navigate(mockGame, &Mission{
+		Grid:      Grid{Width: 20, Height: 5},
+		Obstacles: []model.Position{{X: 1, Y: 1}},
+		Start:     Pose{X: 2, Y: 3, Direction: model.West},
+		Commands:  "MLM",
+		Options:   Options{Trace: true, ObstaclePolicy: "skip_with_limit", SkipLimit: 2, Topology: "wrap_x", Compass: "eight", CornerCutting: true, SensorRadius: 3},
+	})
+
+	if report.Fleet != nil {
+		t.Error("Navigate() should not report a fleet for a single rover mission")
+	}
+	if report.Rover == nil || !reflect.DeepEqual(*report.Rover, expected) {
+		t.Errorf("Navigate() rover = %+v, want %+v", report.Rover, expected)
+	}
+}
+
+func TestNavigate_Fleet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expected := game.FleetResult{Mode: game.FleetModeInterleaved}
+	mockGame := gameMock.NewMockGame(ctrl)
+	mockGame.EXPECT().NavigateFleet(5, 5, []model.Position(nil), []game.RoverMission{
+		{ID: "a", Start: model.Position{X: 0, Y: 0}, Direction: model.East, Commands: "MM"},
+		{ID: "b", Start: model.Position{X: 4, Y: 4}, Direction: model.South, Commands: "L"},
+	}, game.FleetModeInterleaved, game.Options{}).Return(expected)
+
+	report := Navigate(mockGame, &Mission{
+		Grid: Grid{Width: 5, Height: 5},
+		Rovers: []Rover{
+			{ID: "a", Start: Pose{X: 0, Y: 0, Direction: model.East}, Commands: "MM"},
+			{ID: "b", Start: Pose{X: 4, Y: 4, Direction: model.South}, Commands: "L"},
+		},
+		Options: Options{FleetMode: "interleaved"},
+	})
+
+	if report.Rover != nil {
+		t.Error("Navigate() should not report a single rover for a fleet mission")
+	}
+	if report.Fleet == nil || !reflect.DeepEqual(*report.Fleet, expected) {
+		t.Errorf("Navigate() fleet = %+v, want %+v", report.Fleet, expected)
+	}
+}
+
+func TestNavigate_Energy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	scout := game.Profiles["scout"]
+	hauler := game.EnergyProfile{Name: "hauler", Charge: 50, Move: 2}
+	expected := game.FleetResult{Mode: game.FleetModeSequential}
+	mockGame := gameMock.NewMockGame(ctrl)
+	mockGame.EXPECT().NavigateFleet(5, 5, []model.Position(nil), []game.RoverMission{
+		{ID: "a", Start: model.Position{X: 0, Y: 0}, Direction: model.North, Commands: "M", Energy: &hauler},
+		{ID: "b", Start: model.Position{X: 4, Y: 4}, Direction: model.North, Commands: "L"},
+	}, game.FleetModeSequential, game.Options{
+		Energy:  &scout,
+		Terrain: map[model.Position]string{{X: 1, Y: 1}: "sand"},
+	}).Return(expected)
+
+	report := Navigate(mockGame, &Mission{
+		Grid: Grid{Width: 5, Height: 5},
+		Rovers: []Rover{
+			{ID: "a", Start: Pose{Direction: model.North}, Commands: "M", Profile: "hauler"},
+			{ID: "b", Start: Pose{X: 4, Y: 4, Direction: model.North}, Commands: "L"},
+		},
+		Terrain:  []TerrainCell{{X: 1, Y: 1, Type: "sand"}},
+		Profiles: map[string]Profile{"hauler": {Charge: 50, Move: 2}},
+		Options:  Options{FleetMode: "sequential", Profile: "scout"},
+	})
+
+	if report.Fleet == nil || !reflect.DeepEqual(*report.Fleet, expected) {
+		t.Errorf("Navigate() fleet = %+v, want %+v", report.Fleet, expected)
+	}
+}
@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: mission.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	mission "mars-rover-navigation/src/modules/mission"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLoader is a mock of Loader interface.
+type MockLoader struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoaderMockRecorder
+}
+
+// MockLoaderMockRecorder is the mock recorder for MockLoader.
+type MockLoaderMockRecorder struct {
+	mock *MockLoader
+}
+
+// NewMockLoader creates a new mock instance.
+func NewMockLoader(ctrl *gomock.Controller) *MockLoader {
+	mock := &MockLoader{ctrl: ctrl}
+	mock.recorder = &MockLoaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoader) EXPECT() *MockLoaderMockRecorder {
+	return m.recorder
+}
+
+// Compile mocks base method.
+func (m_2 *MockLoader) Compile(m *mission.Mission) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Compile", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Compile indicates an expected call of Compile.
+func (mr *MockLoaderMockRecorder) Compile(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Compile", reflect.TypeOf((*MockLoader)(nil).Compile), m)
+}
+
+// Load mocks base method.
+func (m *MockLoader) Load(path string) (*mission.Mission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Load", path)
+	ret0, _ := ret[0].(*mission.Mission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Load indicates an expected call of Load.
+func (mr *MockLoaderMockRecorder) Load(path interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Load", reflect.TypeOf((*MockLoader)(nil).Load), path)
+}
+
+// Parse mocks base method.
+func (m *MockLoader) Parse(data []byte, format mission.Format) (*mission.Mission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Parse", data, format)
+	ret0, _ := ret[0].(*mission.Mission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Parse indicates an expected call of Parse.
+func (mr *MockLoaderMockRecorder) Parse(data, format interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Parse", reflect.TypeOf((*MockLoader)(nil).Parse), data, format)
+}
@@ -0,0 +1,81 @@
+package obstacle
+
+import "fmt"
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenOpen
+	tokenClose
+	tokenOpenBracket
+	tokenCloseBracket
+	tokenComma
+	tokenRange
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	column int
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of input"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// tokenize splits an obstacle list into tokens, skipping whitespace.
+func tokenize(source string) ([]token, error) {
+	var tokens []token
+	runes := []rune(source)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		start := token{column: i + 1}
+
+		switch {
+		case r == ' ' || r == '\t' || r == '\r' || r == '\n':
+			i++
+			continue
+		case r == '(':
+			start.kind, start.text = tokenOpen, "("
+		case r == ')':
+			start.kind, start.text = tokenClose, ")"
+		case r == '[':
+			start.kind, start.text = tokenOpenBracket, "["
+		case r == ']':
+			start.kind, start.text = tokenCloseBracket, "]"
+		case r == ',':
+			start.kind, start.text = tokenComma, ","
+		case r == '.':
+			if i+1 >= len(runes) || runes[i+1] != '.' {
+				return nil, &SyntaxError{Column: i + 1, Message: "expected .. between the ends of a range"}
+			}
+			start.kind, start.text = tokenRange, ".."
+			i++
+		case r == '-' || r >= '0' && r <= '9':
+			end := i + 1
+			for end < len(runes) && runes[end] >= '0' && runes[end] <= '9' {
+				end++
+			}
+			if r == '-' && end == i+1 {
+				return nil, &SyntaxError{Column: i + 1, Message: "expected a digit after -"}
+			}
+			start.kind, start.text = tokenNumber, string(runes[i:end])
+			tokens = append(tokens, start)
+			i = end
+			continue
+		default:
+			return nil, &SyntaxError{Column: i + 1, Message: fmt.Sprintf("unexpected character %q", r)}
+		}
+
+		tokens = append(tokens, start)
+		i++
+	}
+
+	return append(tokens, token{kind: tokenEOF, column: len(runes) + 1}), nil
+}
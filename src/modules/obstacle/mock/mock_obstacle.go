@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: obstacle.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "mars-rover-navigation/src/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockParser is a mock of Parser interface.
+type MockParser struct {
+	ctrl     *gomock.Controller
+	recorder *MockParserMockRecorder
+}
+
+// MockParserMockRecorder is the mock recorder for MockParser.
+type MockParserMockRecorder struct {
+	mock *MockParser
+}
+
+// NewMockParser creates a new mock instance.
+func NewMockParser(ctrl *gomock.Controller) *MockParser {
+	mock := &MockParser{ctrl: ctrl}
+	mock.recorder = &MockParserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockParser) EXPECT() *MockParserMockRecorder {
+	return m.recorder
+}
+
+// Parse mocks base method.
+func (m *MockParser) Parse(source string) ([]model.Position, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Parse", source)
+	ret0, _ := ret[0].([]model.Position)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Parse indicates an expected call of Parse.
+func (mr *MockParserMockRecorder) Parse(source interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Parse", reflect.TypeOf((*MockParser)(nil).Parse), source)
+}
@@ -0,0 +1,27 @@
+//go:generate go run github.com/golang/mock/mockgen -source=obstacle.go -destination=./mock/mock_obstacle.go -package=mock
+
+package obstacle
+
+import "mars-rover-navigation/src/model"
+
+// Parser reads the obstacle list of the --obstacles flag.
+//
+// A list is [ ] around obstacles separated by commas, each obstacle is either:
+//   - a tuple: (1,2)
+//   - a JSON array: [1,2]
+//   - a tuple or array whose x or y is an inclusive range: (2..5,3) is a wall of 4 cells,
+//     (1..2,1..2) a 2x2 block
+//
+// Whitespace is ignored.
+type Parser interface {
+	Parse(source string) ([]model.Position, error)
+}
+
+// SyntaxError points at the offending token, Column counts characters from 1.
+type SyntaxError struct {
+	Column  int    `json:"column"`
+	Message string `json:"message"`
+}
+
+// DefaultMaxObstacles caps the number of cells a list expands to.
+const DefaultMaxObstacles = 1 << 20
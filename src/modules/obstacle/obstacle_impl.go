@@ -0,0 +1,159 @@
+package obstacle
+
+import (
+	"fmt"
+	"mars-rover-navigation/src/model"
+	"strconv"
+)
+
+type parserImpl struct {
+	maxObstacles int
+}
+
+func NewParser() *parserImpl {
+	return &parserImpl{
+		maxObstacles: DefaultMaxObstacles,
+	}
+}
+
+func (p *parserImpl) Parse(source string) ([]model.Position, error) {
+	tokens, err := tokenize(source)
+	if err != nil {
+		return nil, err
+	}
+
+	ps := &parser{tokens: tokens, maxObstacles: p.maxObstacles}
+	return ps.list()
+}
+
+type parser struct {
+	tokens       []token
+	pos          int
+	maxObstacles int
+	obstacles    []model.Position
+}
+
+func (ps *parser) peek() token {
+	return ps.tokens[ps.pos]
+}
+
+func (ps *parser) next() token {
+	t := ps.tokens[ps.pos]
+	if t.kind != tokenEOF {
+		ps.pos++
+	}
+	return t
+}
+
+func (ps *parser) errorAt(t token, format string, args ...interface{}) error {
+	return &SyntaxError{Column: t.column, Message: fmt.Sprintf(format, args...)}
+}
+
+// list parses [ obstacle, ... ] and the end of input after it.
+func (ps *parser) list() ([]model.Position, error) {
+	if open := ps.next(); open.kind != tokenOpenBracket {
+		return nil, ps.errorAt(open, "expected [ to open the obstacle list, got %s", open)
+	}
+
+	if ps.peek().kind == tokenCloseBracket {
+		ps.next()
+	} else {
+		for done := false; !done; {
+			if err := ps.obstacle(); err != nil {
+				return nil, err
+			}
+
+			switch t := ps.next(); t.kind {
+			case tokenComma:
+			case tokenCloseBracket:
+				done = true
+			default:
+				return nil, ps.errorAt(t, "expected , or ] after an obstacle, got %s", t)
+			}
+		}
+	}
+
+	if t := ps.next(); t.kind != tokenEOF {
+		return nil, ps.errorAt(t, "unexpected %s after the obstacle list", t)
+	}
+	return ps.obstacles, nil
+}
+
+// obstacle parses (x,y) or [x,y], either coordinate may be a range.
+func (ps *parser) obstacle() error {
+	open := ps.next()
+	var closeKind tokenKind
+	var closeText string
+	switch open.kind {
+	case tokenOpen:
+		closeKind, closeText = tokenClose, ")"
+	case tokenOpenBracket:
+		closeKind, closeText = tokenCloseBracket, "]"
+	default:
+		return ps.errorAt(open, "expected ( or [ to open an obstacle, got %s", open)
+	}
+
+	fromX, toX, err := ps.coordinate()
+	if err != nil {
+		return err
+	}
+	if t := ps.next(); t.kind != tokenComma {
+		return ps.errorAt(t, "expected , between x and y, got %s", t)
+	}
+	fromY, toY, err := ps.coordinate()
+	if err != nil {
+		return err
+	}
+	if t := ps.next(); t.kind != closeKind {
+		return ps.errorAt(t, "expected %s to close the obstacle opened at column %d, got %s", closeText, open.column, t)
+	}
+
+	// A range can span the whole int range, the spans are compared unsigned before the cells are counted
+	if uint64(toX-fromX) >= uint64(ps.maxObstacles) || uint64(toY-fromY) >= uint64(ps.maxObstacles) ||
+		len(ps.obstacles)+(toX-fromX+1)*(toY-fromY+1) > ps.maxObstacles {
+		return ps.errorAt(open, "obstacle list expands to more than %d cells", ps.maxObstacles)
+	}
+	for x := fromX; x <= toX; x++ {
+		for y := fromY; y <= toY; y++ {
+			ps.obstacles = append(ps.obstacles, model.Position{X: x, Y: y})
+		}
+	}
+	return nil
+}
+
+// coordinate parses a number or an inclusive from..to range.
+func (ps *parser) coordinate() (int, int, error) {
+	from, err := ps.number()
+	if err != nil {
+		return 0, 0, err
+	}
+	if ps.peek().kind != tokenRange {
+		return from, from, nil
+	}
+
+	rangeToken := ps.next()
+	to, err := ps.number()
+	if err != nil {
+		return 0, 0, err
+	}
+	if to < from {
+		return 0, 0, ps.errorAt(rangeToken, "range %d..%d ends before it starts", from, to)
+	}
+	return from, to, nil
+}
+
+func (ps *parser) number() (int, error) {
+	t := ps.next()
+	if t.kind != tokenNumber {
+		return 0, ps.errorAt(t, "expected a number, got %s", t)
+	}
+	n, err := strconv.Atoi(t.text)
+	if err != nil {
+		return 0, ps.errorAt(t, "number %s is too large", t.text)
+	}
+	return n, nil
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Column, e.Message)
+}
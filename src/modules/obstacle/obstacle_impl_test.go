@@ -0,0 +1,236 @@
+package obstacle
+
+import (
+	"errors"
+	"fmt"
+	"mars-rover-navigation/src/model"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestNewParser(t *testing.T) {
+	p := NewParser()
+	if p == nil {
+		t.Fatal("NewParser() returned nil")
+	}
+	if p.maxObstacles != DefaultMaxObstacles {
+		t.Errorf("maxObstacles = %d, want %d", p.maxObstacles, DefaultMaxObstacles)
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		expected []model.Position
+	}{
+		{name: "empty", source: "[]"},
+		{name: "empty with spaces", source: " [ ] "},
+		{name: "single tuple", source: "[(1,2)]", expected: []model.Position{{X: 1, Y: 2}}},
+		{name: "tuples", source: "[(1,2),(3,4)]", expected: []model.Position{{X: 1, Y: 2}, {X: 3, Y: 4}}},
+		{name: "tuples with spaces", source: "[(1, 2), (3, 4)]", expected: []model.Position{{X: 1, Y: 2}, {X: 3, Y: 4}}},
+		{name: "json arrays", source: "[[1,2],[3,3]]", expected: []model.Position{{X: 1, Y: 2}, {X: 3, Y: 3}}},
+		{name: "mixed", source: "[[1,2],(3,3)]", expected: []model.Position{{X: 1, Y: 2}, {X: 3, Y: 3}}},
+		{name: "multi digit", source: "[(120,4711)]", expected: []model.Position{{X: 120, Y: 4711}}},
+		{name: "negative", source: "[(-1,2)]", expected: []model.Position{{X: -1, Y: 2}}},
+		{
+			name:     "horizontal wall",
+			source:   "[(2..5,3)]",
+			expected: []model.Position{{X: 2, Y: 3}, {X: 3, Y: 3}, {X: 4, Y: 3}, {X: 5, Y: 3}},
+		},
+		{
+			name:     "vertical wall in an array",
+			source:   "[[0, 1 .. 2]]",
+			expected: []model.Position{{X: 0, Y: 1}, {X: 0, Y: 2}},
+		},
+		{
+			name:     "block",
+			source:   "[(1..2,1..2),(4,4)]",
+			expected: []model.Position{{X: 1, Y: 1}, {X: 1, Y: 2}, {X: 2, Y: 1}, {X: 2, Y: 2}, {X: 4, Y: 4}},
+		},
+		{name: "single cell range", source: "[(3..3,0)]", expected: []model.Position{{X: 3, Y: 0}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewParser().Parse(tt.source)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.source, err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Parse(%q) = %v, want %v", tt.source, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParse_SyntaxError(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		expected SyntaxError
+	}{
+		{
+			name:     "empty input",
+			source:   "",
+			expected: SyntaxError{Column: 1, Message: "expected [ to open the obstacle list, got end of input"},
+		},
+		{
+			name:     "missing list brackets",
+			source:   "(1,2)",
+			expected: SyntaxError{Column: 1, Message: `expected [ to open the obstacle list, got "("`},
+		},
+		{
+			name:     "non numeric coordinate",
+			source:   "[(1,a)]",
+			expected: SyntaxError{Column: 5, Message: `unexpected character 'a'`},
+		},
+		{
+			name:     "odd value",
+			source:   "[(1,2),(3)]",
+			expected: SyntaxError{Column: 10, Message: `expected , between x and y, got ")"`},
+		},
+		{
+			name:     "third coordinate",
+			source:   "[(1,2,3)]",
+			expected: SyntaxError{Column: 6, Message: `expected ) to close the obstacle opened at column 2, got ","`},
+		},
+		{
+			name:     "mismatched closer",
+			source:   "[[1,2)]",
+			expected: SyntaxError{Column: 6, Message: `expected ] to close the obstacle opened at column 2, got ")"`},
+		},
+		{
+			name:     "unclosed list",
+			source:   "[(1,2)",
+			expected: SyntaxError{Column: 7, Message: "expected , or ] after an obstacle, got end of input"},
+		},
+		{
+			name:     "missing comma between obstacles",
+			source:   "[(1,2) (3,4)]",
+			expected: SyntaxError{Column: 8, Message: `expected , or ] after an obstacle, got "("`},
+		},
+		{
+			name:     "trailing comma",
+			source:   "[(1,2),]",
+			expected: SyntaxError{Column: 8, Message: `expected ( or [ to open an obstacle, got "]"`},
+		},
+		{
+			name:     "bare number",
+			source:   "[1,2]",
+			expected: SyntaxError{Column: 2, Message: `expected ( or [ to open an obstacle, got "1"`},
+		},
+		{
+			name:     "text after the list",
+			source:   "[(1,2)] (3,4)",
+			expected: SyntaxError{Column: 9, Message: `unexpected "(" after the obstacle list`},
+		},
+		{
+			name:     "single dot",
+			source:   "[(2.5,3)]",
+			expected: SyntaxError{Column: 4, Message: "expected .. between the ends of a range"},
+		},
+		{
+			name:     "range without an end",
+			source:   "[(2..,3)]",
+			expected: SyntaxError{Column: 6, Message: `expected a number, got ","`},
+		},
+		{
+			name:     "descending range",
+			source:   "[(5..2,3)]",
+			expected: SyntaxError{Column: 4, Message: "range 5..2 ends before it starts"},
+		},
+		{
+			name:     "lone minus",
+			source:   "[(-,1)]",
+			expected: SyntaxError{Column: 3, Message: "expected a digit after -"},
+		},
+		{
+			name:     "huge number",
+			source:   "[(99999999999999999999,1)]",
+			expected: SyntaxError{Column: 3, Message: "number 99999999999999999999 is too large"},
+		},
+		{
+			name:     "huge range",
+			source:   "[(0..9223372036854775807,0)]",
+			expected: SyntaxError{Column: 2, Message: "obstacle list expands to more than 1048576 cells"},
+		},
+		{
+			name:     "huge block",
+			source:   "[(0,0),(0..1023,0..1024)]",
+			expected: SyntaxError{Column: 8, Message: "obstacle list expands to more than 1048576 cells"},
+		},
+		{
+			name:     "columns count characters",
+			source:   "[(1,2),(é,3)]",
+			expected: SyntaxError{Column: 9, Message: `unexpected character 'é'`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewParser().Parse(tt.source)
+
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("Parse(%q) error = %v, want *SyntaxError", tt.source, err)
+			}
+			if *syntaxErr != tt.expected {
+				t.Errorf("Parse(%q) error = %+v, want %+v", tt.source, *syntaxErr, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSyntaxError_Error(t *testing.T) {
+	err := &SyntaxError{Column: 5, Message: "unexpected character 'a'"}
+	if got := err.Error(); got != "column 5: unexpected character 'a'" {
+		t.Errorf("Error() = %q", got)
+	}
+}
+
+// format writes positions back in the tuple syntax.
+func format(positions []model.Position) string {
+	tuples := make([]string, 0, len(positions))
+	for _, p := range positions {
+		tuples = append(tuples, fmt.Sprintf("(%d,%d)", p.X, p.Y))
+	}
+	return "[" + strings.Join(tuples, ",") + "]"
+}
+
+func FuzzParse(f *testing.F) {
+	for _, seed := range []string{
+		"[]", "[(1,2),(3,4)]", "[[1,2],[3,3]]", "[(2..5,3)]", "[(1..2,1..2)]", "[(-1, 2)]",
+		"[(1,a)]", "[(1,2),(3)]", "[(1,2", "[(5..2,3)]", "[(0..9223372036854775807,0)]", "[(é,1)]",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, source string) {
+		positions, err := NewParser().Parse(source)
+		if err != nil {
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("Parse(%q) error = %v, want *SyntaxError", source, err)
+			}
+			if syntaxErr.Column < 1 || syntaxErr.Column > utf8.RuneCountInString(source)+1 {
+				t.Fatalf("Parse(%q) error column %d is outside the input", source, syntaxErr.Column)
+			}
+			return
+		}
+
+		if len(positions) > DefaultMaxObstacles {
+			t.Fatalf("Parse(%q) returned %d obstacles, more than %d", source, len(positions), DefaultMaxObstacles)
+		}
+		// A parsed list round trips through the tuple syntax
+		again, err := NewParser().Parse(format(positions))
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", format(positions), err)
+		}
+		if !reflect.DeepEqual(again, positions) {
+			t.Fatalf("Parse(%q) = %v, want %v", format(positions), again, positions)
+		}
+	})
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: output.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	io "io"
+	output "mars-rover-navigation/src/modules/output"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockFormatter is a mock of Formatter interface.
+type MockFormatter struct {
+	ctrl     *gomock.Controller
+	recorder *MockFormatterMockRecorder
+}
+
+// MockFormatterMockRecorder is the mock recorder for MockFormatter.
+type MockFormatterMockRecorder struct {
+	mock *MockFormatter
+}
+
+// NewMockFormatter creates a new mock instance.
+func NewMockFormatter(ctrl *gomock.Controller) *MockFormatter {
+	mock := &MockFormatter{ctrl: ctrl}
+	mock.recorder = &MockFormatterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFormatter) EXPECT() *MockFormatterMockRecorder {
+	return m.recorder
+}
+
+// Write mocks base method.
+func (m *MockFormatter) Write(w io.Writer, records []output.Record) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Write", w, records)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Write indicates an expected call of Write.
+func (mr *MockFormatterMockRecorder) Write(w, records interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Write", reflect.TypeOf((*MockFormatter)(nil).Write), w, records)
+}
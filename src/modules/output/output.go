@@ -0,0 +1,83 @@
+//go:generate go run github.com/golang/mock/mockgen -source=output.go -destination=./mock/mock_output.go -package=mock
+
+package output
+
+import (
+	_ "embed"
+	"io"
+
+	"mars-rover-navigation/src/model"
+	"mars-rover-navigation/src/modules/environment"
+	"mars-rover-navigation/src/modules/game"
+)
+
+// Schema is the published JSON Schema of a Record.
+//
+//go:embed output.schema.json
+var Schema []byte
+
+// SchemaVersion is the Record.Version of the current schema, it changes only when a field is renamed or removed.
+const SchemaVersion = 1
+
+type Formatter interface {
+	// Write encodes the records of one mission, one per rover, to w
+	Write(w io.Writer, records []Record) error
+}
+
+type Format string
+
+const (
+	// FormatJSON writes one JSON record per line
+	FormatJSON Format = "json"
+	// FormatJSONPretty writes every JSON record indented
+	FormatJSONPretty Format = "json-pretty"
+	// FormatYAML writes one YAML document per record
+	FormatYAML Format = "yaml"
+	// FormatText writes one human readable line per record
+	FormatText Format = "text"
+	// FormatCSV writes a header and one row per record
+	FormatCSV Format = "csv"
+)
+
+// Formats lists every Format, FormatJSON first as the default.
+var Formats = []Format{FormatJSON, FormatJSONPretty, FormatYAML, FormatText, FormatCSV}
+
+// Record is the outcome of one rover, Rover is the id of a fleet rover.
+type Record struct {
+	Version        int             `json:"version" yaml:"version"`
+	Rover          string          `json:"rover,omitempty" yaml:"rover,omitempty"`
+	FinalPosition  Pair            `json:"final_position" yaml:"final_position"`
+	FinalDirection model.Direction `json:"final_direction" yaml:"final_direction"`
+	Status         game.Status     `json:"status" yaml:"status"`
+	// Grid is [width, height]
+	Grid            Pair      `json:"grid" yaml:"grid"`
+	RemainingCharge *float64  `json:"remaining_charge,omitempty" yaml:"remaining_charge,omitempty"`
+	Explored        *float64  `json:"explored,omitempty" yaml:"explored,omitempty"`
+	Blocked         []Blocked `json:"blocked,omitempty" yaml:"blocked,omitempty"`
+	Errors          []Error   `json:"errors,omitempty" yaml:"errors,omitempty"`
+	// Commands, Moves and Stop are set by an exploration, see game.ExploreResult
+	Commands string           `json:"commands,omitempty" yaml:"commands,omitempty"`
+	Moves    *int             `json:"moves,omitempty" yaml:"moves,omitempty"`
+	Stop     game.ExploreStop `json:"stop,omitempty" yaml:"stop,omitempty"`
+}
+
+// Blocked is a move the environment refused, see game.BlockedMove.
+type Blocked struct {
+	Index    int                       `json:"index" yaml:"index"`
+	Command  string                    `json:"command" yaml:"command"`
+	Position Pair                      `json:"position" yaml:"position"`
+	Status   environment.CanMoveStatus `json:"status" yaml:"status"`
+	Replan   string                    `json:"replan,omitempty" yaml:"replan,omitempty"`
+}
+
+// Error is a problem of the input of a rejected rover, see game.ValidationError.
+type Error struct {
+	Code    game.ValidationCode `json:"code" yaml:"code"`
+	Field   string              `json:"field" yaml:"field"`
+	Value   string              `json:"value" yaml:"value"`
+	Index   *int                `json:"index,omitempty" yaml:"index,omitempty"`
+	Message string              `json:"message" yaml:"message"`
+}
+
+// Pair is an [x, y] position or a [width, height] size.
+type Pair [2]int
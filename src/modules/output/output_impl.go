@@ -0,0 +1,203 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mars-rover-navigation/src/model"
+	"mars-rover-navigation/src/modules/game"
+	"mars-rover-navigation/src/modules/mission"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// NewFormatter returns the formatter of format, the name is case insensitive.
+func NewFormatter(format Format) (Formatter, error) {
+	switch Format(strings.ToLower(string(format))) {
+	case FormatJSON:
+		return &jsonFormatter{}, nil
+	case FormatJSONPretty:
+		return &jsonFormatter{indent: "  "}, nil
+	case FormatYAML:
+		return &yamlFormatter{}, nil
+	case FormatText:
+		return &textFormatter{}, nil
+	case FormatCSV:
+		return &csvFormatter{}, nil
+	}
+
+	names := make([]string, 0, len(Formats))
+	for _, f := range Formats {
+		names = append(names, string(f))
+	}
+	return nil, fmt.Errorf("unsupported output format: %q (use %s)", format, strings.Join(names, ", "))
+}
+
+// RecordsOf lists the records of a mission report, the blocked moves only when withBlocked.
+func RecordsOf(report mission.Report, withBlocked bool) []Record {
+	if report.Fleet == nil {
+		return []Record{recordOf("", *report.Rover, withBlocked)}
+	}
+
+	records := make([]Record, 0, len(report.Fleet.Rovers))
+	for _, rover := range report.Fleet.Rovers {
+		records = append(records, recordOf(rover.ID, rover.ExtendedResult, withBlocked))
+	}
+	return records
+}
+
+// RecordsOfExploration lists the record of an exploration, the commands it drove with and why it stopped.
+func RecordsOfExploration(result game.ExploreResult) []Record {
+	record := recordOf("", result.ExtendedResult, false)
+	record.Commands = result.Commands
+	record.Stop = result.Stop
+	// A rejected exploration made no move to count
+	if result.Stop != "" {
+		moves := result.Moves
+		record.Moves = &moves
+	}
+	return []Record{record}
+}
+
+func recordOf(id string, result game.ExtendedResult, withBlocked bool) Record {
+	record := Record{
+		Version:         SchemaVersion,
+		Rover:           id,
+		FinalPosition:   pairOf(result.FinalPosition),
+		FinalDirection:  result.FinalDirection,
+		Status:          result.Status,
+		Grid:            Pair{result.Grid.Width, result.Grid.Height},
+		RemainingCharge: result.RemainingCharge,
+		Explored:        result.Explored,
+	}
+	for _, e := range result.Errors {
+		record.Errors = append(record.Errors, Error{Code: e.Code, Field: e.Field, Value: e.Value, Index: e.Index, Message: e.Message})
+	}
+	if withBlocked {
+		for _, b := range result.Blocked {
+			record.Blocked = append(record.Blocked, Blocked{Index: b.Index, Command: b.Command, Position: pairOf(b.Position), Status: b.Status, Replan: b.Replan})
+		}
+	}
+	return record
+}
+
+func pairOf(position model.Position) Pair {
+	return Pair{position.X, position.Y}
+}
+
+// MarshalYAML keeps a pair on one line, [1, 3] instead of a block list.
+func (p Pair) MarshalYAML() (interface{}, error) {
+	node := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+	for _, v := range p {
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(v)})
+	}
+	return node, nil
+}
+
+type jsonFormatter struct {
+	indent string
+}
+
+func (f *jsonFormatter) Write(w io.Writer, records []Record) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", f.indent)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type yamlFormatter struct{}
+
+func (f *yamlFormatter) Write(w io.Writer, records []Record) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+	return encoder.Close()
+}
+
+type textFormatter struct{}
+
+func (f *textFormatter) Write(w io.Writer, records []Record) error {
+	for _, record := range records {
+		var line strings.Builder
+		if record.Rover != "" {
+			line.WriteString(record.Rover + ": ")
+		}
+		fmt.Fprintf(&line, "(%d, %d) %s %s on a %dx%d grid", record.FinalPosition[0], record.FinalPosition[1],
+			record.FinalDirection, record.Status, record.Grid[0], record.Grid[1])
+		if record.RemainingCharge != nil {
+			fmt.Fprintf(&line, ", remaining charge %g", *record.RemainingCharge)
+		}
+		if record.Explored != nil {
+			fmt.Fprintf(&line, ", explored %g", *record.Explored)
+		}
+		if record.Moves != nil {
+			fmt.Fprintf(&line, ", %d moves %q until %s", *record.Moves, record.Commands, record.Stop)
+		}
+		for _, b := range record.Blocked {
+			fmt.Fprintf(&line, ", command %d %s blocked at (%d, %d): %s", b.Index, b.Command, b.Position[0], b.Position[1], b.Status)
+			if b.Replan != "" {
+				fmt.Fprintf(&line, " replanned %s", b.Replan)
+			}
+		}
+		for _, e := range record.Errors {
+			field := e.Field
+			if e.Index != nil {
+				field = fmt.Sprintf("%s[%d]", field, *e.Index)
+			}
+			fmt.Fprintf(&line, ", %s %q %s: %s", field, e.Value, e.Code, e.Message)
+		}
+		if _, err := fmt.Fprintln(w, line.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type csvFormatter struct{}
+
+// csvHeader names the columns, blocked and errors are the number of blocked moves and input problems
+var csvHeader = []string{"version", "rover", "x", "y", "direction", "status", "width", "height", "remaining_charge", "blocked", "errors", "explored",
+	"commands", "moves", "stop"}
+
+func (f *csvFormatter) Write(w io.Writer, records []Record) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, record := range records {
+		charge, explored, moves := "", "", ""
+		if record.RemainingCharge != nil {
+			charge = strconv.FormatFloat(*record.RemainingCharge, 'g', -1, 64)
+		}
+		if record.Explored != nil {
+			explored = strconv.FormatFloat(*record.Explored, 'g', -1, 64)
+		}
+		if record.Moves != nil {
+			moves = strconv.Itoa(*record.Moves)
+		}
+		row := []string{
+			strconv.Itoa(record.Version), record.Rover,
+			strconv.Itoa(record.FinalPosition[0]), strconv.Itoa(record.FinalPosition[1]),
+			string(record.FinalDirection), string(record.Status),
+			strconv.Itoa(record.Grid[0]), strconv.Itoa(record.Grid[1]),
+			charge, strconv.Itoa(len(record.Blocked)), strconv.Itoa(len(record.Errors)), explored,
+			record.Commands, moves, string(record.Stop),
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,202 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"mars-rover-navigation/src/model"
+	"mars-rover-navigation/src/modules/game"
+	"mars-rover-navigation/src/modules/mission"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files")
+
+// goldenReports are written to testdata/<name>.<format>.golden
+func goldenReports() map[string]mission.Report {
+	charge, explored := 12.5, 0.36
+	return map[string]mission.Report{
+		"rover": {Rover: &game.ExtendedResult{
+			Result: game.Result{
+				FinalPosition:   model.Position{X: 1, Y: 4},
+				FinalDirection:  model.East,
+				Status:          game.StatusSuccess,
+				Grid:            model.Size{Width: 5, Height: 5},
+				RemainingCharge: &charge,
+			},
+			Blocked: []game.BlockedMove{
+				{Index: 1, Command: "M", Position: model.Position{X: 0, Y: 2}, Status: "Obstacle encountered", Replan: "RML"},
+				{Index: 4, Command: "B", Position: model.Position{X: 5, Y: 4}, Status: "Out of bounds"},
+			},
+		}},
+		"fleet": {Fleet: &game.FleetResult{Mode: game.FleetModeInterleaved, Rovers: []game.RoverResult{
+			{ID: "alpha", ExtendedResult: game.ExtendedResult{Result: game.Result{
+				FinalPosition: model.Position{X: 2, Y: 0}, FinalDirection: model.East, Status: game.StatusRoverCollision, Grid: model.Size{Width: 5, Height: 5},
+			}}},
+			{ID: "rover-2", ExtendedResult: game.ExtendedResult{Result: game.Result{
+				FinalPosition: model.Position{X: 3, Y: 0}, FinalDirection: model.NorthWest, Status: game.StatusSuccess, Grid: model.Size{Width: 5, Height: 5},
+				Explored: &explored,
+			}}},
+			{ID: "gamma", ExtendedResult: game.ExtendedResult{Result: game.Result{
+				FinalPosition: model.Position{X: 0, Y: 0}, FinalDirection: model.North, Status: game.StatusInvalidInput, Grid: model.Size{Width: 5, Height: 5},
+				Errors: game.ValidationErrors{
+					game.NewValidationError(game.CodeOutOfBounds, "start", "(9, 0)", "start is outside the 5x5 grid"),
+					game.NewIndexedValidationError(game.CodeInvalidCommand, "commands", 2, "X", "command must be one of LRMBUAC"),
+				},
+			}}},
+		}}},
+	}
+}
+
+// goldenExploration is written to testdata/explore.<format>.golden
+func goldenExploration() game.ExploreResult {
+	explored := 0.8
+	return game.ExploreResult{
+		ExtendedResult: game.ExtendedResult{Result: game.Result{
+			FinalPosition: model.Position{X: 3, Y: 2}, FinalDirection: model.South, Status: game.StatusSuccess, Grid: model.Size{Width: 5, Height: 5},
+			Explored: &explored,
+		}},
+		Commands: "MMRMMRM",
+		Moves:    5,
+		Stop:     game.ExploreStopMoveBudget,
+	}
+}
+
+// goldenRecords are the records of every golden report and of the golden exploration.
+func goldenRecords() map[string][]Record {
+	records := map[string][]Record{"explore": RecordsOfExploration(goldenExploration())}
+	for name, report := range goldenReports() {
+		records[name] = RecordsOf(report, true)
+	}
+	return records
+}
+
+func TestFormatter_Golden(t *testing.T) {
+	for name, records := range goldenRecords() {
+		for _, format := range Formats {
+			t.Run(name+"/"+string(format), func(t *testing.T) {
+				formatter, err := NewFormatter(format)
+				if err != nil {
+					t.Fatalf("NewFormatter(%q) error = %v", format, err)
+				}
+
+				var out bytes.Buffer
+				if err := formatter.Write(&out, records); err != nil {
+					t.Fatalf("Write() error = %v", err)
+				}
+
+				path := filepath.Join("testdata", name+"."+string(format)+".golden")
+				if *update {
+					if err := os.WriteFile(path, out.Bytes(), 0o644); err != nil {
+						t.Fatal(err)
+					}
+				}
+				golden, err := os.ReadFile(path)
+				if err != nil {
+					t.Fatalf("read golden file: %v (run go test -update)", err)
+				}
+				if out.String() != string(golden) {
+					t.Errorf("Write() output =\n%s\ngolden %s\n%s", out.String(), path, golden)
+				}
+			})
+		}
+	}
+}
+
+func TestRecordsOf(t *testing.T) {
+	reports := goldenReports()
+	commandIndex := 2
+
+	tests := []struct {
+		name        string
+		report      mission.Report
+		withBlocked bool
+		expected    []Record
+	}{
+		{
+			name:   "Rover without blocked moves",
+			report: reports["rover"],
+			expected: []Record{{Version: SchemaVersion, FinalPosition: Pair{1, 4}, FinalDirection: model.East, Status: game.StatusSuccess,
+				Grid: Pair{5, 5}, RemainingCharge: reports["rover"].Rover.RemainingCharge}},
+		},
+		{
+			name:   "Fleet",
+			report: reports["fleet"],
+			expected: []Record{
+				{Version: SchemaVersion, Rover: "alpha", FinalPosition: Pair{2, 0}, FinalDirection: model.East, Status: game.StatusRoverCollision, Grid: Pair{5, 5}},
+				{Version: SchemaVersion, Rover: "rover-2", FinalPosition: Pair{3, 0}, FinalDirection: model.NorthWest, Status: game.StatusSuccess, Grid: Pair{5, 5},
+					Explored: reports["fleet"].Fleet.Rovers[1].Explored},
+				{Version: SchemaVersion, Rover: "gamma", FinalPosition: Pair{0, 0}, FinalDirection: model.North, Status: game.StatusInvalidInput, Grid: Pair{5, 5},
+					Errors: []Error{
+						{Code: game.CodeOutOfBounds, Field: "start", Value: "(9, 0)", Message: "start is outside the 5x5 grid"},
+						{Code: game.CodeInvalidCommand, Field: "commands", Value: "X", Index: &commandIndex, Message: "command must be one of LRMBUAC"},
+					}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if records := RecordsOf(tt.report, tt.withBlocked); !reflect.DeepEqual(records, tt.expected) {
+				t.Errorf("RecordsOf() = %+v, expected %+v", records, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRecordsOfExploration(t *testing.T) {
+	exploration := goldenExploration()
+	moves := 5
+	expected := []Record{{Version: SchemaVersion, FinalPosition: Pair{3, 2}, FinalDirection: model.South, Status: game.StatusSuccess,
+		Grid: Pair{5, 5}, Explored: exploration.Explored, Commands: "MMRMMRM", Moves: &moves, Stop: game.ExploreStopMoveBudget}}
+	if records := RecordsOfExploration(exploration); !reflect.DeepEqual(records, expected) {
+		t.Errorf("RecordsOfExploration() = %+v, expected %+v", records, expected)
+	}
+
+	// A rejected exploration has no moves to count
+	rejected := game.ExploreResult{ExtendedResult: game.ExtendedResult{Result: game.Result{Status: game.StatusInvalidInput}}}
+	if records := RecordsOfExploration(rejected); records[0].Moves != nil || records[0].Stop != "" {
+		t.Errorf("RecordsOfExploration() = %+v, expected no moves and no stop", records)
+	}
+}
+
+func TestNewFormatter_Unsupported(t *testing.T) {
+	if _, err := NewFormatter("xml"); err == nil {
+		t.Error("NewFormatter(xml) expected an error")
+	}
+	if _, err := NewFormatter("JSON-Pretty"); err != nil {
+		t.Errorf("NewFormatter(JSON-Pretty) error = %v", err)
+	}
+}
+
+func TestSchema(t *testing.T) {
+	var schema struct {
+		Required   []string `json:"required"`
+		Properties map[string]struct {
+			Const *int `json:"const"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(Schema, &schema); err != nil {
+		t.Fatalf("Schema is not valid JSON: %v", err)
+	}
+	if version := schema.Properties["version"].Const; version == nil || *version != SchemaVersion {
+		t.Errorf("Schema version = %v, want %d", version, SchemaVersion)
+	}
+
+	// Every record field is described by the schema
+	for name, records := range goldenRecords() {
+		for _, r := range records {
+			record, _ := json.Marshal(r)
+			var fields map[string]any
+			_ = json.Unmarshal(record, &fields)
+			for field := range fields {
+				if _, ok := schema.Properties[field]; !ok {
+					t.Errorf("Schema does not describe the %q field of the %s report", field, name)
+				}
+			}
+		}
+	}
+}
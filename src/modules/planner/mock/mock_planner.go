@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: planner.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "mars-rover-navigation/src/model"
+	environment "mars-rover-navigation/src/modules/environment"
+	planner "mars-rover-navigation/src/modules/planner"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPlanner is a mock of Planner interface.
+type MockPlanner struct {
+	ctrl     *gomock.Controller
+	recorder *MockPlannerMockRecorder
+}
+
+// MockPlannerMockRecorder is the mock recorder for MockPlanner.
+type MockPlannerMockRecorder struct {
+	mock *MockPlanner
+}
+
+// NewMockPlanner creates a new mock instance.
+func NewMockPlanner(ctrl *gomock.Controller) *MockPlanner {
+	mock := &MockPlanner{ctrl: ctrl}
+	mock.recorder = &MockPlannerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPlanner) EXPECT() *MockPlannerMockRecorder {
+	return m.recorder
+}
+
+// Plan mocks base method.
+func (m *MockPlanner) Plan(env environment.Environment, start model.Pose, goal planner.Goal) planner.Plan {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Plan", env, start, goal)
+	ret0, _ := ret[0].(planner.Plan)
+	return ret0
+}
+
+// Plan indicates an expected call of Plan.
+func (mr *MockPlannerMockRecorder) Plan(env, start, goal interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Plan", reflect.TypeOf((*MockPlanner)(nil).Plan), env, start, goal)
+}
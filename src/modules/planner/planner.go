@@ -0,0 +1,39 @@
+//go:generate go run github.com/golang/mock/mockgen -source=planner.go -destination=./mock/mock_planner.go -package=mock
+
+package planner
+
+import (
+	"mars-rover-navigation/src/model"
+	"mars-rover-navigation/src/modules/environment"
+)
+
+type Planner interface {
+	Plan(env environment.Environment, start model.Pose, goal Goal) Plan
+}
+
+type Status string
+
+const (
+	StatusSuccess      Status = "Success"
+	StatusUnreachable  Status = "Unreachable"
+	StatusInvalidInput Status = "Invalid input"
+)
+
+// Costs are the price of a single command, Move must be positive.
+type Costs struct {
+	Move int
+	Turn int
+}
+
+type Goal struct {
+	Position model.Position
+	// Direction is the required final heading, empty accepts any heading
+	Direction model.Direction
+}
+
+type Plan struct {
+	Status   Status `json:"status"`
+	Commands string `json:"commands"`
+	Cost     int    `json:"cost"`
+	Reason   string `json:"reason,omitempty"`
+}
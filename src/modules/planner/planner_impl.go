@@ -0,0 +1,216 @@
+package planner
+
+import (
+	"container/heap"
+	"fmt"
+	"mars-rover-navigation/src/model"
+	"mars-rover-navigation/src/modules/environment"
+	"mars-rover-navigation/src/modules/rover"
+	"math"
+)
+
+var DefaultCosts = Costs{Move: 1, Turn: 1}
+
+// DefaultMaxPoses bounds a search, a goal walled in on a large grid would otherwise have every pose of the grid explored.
+const DefaultMaxPoses = 1 << 18
+
+type plannerImpl struct {
+	costs Costs
+	// maxPoses is the number of poses a search may reach before the goal is reported unreachable
+	maxPoses     int
+	roverFactory func(int, int, model.Direction) rover.Rover
+}
+
+func NewPlanner(costs Costs) *plannerImpl {
+	return NewPlannerWithLimit(costs, DefaultMaxPoses)
+}
+
+func NewPlannerWithLimit(costs Costs, maxPoses int) *plannerImpl {
+	return &plannerImpl{
+		costs:    costs,
+		maxPoses: maxPoses,
+		roverFactory: func(x, y int, direction model.Direction) rover.Rover {
+			return rover.NewRover(x, y, direction)
+		},
+	}
+}
+
+// state is a node of the search graph, a rover pose.
+type state struct {
+	position  model.Position
+	direction model.Direction
+}
+
+type visit struct {
+	cost   int
+	parent state
+	// command leads from parent to this state, 0 for the start
+	command byte
+}
+
+func (p *plannerImpl) Plan(env environment.Environment, start model.Pose, goal Goal) Plan {
+	if p.costs.Move <= 0 || p.costs.Turn < 0 {
+		return invalid("move cost must be positive and turn cost must not be negative")
+	}
+	if !isValidDirection(start.Direction) {
+		return invalid(fmt.Sprintf("unknown start direction %q", start.Direction))
+	}
+	if goal.Direction != "" && !isValidDirection(goal.Direction) {
+		return invalid(fmt.Sprintf("unknown goal direction %q", goal.Direction))
+	}
+
+	startPosition := model.Position{X: start.X, Y: start.Y}
+	if status := env.CanMove(startPosition); status != environment.Success {
+		return invalid(fmt.Sprintf("start (%d,%d): %s", start.X, start.Y, status))
+	}
+	if status := env.CanMove(goal.Position); status != environment.Success {
+		return unreachable(fmt.Sprintf("goal (%d,%d): %s", goal.Position.X, goal.Position.Y, status))
+	}
+
+	origin := state{position: startPosition, direction: start.Direction}
+	visited := map[state]visit{origin: {}}
+	open := &openSet{}
+	heap.Push(open, &node{state: origin, priority: p.heuristic(env, origin, goal)})
+
+	for open.Len() > 0 {
+		current := heap.Pop(open).(*node)
+		currentCost := visited[current.state].cost
+		if current.cost > currentCost {
+			// A cheaper path to this state was queued after this entry
+			continue
+		}
+
+		if current.state.position == goal.Position && (goal.Direction == "" || current.state.direction == goal.Direction) {
+			return Plan{
+				Status:   StatusSuccess,
+				Commands: commandsTo(current.state, origin, visited),
+				Cost:     currentCost,
+			}
+		}
+
+		if len(visited) > p.maxPoses {
+			return unreachable(fmt.Sprintf("no path from (%d,%d) to (%d,%d) within %d poses",
+				start.X, start.Y, goal.Position.X, goal.Position.Y, p.maxPoses))
+		}
+
+		for _, next := range p.neighbors(env, current.state) {
+			nextCost := currentCost + next.cost
+			if known, ok := visited[next.state]; ok && known.cost <= nextCost {
+				continue
+			}
+			visited[next.state] = visit{cost: nextCost, parent: current.state, command: next.command}
+			heap.Push(open, &node{
+				state:    next.state,
+				cost:     nextCost,
+				priority: nextCost + p.heuristic(env, next.state, goal),
+			})
+		}
+	}
+
+	return unreachable(fmt.Sprintf("no path from (%d,%d) to (%d,%d), explored %d poses",
+		start.X, start.Y, goal.Position.X, goal.Position.Y, len(visited)))
+}
+
+type neighbor struct {
+	state   state
+	cost    int
+	command byte
+}
+
+// neighbors expands a pose with the M, L and R commands, in that order.
+func (p *plannerImpl) neighbors(env environment.Environment, from state) []neighbor {
+	neighbors := make([]neighbor, 0, 3)
+
+	r := p.roverFactory(from.position.X, from.position.Y, from.direction)
+	if next, _ := env.Normalize(r.GetTryMovePosition()); env.CanMove(next) == environment.Success {
+		// Terrain costs at least plain ground, rounding keeps plans in whole cost units
+		cost := int(math.Round(float64(p.costs.Move) * env.TerrainCost(next)))
+		neighbors = append(neighbors, neighbor{state: state{position: next, direction: from.direction}, cost: cost, command: 'M'})
+	}
+
+	r.TurnLeft()
+	neighbors = append(neighbors, neighbor{state: state{position: from.position, direction: r.GetDirection()}, cost: p.costs.Turn, command: 'L'})
+
+	r = p.roverFactory(from.position.X, from.position.Y, from.direction)
+	r.TurnRight()
+	neighbors = append(neighbors, neighbor{state: state{position: from.position, direction: r.GetDirection()}, cost: p.costs.Turn, command: 'R'})
+
+	return neighbors
+}
+
+// heuristic is the grid distance in move costs, admissible because every move covers one cell.
+// The environment measures it so that wrapping edges are taken into account.
+func (p *plannerImpl) heuristic(env environment.Environment, from state, goal Goal) int {
+	return env.Distance(from.position, goal.Position) * p.costs.Move
+}
+
+func commandsTo(target, origin state, visited map[state]visit) string {
+	var reversed []byte
+	for current := target; current != origin; current = visited[current].parent {
+		reversed = append(reversed, visited[current].command)
+	}
+
+	commands := make([]byte, len(reversed))
+	for i, command := range reversed {
+		commands[len(reversed)-1-i] = command
+	}
+	return string(commands)
+}
+
+func invalid(reason string) Plan {
+	return Plan{Status: StatusInvalidInput, Reason: reason}
+}
+
+func unreachable(reason string) Plan {
+	return Plan{Status: StatusUnreachable, Reason: reason}
+}
+
+func isValidDirection(direction model.Direction) bool {
+	switch direction {
+	case model.North, model.East, model.South, model.West:
+		return true
+	}
+	return false
+}
+
+type node struct {
+	state    state
+	cost     int
+	priority int
+	// seq keeps the pop order stable between equal priorities
+	seq int
+}
+
+type openSet struct {
+	nodes []*node
+	seq   int
+}
+
+func (o *openSet) Len() int { return len(o.nodes) }
+
+func (o *openSet) Less(i, j int) bool {
+	a, b := o.nodes[i], o.nodes[j]
+	if a.priority != b.priority {
+		return a.priority < b.priority
+	}
+	if a.cost != b.cost {
+		// Prefer the node closer to the goal
+		return a.cost > b.cost
+	}
+	return a.seq < b.seq
+}
+
+func (o *openSet) Swap(i, j int) { o.nodes[i], o.nodes[j] = o.nodes[j], o.nodes[i] }
+
+func (o *openSet) Push(x any) {
+	n := x.(*node)
+	n.seq = o.seq
+	o.seq++
+	o.nodes = append(o.nodes, n)
+}
+
+func (o *openSet) Pop() any {
+	last := o.nodes[len(o.nodes)-1]
+	o.nodes = o.nodes[:len(o.nodes)-1]
+	return last
+}
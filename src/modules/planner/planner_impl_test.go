@@ -0,0 +1,316 @@
+package planner
+
+import (
+	"mars-rover-navigation/src/model"
+	"mars-rover-navigation/src/modules/environment"
+	"mars-rover-navigation/src/modules/rover"
+	"strings"
+	"testing"
+)
+
+func TestNewPlanner(t *testing.T) {
+	p := NewPlanner(DefaultCosts)
+	if p == nil {
+		t.Fatal("NewPlanner() returned nil")
+	}
+	if p.costs != DefaultCosts {
+		t.Errorf("costs = %+v, want %+v", p.costs, DefaultCosts)
+	}
+	if p.maxPoses != DefaultMaxPoses {
+		t.Errorf("maxPoses = %d, want %d", p.maxPoses, DefaultMaxPoses)
+	}
+	if p.roverFactory == nil {
+		t.Error("roverFactory should not be nil")
+	}
+}
+
+func TestPlan_Success(t *testing.T) {
+	tests := []struct {
+		name         string
+		width        int
+		height       int
+		obstacles    []model.Position
+		start        model.Pose
+		goal         Goal
+		costs        Costs
+		expectedCost int
+		expected     string
+	}{
+		{
+			name:         "straight ahead",
+			width:        5,
+			height:       5,
+			start:        model.Pose{X: 0, Y: 0, Direction: model.North},
+			goal:         Goal{Position: model.Position{X: 0, Y: 3}},
+			costs:        DefaultCosts,
+			expectedCost: 3,
+			expected:     "MMM",
+		},
+		{
+			name:         "single turn",
+			width:        5,
+			height:       5,
+			start:        model.Pose{X: 0, Y: 0, Direction: model.North},
+			goal:         Goal{Position: model.Position{X: 2, Y: 0}},
+			costs:        DefaultCosts,
+			expectedCost: 3,
+			expected:     "RMM",
+		},
+		{
+			name:         "already at goal",
+			width:        3,
+			height:       3,
+			start:        model.Pose{X: 1, Y: 1, Direction: model.East},
+			goal:         Goal{Position: model.Position{X: 1, Y: 1}},
+			costs:        DefaultCosts,
+			expectedCost: 0,
+			expected:     "",
+		},
+		{
+			name:         "goal heading",
+			width:        5,
+			height:       5,
+			start:        model.Pose{X: 0, Y: 0, Direction: model.North},
+			goal:         Goal{Position: model.Position{X: 0, Y: 2}, Direction: model.East},
+			costs:        DefaultCosts,
+			expectedCost: 3,
+			expected:     "MMR",
+		},
+		{
+			name:         "detour around a wall",
+			width:        5,
+			height:       5,
+			obstacles:    []model.Position{{X: 0, Y: 2}, {X: 1, Y: 2}, {X: 2, Y: 2}},
+			start:        model.Pose{X: 0, Y: 0, Direction: model.North},
+			goal:         Goal{Position: model.Position{X: 0, Y: 4}},
+			costs:        DefaultCosts,
+			expectedCost: 13,
+		},
+		{
+			name:         "expensive turns prefer fewer turns",
+			width:        5,
+			height:       5,
+			obstacles:    []model.Position{{X: 1, Y: 1}},
+			start:        model.Pose{X: 0, Y: 0, Direction: model.North},
+			goal:         Goal{Position: model.Position{X: 3, Y: 3}},
+			costs:        Costs{Move: 1, Turn: 10},
+			expectedCost: 16,
+			expected:     "MMMRMMM",
+		},
+		{
+			name:         "free turns",
+			width:        5,
+			height:       5,
+			start:        model.Pose{X: 2, Y: 2, Direction: model.North},
+			goal:         Goal{Position: model.Position{X: 2, Y: 1}},
+			costs:        Costs{Move: 2, Turn: 0},
+			expectedCost: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := environment.NewEnvironment(tt.width, tt.height, tt.obstacles)
+			plan := NewPlanner(tt.costs).Plan(env, tt.start, tt.goal)
+
+			if plan.Status != StatusSuccess {
+				t.Fatalf("Plan() status = %s (%s), want %s", plan.Status, plan.Reason, StatusSuccess)
+			}
+			if plan.Cost != tt.expectedCost {
+				t.Errorf("Plan() cost = %d, want %d (commands %q)", plan.Cost, tt.expectedCost, plan.Commands)
+			}
+			if tt.expected != "" && plan.Commands != tt.expected {
+				t.Errorf("Plan() commands = %q, want %q", plan.Commands, tt.expected)
+			}
+
+			// Replaying the plan must reach the goal without hitting anything
+			final, status := replay(env, tt.start, plan.Commands)
+			if status != environment.Success || final.GetPosition() != tt.goal.Position {
+				t.Errorf("replaying %q ended at %+v with %s, want goal %+v", plan.Commands, final.GetPosition(), status, tt.goal.Position)
+			}
+			if tt.goal.Direction != "" && final.GetDirection() != tt.goal.Direction {
+				t.Errorf("replaying %q ended facing %s, want %s", plan.Commands, final.GetDirection(), tt.goal.Direction)
+			}
+			cost := strings.Count(plan.Commands, "M")*tt.costs.Move + (len(plan.Commands)-strings.Count(plan.Commands, "M"))*tt.costs.Turn
+			if cost != plan.Cost {
+				t.Errorf("Plan() cost = %d, but commands %q cost %d", plan.Cost, plan.Commands, cost)
+			}
+		})
+	}
+}
+
+func TestPlan_Topology(t *testing.T) {
+	tests := []struct {
+		name     string
+		topology environment.Topology
+		goal     model.Position
+		expected string
+	}{
+		{name: "bounded goes the long way", topology: environment.Bounded, goal: model.Position{X: 6, Y: 0}, expected: "RMMMMMM"},
+		{name: "wrap_x crosses the west edge", topology: environment.WrapX, goal: model.Position{X: 6, Y: 0}, expected: "LM"},
+		{name: "wrap_y crosses the south edge", topology: environment.WrapY, goal: model.Position{X: 0, Y: 4}, expected: "LLM"},
+		{name: "torus crosses both edges", topology: environment.Torus, goal: model.Position{X: 6, Y: 4}, expected: "LMLM"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := environment.NewEnvironment(7, 5, nil)
+			env.SetTopology(tt.topology)
+			start := model.Pose{X: 0, Y: 0, Direction: model.North}
+
+			plan := NewPlanner(DefaultCosts).Plan(env, start, Goal{Position: tt.goal})
+
+			if plan.Status != StatusSuccess || plan.Commands != tt.expected {
+				t.Fatalf("Plan() = %+v, want commands %q", plan, tt.expected)
+			}
+			if final, status := replay(env, start, plan.Commands); status != environment.Success || final.GetPosition() != tt.goal {
+				t.Errorf("replaying %q ended at %+v with %s, want goal %+v", plan.Commands, final.GetPosition(), status, tt.goal)
+			}
+		})
+	}
+}
+
+func TestPlan_Terrain(t *testing.T) {
+	// A crater rim band across x=0..3 at y=2, open at x=4
+	terrain := map[model.Position]string{{X: 0, Y: 2}: "crater_rim", {X: 1, Y: 2}: "crater_rim", {X: 2, Y: 2}: "crater_rim", {X: 3, Y: 2}: "crater_rim"}
+
+	tests := []struct {
+		name      string
+		roverType string
+		expected  string
+		cost      int
+	}{
+		{name: "crosses the rim when cheaper", expected: "MMMM", cost: 7},
+		{name: "walks around the rim it cannot enter", roverType: "heavy", expected: "MRMMMMLMMMLMMMM", cost: 15},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := environment.NewEnvironment(5, 5, nil)
+			env.SetTerrain(terrain)
+			env.SetRoverType(tt.roverType)
+			start := model.Pose{X: 0, Y: 0, Direction: model.North}
+
+			plan := NewPlanner(DefaultCosts).Plan(env, start, Goal{Position: model.Position{X: 0, Y: 4}})
+
+			if plan.Status != StatusSuccess || plan.Commands != tt.expected || plan.Cost != tt.cost {
+				t.Fatalf("Plan() = %+v, want %q with cost %d", plan, tt.expected, tt.cost)
+			}
+			if final, status := replay(env, start, plan.Commands); status != environment.Success || final.GetPosition() != (model.Position{X: 0, Y: 4}) {
+				t.Errorf("replaying %q ended at %+v with %s", plan.Commands, final.GetPosition(), status)
+			}
+		})
+	}
+}
+
+func TestPlan_Unreachable(t *testing.T) {
+	tests := []struct {
+		name      string
+		obstacles []model.Position
+		goal      Goal
+		reason    string
+	}{
+		{
+			name:      "goal walled off",
+			obstacles: []model.Position{{X: 3, Y: 4}, {X: 3, Y: 3}, {X: 4, Y: 3}},
+			goal:      Goal{Position: model.Position{X: 4, Y: 4}},
+			reason:    "no path from (0,0) to (4,4)",
+		},
+		{
+			name:      "goal is an obstacle",
+			obstacles: []model.Position{{X: 2, Y: 2}},
+			goal:      Goal{Position: model.Position{X: 2, Y: 2}},
+			reason:    "goal (2,2): Obstacle encountered",
+		},
+		{
+			name:   "goal out of bounds",
+			goal:   Goal{Position: model.Position{X: 5, Y: 0}},
+			reason: "goal (5,0): Out of bounds",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := environment.NewEnvironment(5, 5, tt.obstacles)
+			plan := NewPlanner(DefaultCosts).Plan(env, model.Pose{X: 0, Y: 0, Direction: model.North}, tt.goal)
+
+			if plan.Status != StatusUnreachable {
+				t.Errorf("Plan() status = %s, want %s", plan.Status, StatusUnreachable)
+			}
+			if !strings.HasPrefix(plan.Reason, tt.reason) {
+				t.Errorf("Plan() reason = %q, want prefix %q", plan.Reason, tt.reason)
+			}
+			if plan.Commands != "" {
+				t.Errorf("Plan() commands = %q, want empty", plan.Commands)
+			}
+		})
+	}
+}
+
+func TestPlan_MaxPoses(t *testing.T) {
+	// The goal in the corner is walled in, a bounded search gives up long before the grid is covered
+	env := environment.New(1500, 1500, []model.Position{{X: 1498, Y: 1498}, {X: 1498, Y: 1499}, {X: 1499, Y: 1498}})
+	start := model.Pose{X: 1499, Y: 1496, Direction: model.North}
+	goal := Goal{Position: model.Position{X: 1499, Y: 1499}}
+
+	plan := NewPlannerWithLimit(DefaultCosts, 1000).Plan(env, start, goal)
+	if plan.Status != StatusUnreachable || plan.Reason != "no path from (1499,1496) to (1499,1499) within 1000 poses" {
+		t.Errorf("Plan() = %+v, want unreachable within 1000 poses", plan)
+	}
+
+	// A reachable goal within the limit is still planned
+	plan = NewPlannerWithLimit(DefaultCosts, 1000).Plan(env, start, Goal{Position: model.Position{X: 1499, Y: 1497}})
+	if plan.Status != StatusSuccess || plan.Commands != "M" {
+		t.Errorf("Plan() = %+v, want M", plan)
+	}
+}
+
+func TestPlan_InvalidInput(t *testing.T) {
+	tests := []struct {
+		name  string
+		costs Costs
+		start model.Pose
+		goal  Goal
+	}{
+		{"zero move cost", Costs{Move: 0, Turn: 1}, model.Pose{Direction: model.North}, Goal{}},
+		{"negative turn cost", Costs{Move: 1, Turn: -1}, model.Pose{Direction: model.North}, Goal{}},
+		{"unknown start direction", DefaultCosts, model.Pose{Direction: "Q"}, Goal{}},
+		{"unknown goal direction", DefaultCosts, model.Pose{Direction: model.North}, Goal{Direction: "Q"}},
+		{"start out of bounds", DefaultCosts, model.Pose{X: -1, Direction: model.North}, Goal{}},
+		{"start on obstacle", DefaultCosts, model.Pose{X: 2, Y: 2, Direction: model.North}, Goal{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := environment.NewEnvironment(5, 5, []model.Position{{X: 2, Y: 2}})
+			plan := NewPlanner(tt.costs).Plan(env, tt.start, tt.goal)
+
+			if plan.Status != StatusInvalidInput {
+				t.Errorf("Plan() status = %s, want %s", plan.Status, StatusInvalidInput)
+			}
+			if plan.Reason == "" {
+				t.Error("Plan() reason should explain the invalid input")
+			}
+		})
+	}
+}
+
+// replay drives a rover through commands and stops at the first blocked move.
+func replay(env environment.Environment, start model.Pose, commands string) (rover.Rover, environment.CanMoveStatus) {
+	r := rover.NewRover(start.X, start.Y, start.Direction)
+	for _, cmd := range commands {
+		switch cmd {
+		case 'M':
+			next, _ := env.Normalize(r.GetTryMovePosition())
+			if status := env.CanMove(next); status != environment.Success {
+				return r, status
+			}
+			r.MoveTo(next)
+		case 'L':
+			r.TurnLeft()
+		case 'R':
+			r.TurnRight()
+		}
+	}
+	return r, environment.Success
+}
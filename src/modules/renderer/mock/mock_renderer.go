@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: renderer.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "mars-rover-navigation/src/model"
+	environment "mars-rover-navigation/src/modules/environment"
+	renderer "mars-rover-navigation/src/modules/renderer"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRenderer is a mock of Renderer interface.
+type MockRenderer struct {
+	ctrl     *gomock.Controller
+	recorder *MockRendererMockRecorder
+}
+
+// MockRendererMockRecorder is the mock recorder for MockRenderer.
+type MockRendererMockRecorder struct {
+	mock *MockRenderer
+}
+
+// NewMockRenderer creates a new mock instance.
+func NewMockRenderer(ctrl *gomock.Controller) *MockRenderer {
+	mock := &MockRenderer{ctrl: ctrl}
+	mock.recorder = &MockRendererMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRenderer) EXPECT() *MockRendererMockRecorder {
+	return m.recorder
+}
+
+// Render mocks base method.
+func (m *MockRenderer) Render(env environment.Environment, tracks []renderer.Track, viewport model.Size) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Render", env, tracks, viewport)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Render indicates an expected call of Render.
+func (mr *MockRendererMockRecorder) Render(env, tracks, viewport interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Render", reflect.TypeOf((*MockRenderer)(nil).Render), env, tracks, viewport)
+}
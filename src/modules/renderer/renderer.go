@@ -0,0 +1,32 @@
+//go:generate go run github.com/golang/mock/mockgen -source=renderer.go -destination=./mock/mock_renderer.go -package=mock
+
+package renderer
+
+import (
+	"mars-rover-navigation/src/model"
+	"mars-rover-navigation/src/modules/environment"
+)
+
+type Renderer interface {
+	Render(env environment.Environment, tracks []Track, viewport model.Size) string
+}
+
+type Format string
+
+const (
+	FormatASCII Format = "ascii"
+)
+
+// Track is the journey of one rover, Path lists the cells entered after Start in order.
+type Track struct {
+	Start model.Pose
+	Path  []model.Position
+	Final model.Pose
+}
+
+const (
+	CellEmpty    = '.'
+	CellObstacle = '#'
+	CellStart    = 'S'
+	CellPath     = '*'
+)
@@ -0,0 +1,120 @@
+package renderer
+
+import (
+	"fmt"
+	"mars-rover-navigation/src/model"
+	"mars-rover-navigation/src/modules/environment"
+	"mars-rover-navigation/src/modules/game"
+	"strings"
+)
+
+type rendererImpl struct{}
+
+func NewRenderer() *rendererImpl {
+	return &rendererImpl{}
+}
+
+var headingArrows = map[model.Direction]rune{
+	model.North: '^',
+	model.East:  '>',
+	model.South: 'v',
+	model.West:  '<',
+	// Diagonals show the line they drive along, the trail tells the two ends apart
+	model.NorthEast: '/',
+	model.SouthWest: '/',
+	model.NorthWest: '\\',
+	model.SouthEast: '\\',
+}
+
+// Render draws the grid with the top row at the highest Y, matching rover.Move where N is Y+1.
+// A zero viewport dimension shows the whole grid along that axis, otherwise the view is
+// cropped around the final pose of the first track and kept inside the grid.
+func (r *rendererImpl) Render(env environment.Environment, tracks []Track, viewport model.Size) string {
+	size := env.Size()
+	width, height := size.Width, size.Height
+	if width <= 0 || height <= 0 {
+		return ""
+	}
+
+	var focus model.Position
+	if len(tracks) > 0 {
+		focus = model.Position{X: tracks[0].Final.X, Y: tracks[0].Final.Y}
+	}
+	minX, maxX := crop(focus.X, viewport.Width, width)
+	minY, maxY := crop(focus.Y, viewport.Height, height)
+
+	// Only the visible cells are looked up, the grid may be far larger than the view
+	cells := make(map[model.Position]rune)
+	for x := minX; x <= maxX; x++ {
+		for y := minY; y <= maxY; y++ {
+			if cell := env.Cell(model.Position{X: x, Y: y}); cell.IsObstacle {
+				cells[cell.Position] = CellObstacle
+			}
+		}
+	}
+	// Later layers win: path, then starts, then the rovers themselves
+	for _, track := range tracks {
+		for _, position := range track.Path {
+			cells[position] = CellPath
+		}
+	}
+	for _, track := range tracks {
+		cells[model.Position{X: track.Start.X, Y: track.Start.Y}] = CellStart
+	}
+	for _, track := range tracks {
+		arrow, ok := headingArrows[track.Final.Direction]
+		if !ok {
+			arrow = '?'
+		}
+		cells[model.Position{X: track.Final.X, Y: track.Final.Y}] = arrow
+	}
+
+	labelWidth := len(fmt.Sprint(maxY))
+	var sb strings.Builder
+	for y := maxY; y >= minY; y-- {
+		fmt.Fprintf(&sb, "%*d ", labelWidth, y)
+		for x := minX; x <= maxX; x++ {
+			if symbol, ok := cells[model.Position{X: x, Y: y}]; ok {
+				sb.WriteRune(symbol)
+			} else {
+				sb.WriteRune(CellEmpty)
+			}
+		}
+		sb.WriteByte('\n')
+	}
+	fmt.Fprintf(&sb, "%*s x=%d..%d\n", labelWidth, "", minX, maxX)
+
+	return sb.String()
+}
+
+// crop returns the inclusive range of size cells around center within [0, limit).
+func crop(center, size, limit int) (int, int) {
+	if size <= 0 || size >= limit {
+		return 0, limit - 1
+	}
+
+	low := center - size/2
+	if low < 0 {
+		low = 0
+	}
+	if low+size > limit {
+		low = limit - size
+	}
+	return low, low + size - 1
+}
+
+// TrackOf rebuilds the path of a rover from its execution trace.
+func TrackOf(start model.Pose, result game.ExtendedResult) Track {
+	track := Track{
+		Start: start,
+		Final: model.Pose{X: result.FinalPosition.X, Y: result.FinalPosition.Y, Direction: result.FinalDirection},
+	}
+
+	for _, step := range result.Trace {
+		if step.Before.X != step.After.X || step.Before.Y != step.After.Y {
+			track.Path = append(track.Path, model.Position{X: step.After.X, Y: step.After.Y})
+		}
+	}
+
+	return track
+}
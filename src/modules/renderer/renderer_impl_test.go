@@ -0,0 +1,144 @@
+package renderer
+
+import (
+	"mars-rover-navigation/src/model"
+	"mars-rover-navigation/src/modules/environment"
+	"mars-rover-navigation/src/modules/game"
+	"reflect"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name      string
+		width     int
+		height    int
+		obstacles []model.Position
+		tracks    []Track
+		viewport  model.Size
+		expected  string
+	}{
+		{
+			name:      "Y increases upward",
+			width:     3,
+			height:    3,
+			obstacles: []model.Position{{X: 2, Y: 2}},
+			tracks: []Track{{
+				Start: model.Pose{X: 0, Y: 0, Direction: model.North},
+				Path:  []model.Position{{X: 0, Y: 1}, {X: 1, Y: 1}},
+				Final: model.Pose{X: 1, Y: 1, Direction: model.East},
+			}},
+			expected: "2 ..#\n" +
+				"1 *>.\n" +
+				"0 S..\n" +
+				"  x=0..2\n",
+		},
+		{
+			name:   "Heading arrows",
+			width:  4,
+			height: 1,
+			tracks: []Track{
+				{Start: model.Pose{X: 0, Direction: model.North}, Final: model.Pose{X: 0, Direction: model.North}},
+				{Start: model.Pose{X: 1, Direction: model.East}, Final: model.Pose{X: 1, Direction: model.East}},
+				{Start: model.Pose{X: 2, Direction: model.South}, Final: model.Pose{X: 2, Direction: model.South}},
+				{Start: model.Pose{X: 3, Direction: model.West}, Final: model.Pose{X: 3, Direction: model.West}},
+			},
+			expected: "0 ^>v<\n" +
+				"  x=0..3\n",
+		},
+		{
+			name:   "Diagonal heading arrows",
+			width:  2,
+			height: 1,
+			tracks: []Track{
+				{Start: model.Pose{X: 0, Direction: model.NorthEast}, Final: model.Pose{X: 0, Direction: model.NorthEast}},
+				{Start: model.Pose{X: 1, Direction: model.NorthWest}, Final: model.Pose{X: 1, Direction: model.NorthWest}},
+			},
+			expected: "0 /\\\n" +
+				"  x=0..1\n",
+		},
+		{
+			name:      "No tracks",
+			width:     2,
+			height:    2,
+			obstacles: []model.Position{{X: 1, Y: 0}},
+			expected: "1 ..\n" +
+				"0 .#\n" +
+				"  x=0..1\n",
+		},
+		{
+			name:   "Viewport around the rover",
+			width:  20,
+			height: 12,
+			tracks: []Track{{
+				Start: model.Pose{X: 10, Y: 9, Direction: model.East},
+				Path:  []model.Position{{X: 11, Y: 9}},
+				Final: model.Pose{X: 11, Y: 9, Direction: model.East},
+			}},
+			viewport: model.Size{Width: 5, Height: 3},
+			expected: "10 .....\n" +
+				" 9 .S>..\n" +
+				" 8 .....\n" +
+				"   x=9..13\n",
+		},
+		{
+			name:   "Viewport clamped to the grid",
+			width:  10,
+			height: 10,
+			tracks: []Track{{
+				Start: model.Pose{X: 0, Y: 0, Direction: model.West},
+				Final: model.Pose{X: 0, Y: 0, Direction: model.West},
+			}},
+			viewport: model.Size{Width: 3, Height: 2},
+			expected: "1 ...\n" +
+				"0 <..\n" +
+				"  x=0..2\n",
+		},
+		{
+			name:   "Viewport larger than the grid",
+			width:  2,
+			height: 1,
+			tracks: []Track{{
+				Start: model.Pose{X: 1, Y: 0, Direction: model.North},
+				Final: model.Pose{X: 1, Y: 0, Direction: model.North},
+			}},
+			viewport: model.Size{Width: 50, Height: 50},
+			expected: "0 .^\n" +
+				"  x=0..1\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			env := environment.NewEnvironment(tc.width, tc.height, tc.obstacles)
+			got := NewRenderer().Render(env, tc.tracks, tc.viewport)
+			if got != tc.expected {
+				t.Errorf("Render() =\n%s\nwant\n%s", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestRender_EmptyGrid(t *testing.T) {
+	env := environment.NewEnvironment(0, 0, nil)
+	if got := NewRenderer().Render(env, nil, model.Size{}); got != "" {
+		t.Errorf("Render() = %q, want empty", got)
+	}
+}
+
+func TestTrackOf(t *testing.T) {
+	start := model.Pose{X: 0, Y: 0, Direction: model.North}
+	result := game.NewGame().NavigateRoverWithOptions(3, 3, []model.Position{{X: 2, Y: 1}},
+		model.Position{X: 0, Y: 0}, model.North, "MRMM", game.Options{Trace: true})
+
+	track := TrackOf(start, result)
+
+	expected := Track{
+		Start: start,
+		Path:  []model.Position{{X: 0, Y: 1}, {X: 1, Y: 1}},
+		Final: model.Pose{X: 1, Y: 1, Direction: model.East},
+	}
+	if !reflect.DeepEqual(track, expected) {
+		t.Errorf("TrackOf() = %+v, want %+v", track, expected)
+	}
+}
@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repl.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockREPL is a mock of REPL interface.
+type MockREPL struct {
+	ctrl     *gomock.Controller
+	recorder *MockREPLMockRecorder
+}
+
+// MockREPLMockRecorder is the mock recorder for MockREPL.
+type MockREPLMockRecorder struct {
+	mock *MockREPL
+}
+
+// NewMockREPL creates a new mock instance.
+func NewMockREPL(ctrl *gomock.Controller) *MockREPL {
+	mock := &MockREPL{ctrl: ctrl}
+	mock.recorder = &MockREPLMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockREPL) EXPECT() *MockREPLMockRecorder {
+	return m.recorder
+}
+
+// Run mocks base method.
+func (m *MockREPL) Run(in io.Reader, out io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Run", in, out)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockREPLMockRecorder) Run(in, out interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockREPL)(nil).Run), in, out)
+}
@@ -0,0 +1,25 @@
+//go:generate go run github.com/golang/mock/mockgen -source=repl.go -destination=./mock/mock_repl.go -package=mock
+
+package repl
+
+import (
+	"io"
+)
+
+// REPL drives a single rover live, one line of input at a time.
+//
+// A line is either a command program, e.g. M, 3M or (MR)2, run from the current pose,
+// or one of the keywords:
+//   - status: the pose, the last status and the remaining charge
+//   - map: the grid with the path driven so far
+//   - history: the command lines executed so far
+//   - undo: drop the last executed line
+//   - reset: drop every line and go back to the start pose
+//   - save PATH: write a mission file (.json, .yaml or .yml) that replays the session
+//   - help, quit
+type REPL interface {
+	// Run reads lines from in until it ends or quit, writing every outcome to out
+	Run(in io.Reader, out io.Writer) error
+}
+
+const Prompt = "> "
@@ -0,0 +1,227 @@
+package repl
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mars-rover-navigation/src/model"
+	"mars-rover-navigation/src/modules/command"
+	"mars-rover-navigation/src/modules/environment"
+	"mars-rover-navigation/src/modules/game"
+	"mars-rover-navigation/src/modules/mission"
+	"mars-rover-navigation/src/modules/renderer"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const help = `commands: a command program (M, 3M, (MR)2, @macro) or
+  status         pose, last status and remaining charge
+  map            grid with the path driven so far
+  history        command lines executed so far
+  undo           drop the last executed line
+  reset          go back to the start pose
+  save PATH      write a mission file that replays the session
+  help, quit
+`
+
+type replImpl struct {
+	game     game.Game
+	compiler command.Compiler
+	renderer renderer.Renderer
+	mission  *mission.Mission
+
+	// history holds the primitive commands each input line executed, lines that did nothing are left out
+	history []string
+	// result replays the history, status is the outcome of the last line
+	result game.ExtendedResult
+	status game.Status
+}
+
+// NewREPL drives the rover of m, the commands of m run before the first input line.
+func NewREPL(g game.Game, m *mission.Mission) *replImpl {
+	return &replImpl{
+		game:     g,
+		compiler: command.NewCompiler(),
+		renderer: renderer.NewRenderer(),
+		mission:  m,
+	}
+}
+
+func (r *replImpl) Run(in io.Reader, out io.Writer) error {
+	if len(r.mission.Rovers) > 0 {
+		return errors.New("interactive mode drives a single rover, the mission declares a fleet")
+	}
+
+	r.result = r.navigate("")
+	r.status = r.result.Status
+	if r.mission.Commands != "" {
+		r.execute(r.mission.Commands, out)
+	}
+
+	scanner := bufio.NewScanner(in)
+	fmt.Fprint(out, Prompt)
+	for scanner.Scan() {
+		if quit := r.handle(strings.TrimSpace(scanner.Text()), out); quit {
+			return nil
+		}
+		fmt.Fprint(out, Prompt)
+	}
+	return scanner.Err()
+}
+
+// handle runs one input line and reports whether it ends the session.
+func (r *replImpl) handle(line string, out io.Writer) bool {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return false
+	}
+
+	switch strings.ToLower(fields[0]) {
+	case "quit", "exit":
+		return true
+	case "help":
+		fmt.Fprint(out, help)
+	case "status":
+		r.printStatus(out)
+	case "map":
+		// A rejected grid has no cells to draw
+		if r.mission.Grid.Width <= 0 || r.mission.Grid.Height <= 0 {
+			fmt.Fprintf(out, "error: no map of a %dx%d grid\n", r.mission.Grid.Width, r.mission.Grid.Height)
+			return false
+		}
+		start := model.Pose{X: r.mission.Start.X, Y: r.mission.Start.Y, Direction: r.mission.Start.Direction}
+		env := environment.New(r.mission.Grid.Width, r.mission.Grid.Height, r.mission.Obstacles)
+		fmt.Fprint(out, r.renderer.Render(env, []renderer.Track{renderer.TrackOf(start, r.result)}, model.Size{}))
+	case "history":
+		for i, commands := range r.history {
+			fmt.Fprintf(out, "%d %s\n", i+1, commands)
+		}
+	case "undo":
+		if len(r.history) == 0 {
+			fmt.Fprintln(out, "nothing to undo")
+			return false
+		}
+		r.replay(r.history[:len(r.history)-1])
+		r.printStatus(out)
+	case "reset":
+		r.replay(nil)
+		r.printStatus(out)
+	case "save":
+		if len(fields) != 2 {
+			fmt.Fprintln(out, "error: usage: save PATH")
+			return false
+		}
+		if err := r.save(fields[1]); err != nil {
+			fmt.Fprintf(out, "error: %v\n", err)
+			return false
+		}
+		fmt.Fprintf(out, "saved %s\n", fields[1])
+	default:
+		r.execute(line, out)
+	}
+	return false
+}
+
+// execute runs a command program from the current pose and prints every step.
+func (r *replImpl) execute(program string, out io.Writer) {
+	commands, err := r.compiler.Compile(program, r.mission.Macros)
+	if err != nil {
+		fmt.Fprintf(out, "error: %v\n", err)
+		return
+	}
+
+	// The whole history is replayed so every option (policy, battery, terrain) sees the same rover state
+	executed := r.commands()
+	result := r.navigate(executed + commands)
+	for _, step := range result.Trace {
+		if step.Index >= len(executed) {
+			printStep(out, step)
+		}
+	}
+
+	ran := commands
+	if result.Status != game.StatusSuccess {
+		// The command that stopped the rover did not run, nor did the ones after it
+		ran = ""
+		if n := len(result.Trace); n > 0 && result.Trace[n-1].Index > len(executed) {
+			ran = commands[:result.Trace[n-1].Index-len(executed)]
+		}
+		fmt.Fprintf(out, "stopped: %s\n", result.Status)
+	}
+
+	history := r.history
+	if ran != "" {
+		history = append(history, ran)
+	}
+	r.replay(history)
+	r.status = result.Status
+}
+
+// replay makes history the executed lines and moves the rover to where they lead.
+func (r *replImpl) replay(history []string) {
+	r.history = history
+	r.result = r.navigate(r.commands())
+	r.status = r.result.Status
+}
+
+func (r *replImpl) navigate(commands string) game.ExtendedResult {
+	m := *r.mission
+	m.Commands = commands
+	m.Options.Trace = true
+	return *mission.Navigate(r.game, &m).Rover
+}
+
+func (r *replImpl) commands() string {
+	return strings.Join(r.history, "")
+}
+
+func (r *replImpl) printStatus(out io.Writer) {
+	position := r.result.FinalPosition
+	fmt.Fprintf(out, "(%d, %d) %s %s, %d commands", position.X, position.Y, r.result.FinalDirection, r.status, len(r.commands()))
+	if r.result.RemainingCharge != nil {
+		fmt.Fprintf(out, ", remaining charge %g", *r.result.RemainingCharge)
+	}
+	fmt.Fprintln(out)
+}
+
+func printStep(out io.Writer, step game.Step) {
+	fmt.Fprintf(out, "%s -> (%d, %d) %s", step.Command, step.After.X, step.After.Y, step.After.Direction)
+	if step.MoveStatus != "" {
+		fmt.Fprintf(out, " %s", step.MoveStatus)
+	}
+	if step.Replan {
+		fmt.Fprint(out, " (replan)")
+	}
+	fmt.Fprintln(out)
+}
+
+// save writes the mission with the executed commands, it loads back into the same session.
+func (r *replImpl) save(path string) error {
+	format, err := mission.FormatFromPath(path)
+	if err != nil {
+		return err
+	}
+	if len(r.history) == 0 {
+		return errors.New("nothing to save, no command was executed")
+	}
+
+	m := *r.mission
+	// The history is made of primitives, the macros are no longer called
+	m.Commands, m.Macros = r.commands(), nil
+
+	var data []byte
+	switch format {
+	case mission.FormatJSON:
+		data, err = json.MarshalIndent(m, "", "  ")
+	case mission.FormatYAML:
+		data, err = yaml.Marshal(m)
+	}
+	if err != nil {
+		return fmt.Errorf("encode mission: %w", err)
+	}
+	return os.WriteFile(path, data, 0o644)
+}
@@ -0,0 +1,189 @@
+package repl
+
+import (
+	"bytes"
+	"mars-rover-navigation/src/model"
+	"mars-rover-navigation/src/modules/game"
+	"mars-rover-navigation/src/modules/mission"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func newMission() *mission.Mission {
+	return &mission.Mission{
+		Grid:      mission.Grid{Width: 5, Height: 5},
+		Obstacles: []model.Position{{X: 1, Y: 2}},
+		Start:     mission.Pose{Direction: model.North},
+	}
+}
+
+func run(t *testing.T, m *mission.Mission, script string) string {
+	t.Helper()
+	var out bytes.Buffer
+	if err := NewREPL(game.NewGame(), m).Run(strings.NewReader(script), &out); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	return out.String()
+}
+
+func TestREPL_Run(t *testing.T) {
+	tests := []struct {
+		name     string
+		mission  func() *mission.Mission
+		script   string
+		expected string
+	}{
+		{
+			name:    "Steps print the pose and move status",
+			mission: newMission,
+			script:  "2M\nR\nstatus\n",
+			expected: "> M -> (0, 1) N Success\n" +
+				"M -> (0, 2) N Success\n" +
+				"> R -> (0, 2) E\n" +
+				"> (0, 2) E Success, 3 commands\n" +
+				"> ",
+		},
+		{
+			name:    "A blocked move stops the line and keeps the pose",
+			mission: newMission,
+			script:  "MMRMM\nstatus\nL\nhistory\n",
+			expected: "> M -> (0, 1) N Success\n" +
+				"M -> (0, 2) N Success\n" +
+				"R -> (0, 2) E\n" +
+				"M -> (0, 2) E Obstacle encountered\n" +
+				"stopped: Obstacle encountered\n" +
+				"> (0, 2) E Obstacle encountered, 3 commands\n" +
+				"> L -> (0, 2) N\n" +
+				"> 1 MMR\n" +
+				"2 L\n" +
+				"> ",
+		},
+		{
+			name:    "Undo and reset",
+			mission: newMission,
+			script:  "M\nRM\nundo\nreset\nundo\n",
+			expected: "> M -> (0, 1) N Success\n" +
+				"> R -> (0, 1) E\n" +
+				"M -> (1, 1) E Success\n" +
+				"> (0, 1) N Success, 1 commands\n" +
+				"> (0, 0) N Success, 0 commands\n" +
+				"> nothing to undo\n" +
+				"> ",
+		},
+		{
+			name: "Mission commands run first and quit ends the session",
+			mission: func() *mission.Mission {
+				m := newMission()
+				m.Commands = "RM"
+				return m
+			},
+			script: "quit\nM\n",
+			expected: "R -> (0, 0) E\n" +
+				"M -> (1, 0) E Success\n" +
+				"> ",
+		},
+		{
+			name:    "Syntax errors and unknown commands",
+			mission: newMission,
+			script:  "MX\n\nsave\n",
+			expected: "> error: line 1, column 2: unexpected character 'X'\n" +
+				"> > error: usage: save PATH\n" +
+				"> ",
+		},
+		{
+			name: "Battery and map",
+			mission: func() *mission.Mission {
+				m := newMission()
+				m.Options.Profile = "scout"
+				return m
+			},
+			script: "M\nstatus\nmap\n",
+			expected: "> M -> (0, 1) N Success\n" +
+				"> (0, 1) N Success, 1 commands, remaining charge 39.5\n" +
+				"> 4 .....\n" +
+				"3 .....\n" +
+				"2 .#...\n" +
+				"1 ^....\n" +
+				"0 S....\n" +
+				"  x=0..4\n" +
+				"> ",
+		},
+		{
+			name: "No map of a rejected grid",
+			mission: func() *mission.Mission {
+				m := newMission()
+				m.Grid = mission.Grid{Width: -3, Height: 5}
+				return m
+			},
+			script: "map\nstatus\n",
+			expected: "> error: no map of a -3x5 grid\n" +
+				"> (0, 0) N Invalid input, 0 commands\n" +
+				"> ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := run(t, tt.mission(), tt.script); got != tt.expected {
+				t.Errorf("Run() output =\n%s\nexpected\n%s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestREPL_Save(t *testing.T) {
+	for _, name := range []string{"session.yaml", "session.json"} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+			m := newMission()
+			m.Macros = map[string]string{"hop": "MR"}
+
+			output := run(t, m, "@hop\nL2M\nsave "+path+"\n")
+			if !strings.Contains(output, "saved "+path) {
+				t.Fatalf("Run() output = %s, want the session saved", output)
+			}
+
+			saved, err := mission.NewLoader().Load(path)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if saved.Commands != "MRLMM" || saved.Grid != m.Grid || len(saved.Obstacles) != 1 {
+				t.Errorf("saved mission = %+v, want the grid, obstacles and commands MRLMM", saved)
+			}
+
+			// Unset fields are left out, a null or empty fleet would break the oneOf of the mission schema
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var file map[string]any
+			if err := yaml.Unmarshal(data, &file); err != nil {
+				t.Fatalf("decode %s: %v", name, err)
+			}
+			for _, field := range []string{"rovers", "macros", "terrain", "terrain_classes", "profiles", "options"} {
+				if value, ok := file[field]; ok {
+					t.Errorf("saved mission has %s = %v, want it left out", field, value)
+				}
+			}
+		})
+	}
+}
+
+func TestREPL_SaveNothing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.yaml")
+	if output := run(t, newMission(), "save "+path+"\n"); !strings.Contains(output, "error: nothing to save") {
+		t.Errorf("Run() output = %s, want nothing to save", output)
+	}
+}
+
+func TestREPL_Fleet(t *testing.T) {
+	m := newMission()
+	m.Rovers = []mission.Rover{{ID: "a", Commands: "M"}}
+	if err := NewREPL(game.NewGame(), m).Run(strings.NewReader(""), &bytes.Buffer{}); err == nil {
+		t.Error("Run() expected an error for a fleet mission")
+	}
+}
@@ -62,6 +62,20 @@ func (mr *MockRoverMockRecorder) GetPosition() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPosition", reflect.TypeOf((*MockRover)(nil).GetPosition))
 }
 
+// GetTryMoveBackwardPosition mocks base method.
+func (m *MockRover) GetTryMoveBackwardPosition() model.Position {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTryMoveBackwardPosition")
+	ret0, _ := ret[0].(model.Position)
+	return ret0
+}
+
+// GetTryMoveBackwardPosition indicates an expected call of GetTryMoveBackwardPosition.
+func (mr *MockRoverMockRecorder) GetTryMoveBackwardPosition() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTryMoveBackwardPosition", reflect.TypeOf((*MockRover)(nil).GetTryMoveBackwardPosition))
+}
+
 // GetTryMovePosition mocks base method.
 func (m *MockRover) GetTryMovePosition() model.Position {
 	m.ctrl.T.Helper()
@@ -88,6 +102,54 @@ func (mr *MockRoverMockRecorder) Move() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockRover)(nil).Move))
 }
 
+// MoveBackward mocks base method.
+func (m *MockRover) MoveBackward() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "MoveBackward")
+}
+
+// MoveBackward indicates an expected call of MoveBackward.
+func (mr *MockRoverMockRecorder) MoveBackward() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveBackward", reflect.TypeOf((*MockRover)(nil).MoveBackward))
+}
+
+// MoveTo mocks base method.
+func (m *MockRover) MoveTo(position model.Position) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "MoveTo", position)
+}
+
+// MoveTo indicates an expected call of MoveTo.
+func (mr *MockRoverMockRecorder) MoveTo(position interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveTo", reflect.TypeOf((*MockRover)(nil).MoveTo), position)
+}
+
+// TurnHalfLeft mocks base method.
+func (m *MockRover) TurnHalfLeft() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "TurnHalfLeft")
+}
+
+// TurnHalfLeft indicates an expected call of TurnHalfLeft.
+func (mr *MockRoverMockRecorder) TurnHalfLeft() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TurnHalfLeft", reflect.TypeOf((*MockRover)(nil).TurnHalfLeft))
+}
+
+// TurnHalfRight mocks base method.
+func (m *MockRover) TurnHalfRight() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "TurnHalfRight")
+}
+
+// TurnHalfRight indicates an expected call of TurnHalfRight.
+func (mr *MockRoverMockRecorder) TurnHalfRight() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TurnHalfRight", reflect.TypeOf((*MockRover)(nil).TurnHalfRight))
+}
+
 // TurnLeft mocks base method.
 func (m *MockRover) TurnLeft() {
 	m.ctrl.T.Helper()
@@ -111,3 +173,15 @@ func (mr *MockRoverMockRecorder) TurnRight() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TurnRight", reflect.TypeOf((*MockRover)(nil).TurnRight))
 }
+
+// UTurn mocks base method.
+func (m *MockRover) UTurn() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UTurn")
+}
+
+// UTurn indicates an expected call of UTurn.
+func (mr *MockRoverMockRecorder) UTurn() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UTurn", reflect.TypeOf((*MockRover)(nil).UTurn))
+}
@@ -6,10 +6,20 @@ import "mars-rover-navigation/src/model"
 
 type Rover interface {
 	GetTryMovePosition() model.Position
+	// GetTryMoveBackwardPosition is the cell behind the rover, where MoveBackward goes
+	GetTryMoveBackwardPosition() model.Position
 
 	Move()
+	MoveBackward()
+	// MoveTo places the rover on position, the environment may wrap a move around the grid
+	MoveTo(position model.Position)
 	TurnLeft()
 	TurnRight()
+	// TurnHalfLeft and TurnHalfRight turn 45 degrees, onto the diagonal headings of the eight-direction compass
+	TurnHalfLeft()
+	TurnHalfRight()
+	// UTurn turns the rover 180 degrees in place
+	UTurn()
 
 	GetPosition() model.Position
 	GetDirection() model.Direction
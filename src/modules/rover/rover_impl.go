@@ -28,40 +28,111 @@ func (r *roverImpl) GetTryMovePosition() model.Position {
 		expectMove.X++
 	case "W":
 		expectMove.X--
+	case "NE":
+		expectMove.X++
+		expectMove.Y++
+	case "SE":
+		expectMove.X++
+		expectMove.Y--
+	case "SW":
+		expectMove.X--
+		expectMove.Y--
+	case "NW":
+		expectMove.X--
+		expectMove.Y++
 	}
 
 	return expectMove
 }
 
 func (r *roverImpl) Move() {
-	switch r.Direction {
-	case "N":
-		r.Position.Y++
-	case "S":
-		r.Position.Y--
-	case "E":
-		r.Position.X++
-	case "W":
-		r.Position.X--
+	r.Position = r.GetTryMovePosition()
+}
+
+func (r *roverImpl) GetTryMoveBackwardPosition() model.Position {
+	forward := r.GetTryMovePosition()
+
+	// Reversing is the forward step mirrored through the current cell
+	return model.Position{
+		X: 2*r.Position.X - forward.X,
+		Y: 2*r.Position.Y - forward.Y,
 	}
 }
 
+func (r *roverImpl) MoveBackward() {
+	r.Position = r.GetTryMoveBackwardPosition()
+}
+
+func (r *roverImpl) MoveTo(position model.Position) {
+	r.Position = position
+}
+
 func (r *roverImpl) TurnLeft() {
 	directions := map[model.Direction]model.Direction{
-		"N": "W",
-		"W": "S",
-		"S": "E",
-		"E": "N",
+		"N":  "W",
+		"W":  "S",
+		"S":  "E",
+		"E":  "N",
+		"NE": "NW",
+		"NW": "SW",
+		"SW": "SE",
+		"SE": "NE",
 	}
 	r.Direction = directions[r.Direction]
 }
 
 func (r *roverImpl) TurnRight() {
 	directions := map[model.Direction]model.Direction{
-		"N": "E",
-		"E": "S",
-		"S": "W",
-		"W": "N",
+		"N":  "E",
+		"E":  "S",
+		"S":  "W",
+		"W":  "N",
+		"NE": "SE",
+		"SE": "SW",
+		"SW": "NW",
+		"NW": "NE",
+	}
+	r.Direction = directions[r.Direction]
+}
+
+func (r *roverImpl) TurnHalfLeft() {
+	directions := map[model.Direction]model.Direction{
+		"N":  "NW",
+		"NW": "W",
+		"W":  "SW",
+		"SW": "S",
+		"S":  "SE",
+		"SE": "E",
+		"E":  "NE",
+		"NE": "N",
+	}
+	r.Direction = directions[r.Direction]
+}
+
+func (r *roverImpl) TurnHalfRight() {
+	directions := map[model.Direction]model.Direction{
+		"N":  "NE",
+		"NE": "E",
+		"E":  "SE",
+		"SE": "S",
+		"S":  "SW",
+		"SW": "W",
+		"W":  "NW",
+		"NW": "N",
+	}
+	r.Direction = directions[r.Direction]
+}
+
+func (r *roverImpl) UTurn() {
+	directions := map[model.Direction]model.Direction{
+		"N":  "S",
+		"S":  "N",
+		"E":  "W",
+		"W":  "E",
+		"NE": "SW",
+		"SW": "NE",
+		"SE": "NW",
+		"NW": "SE",
 	}
 	r.Direction = directions[r.Direction]
 }
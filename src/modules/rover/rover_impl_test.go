@@ -57,6 +57,10 @@ func TestGetTryMovePosition(t *testing.T) {
 		{"South from origin", 0, 0, model.South, 0, -1},
 		{"East from origin", 0, 0, model.East, 1, 0},
 		{"West from origin", 0, 0, model.West, -1, 0},
+		{"NorthEast movement", 5, 5, model.NorthEast, 6, 6},
+		{"SouthEast movement", 5, 5, model.SouthEast, 6, 4},
+		{"SouthWest movement", 5, 5, model.SouthWest, 4, 4},
+		{"NorthWest movement", 5, 5, model.NorthWest, 4, 6},
 	}
 
 	for _, tt := range tests {
@@ -94,6 +98,7 @@ func TestMove(t *testing.T) {
 		{"Move South", 5, 5, model.South, 5, 4},
 		{"Move East", 5, 5, model.East, 6, 5},
 		{"Move West", 5, 5, model.West, 4, 5},
+		{"Move NorthWest", 5, 5, model.NorthWest, 4, 6},
 	}
 
 	for _, tt := range tests {
@@ -118,6 +123,93 @@ func TestMove(t *testing.T) {
 	}
 }
 
+func TestGetTryMoveBackwardPosition(t *testing.T) {
+	tests := []struct {
+		name      string
+		direction model.Direction
+		expectedX int
+		expectedY int
+	}{
+		{"Facing North", model.North, 5, 4},
+		{"Facing South", model.South, 5, 6},
+		{"Facing East", model.East, 4, 5},
+		{"Facing West", model.West, 6, 5},
+		{"Facing NorthEast", model.NorthEast, 4, 4},
+		{"Facing SouthWest", model.SouthWest, 6, 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rover := NewRover(5, 5, tt.direction)
+
+			tryMovePos := rover.GetTryMoveBackwardPosition()
+
+			if tryMovePos.X != tt.expectedX || tryMovePos.Y != tt.expectedY {
+				t.Errorf("Expected position (%d,%d), got (%d,%d)", tt.expectedX, tt.expectedY, tryMovePos.X, tryMovePos.Y)
+			}
+			if rover.Position.X != 5 || rover.Position.Y != 5 {
+				t.Error("GetTryMoveBackwardPosition should not modify rover's actual position")
+			}
+		})
+	}
+}
+
+func TestMoveBackward(t *testing.T) {
+	rover := NewRover(2, 2, model.East)
+
+	rover.MoveBackward()
+
+	if rover.Position != (model.Position{X: 1, Y: 2}) {
+		t.Errorf("Expected position (1,2), got (%d,%d)", rover.Position.X, rover.Position.Y)
+	}
+	// Reversing keeps the heading
+	if rover.Direction != model.East {
+		t.Errorf("Direction should remain %s after MoveBackward", model.East)
+	}
+}
+
+func TestUTurn(t *testing.T) {
+	tests := []struct {
+		initialDirection  model.Direction
+		expectedDirection model.Direction
+	}{
+		{model.North, model.South},
+		{model.South, model.North},
+		{model.East, model.West},
+		{model.West, model.East},
+		{model.NorthEast, model.SouthWest},
+		{model.NorthWest, model.SouthEast},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.initialDirection), func(t *testing.T) {
+			rover := NewRover(3, 1, tt.initialDirection)
+
+			rover.UTurn()
+
+			if rover.Direction != tt.expectedDirection {
+				t.Errorf("Expected direction %s, got %s", tt.expectedDirection, rover.Direction)
+			}
+			if rover.Position != (model.Position{X: 3, Y: 1}) {
+				t.Error("Position should not change after UTurn")
+			}
+		})
+	}
+}
+
+func TestMoveTo(t *testing.T) {
+	rover := NewRover(4, 2, model.East)
+
+	rover.MoveTo(model.Position{X: 0, Y: 2})
+
+	if rover.Position != (model.Position{X: 0, Y: 2}) {
+		t.Errorf("Expected position (0,2), got (%d,%d)", rover.Position.X, rover.Position.Y)
+	}
+	if rover.Direction != model.East {
+		t.Errorf("Direction should remain %s after MoveTo", model.East)
+	}
+}
+
 func TestTurnLeft(t *testing.T) {
 	tests := []struct {
 		name              string
@@ -128,6 +220,8 @@ func TestTurnLeft(t *testing.T) {
 		{"Turn left from West", model.West, model.South},
 		{"Turn left from South", model.South, model.East},
 		{"Turn left from East", model.East, model.North},
+		{"Turn left from NorthEast", model.NorthEast, model.NorthWest},
+		{"Turn left from SouthEast", model.SouthEast, model.NorthEast},
 	}
 
 	for _, tt := range tests {
@@ -159,6 +253,8 @@ func TestTurnRight(t *testing.T) {
 		{"Turn right from East", model.East, model.South},
 		{"Turn right from South", model.South, model.West},
 		{"Turn right from West", model.West, model.North},
+		{"Turn right from NorthWest", model.NorthWest, model.NorthEast},
+		{"Turn right from SouthWest", model.SouthWest, model.NorthWest},
 	}
 
 	for _, tt := range tests {
@@ -180,6 +276,62 @@ func TestTurnRight(t *testing.T) {
 	}
 }
 
+func TestTurnHalf(t *testing.T) {
+	tests := []struct {
+		name              string
+		initialDirection  model.Direction
+		left              bool
+		expectedDirection model.Direction
+	}{
+		{"Half left from North", model.North, true, model.NorthWest},
+		{"Half left from NorthWest", model.NorthWest, true, model.West},
+		{"Half left from NorthEast", model.NorthEast, true, model.North},
+		{"Half right from North", model.North, false, model.NorthEast},
+		{"Half right from SouthEast", model.SouthEast, false, model.South},
+		{"Half right from NorthWest", model.NorthWest, false, model.North},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rover := NewRover(1, 1, tt.initialDirection)
+
+			if tt.left {
+				rover.TurnHalfLeft()
+			} else {
+				rover.TurnHalfRight()
+			}
+
+			if rover.Direction != tt.expectedDirection {
+				t.Errorf("Expected direction %s, got %s", tt.expectedDirection, rover.Direction)
+			}
+			if rover.Position != (model.Position{X: 1, Y: 1}) {
+				t.Error("Position should not change after a half turn")
+			}
+		})
+	}
+}
+
+func TestHalfTurnRotation(t *testing.T) {
+	rover := NewRover(0, 0, model.North)
+
+	// Eight half turns either way bring us back to North through every heading
+	seen := map[model.Direction]bool{}
+	for i := 0; i < 8; i++ {
+		rover.TurnHalfRight()
+		seen[rover.Direction] = true
+	}
+	if rover.Direction != model.North || len(seen) != 8 {
+		t.Errorf("After eight half right turns, expected %s through 8 headings, got %s through %d", model.North, rover.Direction, len(seen))
+	}
+
+	for i := 0; i < 8; i++ {
+		rover.TurnHalfLeft()
+	}
+	if rover.Direction != model.North {
+		t.Errorf("After eight half left turns, expected direction %s, got %s", model.North, rover.Direction)
+	}
+}
+
 func TestGetPosition(t *testing.T) {
 	rover := NewRover(10, 20, model.North)
 
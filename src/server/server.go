@@ -0,0 +1,12 @@
+package server
+
+import (
+	"context"
+	"net/http"
+)
+
+type Server interface {
+	Handler() http.Handler
+	// Run serves on addr until ctx is cancelled, then shuts down gracefully
+	Run(ctx context.Context, addr string) error
+}
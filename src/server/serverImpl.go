@@ -0,0 +1,185 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mars-rover-navigation/src/modules/game"
+	"mars-rover-navigation/src/modules/mission"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/labstack/gommon/log"
+)
+
+const (
+	DefaultMaxBodyBytes int64 = 1 << 20
+
+	shutdownTimeout = 10 * time.Second
+)
+
+type Config struct {
+	MaxBodyBytes int64
+}
+
+type serverImpl struct {
+	config Config
+	loader mission.Loader
+	game   game.Game
+}
+
+type errorBody struct {
+	Error apiError `json:"error"`
+}
+
+type apiError struct {
+	Code    string               `json:"code"`
+	Message string               `json:"message"`
+	Details []mission.FieldError `json:"details,omitempty"`
+}
+
+type validateResponse struct {
+	Valid bool `json:"valid"`
+}
+
+type healthResponse struct {
+	Status string `json:"status"`
+}
+
+func Provide(config Config) *serverImpl {
+	return NewServer(config, mission.NewLoader(), game.NewGame())
+}
+
+func NewServer(config Config, loader mission.Loader, g game.Game) *serverImpl {
+	if config.MaxBodyBytes <= 0 {
+		config.MaxBodyBytes = DefaultMaxBodyBytes
+	}
+
+	return &serverImpl{
+		config: config,
+		loader: loader,
+		game:   g,
+	}
+}
+
+func (s *serverImpl) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/v1/navigate", allowMethod(http.MethodPost, http.HandlerFunc(s.handleNavigate)))
+	mux.Handle("/v1/validate", allowMethod(http.MethodPost, http.HandlerFunc(s.handleValidate)))
+	mux.Handle("/healthz", allowMethod(http.MethodGet, http.HandlerFunc(s.handleHealth)))
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "not_found", fmt.Sprintf("no route for %s", r.URL.Path), nil)
+	})
+	return mux
+}
+
+func (s *serverImpl) Run(ctx context.Context, addr string) error {
+	httpServer := &http.Server{
+		Addr:              addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		log.Infof("listening on %s", addr)
+		errCh <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		return httpServer.Shutdown(shutdownCtx)
+	}
+}
+
+func (s *serverImpl) handleNavigate(w http.ResponseWriter, r *http.Request) {
+	m, ok := s.readMission(w, r)
+	if !ok {
+		return
+	}
+
+	report := mission.Navigate(s.game, m)
+	if report.Fleet != nil {
+		writeJSON(w, http.StatusOK, report.Fleet)
+		return
+	}
+	writeJSON(w, http.StatusOK, report.Rover)
+}
+
+func (s *serverImpl) handleValidate(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.readMission(w, r); !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, validateResponse{Valid: true})
+}
+
+func (s *serverImpl) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, healthResponse{Status: "ok"})
+}
+
+// readMission decodes and validates the JSON mission body, writing the error response when it fails.
+func (s *serverImpl) readMission(w http.ResponseWriter, r *http.Request) (*mission.Mission, bool) {
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil || mediaType != "application/json" {
+			writeError(w, http.StatusUnsupportedMediaType, "unsupported_media_type", "Content-Type must be application/json", nil)
+			return nil, false
+		}
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, s.config.MaxBodyBytes))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeError(w, http.StatusRequestEntityTooLarge, "payload_too_large",
+				fmt.Sprintf("request body exceeds %d bytes", maxBytesErr.Limit), nil)
+			return nil, false
+		}
+		writeError(w, http.StatusBadRequest, "invalid_body", err.Error(), nil)
+		return nil, false
+	}
+
+	m, err := s.loader.Parse(data, mission.FormatJSON)
+	if err != nil {
+		var validationErr *mission.ValidationError
+		if errors.As(err, &validationErr) {
+			writeError(w, http.StatusUnprocessableEntity, "invalid_mission", "mission failed validation", validationErr.Errors)
+			return nil, false
+		}
+		writeError(w, http.StatusBadRequest, "invalid_json", err.Error(), nil)
+		return nil, false
+	}
+
+	return m, true
+}
+
+func allowMethod(method string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != method {
+			w.Header().Set("Allow", method)
+			writeError(w, http.StatusMethodNotAllowed, "method_not_allowed",
+				fmt.Sprintf("%s is not allowed, use %s", r.Method, method), nil)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string, details []mission.FieldError) {
+	writeJSON(w, status, errorBody{Error: apiError{Code: code, Message: message, Details: details}})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Error(err)
+	}
+}
@@ -0,0 +1,247 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"mars-rover-navigation/src/model"
+	"mars-rover-navigation/src/modules/game"
+	gameMock "mars-rover-navigation/src/modules/game/mock"
+	"mars-rover-navigation/src/modules/mission"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func doRequest(t *testing.T, handler http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func decodeError(t *testing.T, rec *httptest.ResponseRecorder) apiError {
+	t.Helper()
+
+	var body errorBody
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("error body is not valid JSON: %v, body: %s", err, rec.Body.String())
+	}
+	return body.Error
+}
+
+func TestProvide(t *testing.T) {
+	s := Provide(Config{})
+	if s == nil {
+		t.Fatal("Provide() returned nil")
+	}
+	if s.config.MaxBodyBytes != DefaultMaxBodyBytes {
+		t.Errorf("MaxBodyBytes = %d, want default %d", s.config.MaxBodyBytes, DefaultMaxBodyBytes)
+	}
+	if s.loader == nil || s.game == nil {
+		t.Error("Provide() should initialize loader and game")
+	}
+}
+
+func TestHealthz(t *testing.T) {
+	rec := doRequest(t, Provide(Config{}).Handler(), http.MethodGet, "/healthz", "")
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if strings.TrimSpace(rec.Body.String()) != `{"status":"ok"}` {
+		t.Errorf("body = %s, want {\"status\":\"ok\"}", rec.Body.String())
+	}
+	if rec.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("Content-Type = %s, want application/json", rec.Header().Get("Content-Type"))
+	}
+}
+
+func TestNavigate(t *testing.T) {
+	handler := Provide(Config{}).Handler()
+
+	rec := doRequest(t, handler, http.MethodPost, "/v1/navigate",
+		`{"grid": {"width": 5, "height": 5}, "obstacles": [{"x": 1, "y": 2}, {"x": 3, "y": 3}], "commands": "MMMRM"}`)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d, body: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+
+	var result game.ExtendedResult
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatalf("body is not a result: %v", err)
+	}
+	if result.Status != game.StatusSuccess {
+		t.Errorf("status = %s, want %s", result.Status, game.StatusSuccess)
+	}
+	if result.FinalPosition != (model.Position{X: 1, Y: 3}) {
+		t.Errorf("final position = %+v, want (1,3)", result.FinalPosition)
+	}
+	if !strings.Contains(rec.Body.String(), `"final_position":{"x":1,"y":3}`) {
+		t.Errorf("body = %s, want final_position with x and y", rec.Body.String())
+	}
+}
+
+func TestNavigate_Fleet(t *testing.T) {
+	handler := Provide(Config{}).Handler()
+
+	rec := doRequest(t, handler, http.MethodPost, "/v1/navigate",
+		`{"grid": {"width": 5, "height": 5}, "rovers": [{"id": "a", "commands": "MM"}, {"id": "b", "start": {"x": 0, "y": 4, "direction": "S"}, "commands": "MMM"}]}`)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d, body: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+
+	var result game.FleetResult
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatalf("body is not a fleet result: %v", err)
+	}
+	if len(result.Rovers) != 2 || result.Rovers[1].Status != game.StatusRoverCollision {
+		t.Errorf("fleet result = %+v, want rover b to collide", result)
+	}
+}
+
+func TestNavigate_UsesGame(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGame := gameMock.NewMockGame(ctrl)
+	mockGame.EXPECT().NavigateRoverWithOptions(3, 3, gomock.Any(), model.Position{X: 1, Y: 1}, model.East, "M", game.Options{Trace: true}).
+		Return(game.ExtendedResult{Result: game.Result{Status: game.StatusOutOfBounds}})
+
+	handler := NewServer(Config{}, mission.NewLoader(), mockGame).Handler()
+	rec := doRequest(t, handler, http.MethodPost, "/v1/navigate",
+		`{"grid": {"width": 3, "height": 3}, "start": {"x": 1, "y": 1, "direction": "E"}, "commands": "M", "options": {"trace": true}}`)
+
+	if !strings.Contains(rec.Body.String(), `"status":"Out of bounds"`) {
+		t.Errorf("body = %s, want the game result", rec.Body.String())
+	}
+}
+
+func TestValidate(t *testing.T) {
+	handler := Provide(Config{}).Handler()
+
+	rec := doRequest(t, handler, http.MethodPost, "/v1/validate", `{"grid": {"width": 5, "height": 5}, "commands": "M"}`)
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if strings.TrimSpace(rec.Body.String()) != `{"valid":true}` {
+		t.Errorf("body = %s, want {\"valid\":true}", rec.Body.String())
+	}
+}
+
+func TestValidate_InvalidMission(t *testing.T) {
+	handler := Provide(Config{}).Handler()
+
+	rec := doRequest(t, handler, http.MethodPost, "/v1/validate",
+		`{"grid": {"width": 5, "height": 5}, "obstacles": [{"x": 9, "y": 9}]}`)
+
+	if rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusUnprocessableEntity)
+	}
+	apiErr := decodeError(t, rec)
+	if apiErr.Code != "invalid_mission" {
+		t.Errorf("code = %s, want invalid_mission", apiErr.Code)
+	}
+	expected := []mission.FieldError{
+		{Path: "commands", Message: "is required when rovers is not set"},
+		{Path: "obstacles[0]", Message: "must be within the grid"},
+	}
+	if len(apiErr.Details) != len(expected) {
+		t.Fatalf("details = %+v, want %+v", apiErr.Details, expected)
+	}
+	for i := range expected {
+		if apiErr.Details[i] != expected[i] {
+			t.Errorf("details[%d] = %+v, want %+v", i, apiErr.Details[i], expected[i])
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	handler := NewServer(Config{MaxBodyBytes: 64}, mission.NewLoader(), game.NewGame()).Handler()
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		contentType    string
+		expectedStatus int
+		expectedCode   string
+	}{
+		{"malformed json", http.MethodPost, "/v1/navigate", `{"grid": `, "application/json", http.StatusBadRequest, "invalid_json"},
+		{"unknown field", http.MethodPost, "/v1/navigate", `{"speed": 1}`, "application/json", http.StatusBadRequest, "invalid_json"},
+		{"body too large", http.MethodPost, "/v1/navigate", `{"commands": "` + strings.Repeat("M", 100) + `"}`, "application/json", http.StatusRequestEntityTooLarge, "payload_too_large"},
+		{"wrong content type", http.MethodPost, "/v1/validate", `grid: {}`, "application/yaml", http.StatusUnsupportedMediaType, "unsupported_media_type"},
+		{"wrong method on navigate", http.MethodGet, "/v1/navigate", "", "", http.StatusMethodNotAllowed, "method_not_allowed"},
+		{"wrong method on healthz", http.MethodPost, "/healthz", "", "", http.StatusMethodNotAllowed, "method_not_allowed"},
+		{"unknown route", http.MethodGet, "/v2/navigate", "", "", http.StatusNotFound, "not_found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("status = %d, want %d, body: %s", rec.Code, tt.expectedStatus, rec.Body.String())
+			}
+			apiErr := decodeError(t, rec)
+			if apiErr.Code != tt.expectedCode {
+				t.Errorf("code = %s, want %s", apiErr.Code, tt.expectedCode)
+			}
+			if apiErr.Message == "" {
+				t.Error("error message should not be empty")
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen: %v", err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- Provide(Config{}).Run(ctx, addr)
+	}()
+
+	var resp *http.Response
+	for i := 0; i < 50; i++ {
+		resp, err = http.Get("http://" + addr + "/healthz")
+		if err == nil {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatalf("server did not start: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Run() error = %v, want nil after shutdown", err)
+	}
+}
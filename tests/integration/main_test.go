@@ -1,17 +1,62 @@
 package integration
 
 import (
+	"errors"
+	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"testing"
 )
 
+// roverBinary is built once, go run would hide the exit code of the program
+var roverBinary string
+
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "mars-rover-integration")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	roverBinary = filepath.Join(dir, "mars-rover")
+	if out, err := exec.Command("go", "build", "-o", roverBinary, "../../src").CombinedOutput(); err != nil {
+		fmt.Fprintf(os.Stderr, "build: %v\n%s", err, out)
+		os.Exit(1)
+	}
+
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// runRover runs the program with stdin and returns its combined output and exit code.
+func runRover(t *testing.T, stdin string, args ...string) (string, int) {
+	t.Helper()
+	cmd := exec.Command(roverBinary, args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	out, err := cmd.CombinedOutput()
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return string(out), exitErr.ExitCode()
+	}
+	if err != nil {
+		t.Fatalf("failed to run: %v, output: %s", err, out)
+	}
+	return string(out), 0
+}
+
 type testCase struct {
 	name      string
 	grid      int
 	obstacles string
 	commands  string
+	extraArgs []string
 	want      string
+	// wantExit is the exit code of the status in want
+	wantExit int
 }
 
 func TestMarsRoverIntegration(t *testing.T) {
@@ -21,74 +66,591 @@ func TestMarsRoverIntegration(t *testing.T) {
 			grid:      5,
 			obstacles: "[(1,2),(3,3)]",
 			commands:  "MMMRM",
-			want:      "{\"final_position\": [1, 3], \"final_direction\": \"E\", \"status\": \"Success\"}\n",
+			want:      "{\"version\":1,\"final_position\":[1,3],\"final_direction\":\"E\",\"status\":\"Success\",\"grid\":[5,5]}\n",
 		},
 		{
 			name:      "Obstacle encountered",
 			grid:      5,
 			obstacles: "[(1,2),(3,3)]",
 			commands:  "MMRM",
-			want:      "{\"final_position\": [0, 2], \"final_direction\": \"E\", \"status\": \"Obstacle encountered\"}\n",
+			want:      "{\"version\":1,\"final_position\":[0,2],\"final_direction\":\"E\",\"status\":\"Obstacle encountered\",\"grid\":[5,5]}\n",
+			wantExit:  3,
 		},
 		{
 			name:      "Out of bounds",
 			grid:      5,
 			obstacles: "[]",
 			commands:  "MMMMMMMM",
-			want:      "{\"final_position\": [0, 4], \"final_direction\": \"N\", \"status\": \"Out of bounds\"}\n",
+			want:      "{\"version\":1,\"final_position\":[0,4],\"final_direction\":\"N\",\"status\":\"Out of bounds\",\"grid\":[5,5]}\n",
+			wantExit:  4,
 		},
 		{
-			name:      "Invalid commands",
+			name:      "JSON array obstacles",
 			grid:      5,
-			obstacles: "[]",
-			commands:  "LMXMLM",
-			want:      "{\"final_position\": [0, 0], \"final_direction\": \"N\", \"status\": \"Invalid input\"}\n",
+			obstacles: "[[1,2],[3,3]]",
+			commands:  "MMRM",
+			want:      "{\"version\":1,\"final_position\":[0,2],\"final_direction\":\"E\",\"status\":\"Obstacle encountered\",\"grid\":[5,5]}\n",
+			wantExit:  3,
+		},
+		{
+			name:      "Wall of obstacles",
+			grid:      5,
+			obstacles: "[(0..3,2)]",
+			commands:  "RMMMLMMM",
+			want:      "{\"version\":1,\"final_position\":[3,1],\"final_direction\":\"N\",\"status\":\"Obstacle encountered\",\"grid\":[5,5]}\n",
+			wantExit:  3,
 		},
 		{
 			name:      "Minimal grid 1x1",
 			grid:      1,
 			obstacles: "[]",
 			commands:  "M",
-			want:      "{\"final_position\": [0, 0], \"final_direction\": \"N\", \"status\": \"Out of bounds\"}\n",
+			want:      "{\"version\":1,\"final_position\":[0,0],\"final_direction\":\"N\",\"status\":\"Out of bounds\",\"grid\":[1,1]}\n",
+			wantExit:  4,
 		},
 		{
 			name:      "Minimal grid 1x1 turn only",
 			grid:      1,
 			obstacles: "[]",
 			commands:  "LR",
-			want:      "{\"final_position\": [0, 0], \"final_direction\": \"N\", \"status\": \"Success\"}\n",
+			want:      "{\"version\":1,\"final_position\":[0,0],\"final_direction\":\"N\",\"status\":\"Success\",\"grid\":[1,1]}\n",
 		},
 		{
 			name:      "Large grid",
 			grid:      100,
 			obstacles: "[]",
 			commands:  "RMMMMM",
-			want:      "{\"final_position\": [5, 0], \"final_direction\": \"E\", \"status\": \"Success\"}\n",
+			want:      "{\"version\":1,\"final_position\":[5,0],\"final_direction\":\"E\",\"status\":\"Success\",\"grid\":[100,100]}\n",
 		},
 		{
 			name:      "Long command string",
 			grid:      10,
 			obstacles: "[]",
 			commands:  "MMRMRMRMRMRMRMRMRMRMRMRMRMRMRMRMRMRMRMRMRMRMRMRMRMRMRMRMRMRMRMRMRMRMRMRMRMRMRMRMRMRMRMRMRMRMRMRMRMRM",
-			want:      "{\"final_position\": [1, 2], \"final_direction\": \"E\", \"status\": \"Success\"}\n",
+			want:      "{\"version\":1,\"final_position\":[1,2],\"final_direction\":\"E\",\"status\":\"Success\",\"grid\":[10,10]}\n",
+		},
+		{
+			name:      "Custom start position",
+			grid:      5,
+			obstacles: "[(1,2),(3,3)]",
+			commands:  "MMLM",
+			extraArgs: []string{"--start_x", "4", "--start_y", "0", "--start_direction", "N"},
+			want:      "{\"version\":1,\"final_position\":[3,2],\"final_direction\":\"W\",\"status\":\"Success\",\"grid\":[5,5]}\n",
+		},
+		{
+			name:      "Start on obstacle",
+			grid:      5,
+			obstacles: "[(1,2),(3,3)]",
+			commands:  "M",
+			extraArgs: []string{"--start_x", "3", "--start_y", "3", "--start_direction", "E"},
+			want: "{\"version\":1,\"final_position\":[3,3],\"final_direction\":\"E\",\"status\":\"Start position on obstacle\",\"grid\":[5,5]," +
+				"\"errors\":[{\"code\":\"start_on_obstacle\",\"field\":\"start\",\"value\":\"(3, 3)\",\"message\":\"start is on an obstacle\"}]}\n",
+			wantExit: 6,
+		},
+		{
+			name:      "Start out of bounds",
+			grid:      5,
+			obstacles: "[]",
+			commands:  "M",
+			extraArgs: []string{"--start_x", "5", "--start_y", "0"},
+			want: "{\"version\":1,\"final_position\":[0,0],\"final_direction\":\"N\",\"status\":\"Invalid input\",\"grid\":[5,5]," +
+				"\"errors\":[{\"code\":\"out_of_bounds\",\"field\":\"start\",\"value\":\"(5, 0)\",\"message\":\"start is outside the 5x5 grid\"}]}\n",
+			wantExit: 5,
+		},
+		{
+			name:      "Every input problem",
+			grid:      5,
+			obstacles: "[(1,2),(7,0)]",
+			commands:  "M",
+			extraArgs: []string{"--start_x", "1", "--start_y", "9"},
+			want: "{\"version\":1,\"final_position\":[0,0],\"final_direction\":\"N\",\"status\":\"Invalid input\",\"grid\":[5,5],\"errors\":[" +
+				"{\"code\":\"out_of_bounds\",\"field\":\"obstacles\",\"value\":\"(7, 0)\",\"index\":1,\"message\":\"obstacle is outside the 5x5 grid\"}," +
+				"{\"code\":\"out_of_bounds\",\"field\":\"start\",\"value\":\"(1, 9)\",\"message\":\"start is outside the 5x5 grid\"}]}\n",
+			wantExit: 5,
+		},
+		{
+			name:      "Trace",
+			grid:      3,
+			obstacles: "[(2,0)]",
+			commands:  "RMM",
+			extraArgs: []string{"--trace"},
+			want: "{\"index\":0,\"command\":\"R\",\"before\":{\"x\":0,\"y\":0,\"direction\":\"N\"},\"after\":{\"x\":0,\"y\":0,\"direction\":\"E\"}}\n" +
+				"{\"index\":1,\"command\":\"M\",\"before\":{\"x\":0,\"y\":0,\"direction\":\"E\"},\"after\":{\"x\":1,\"y\":0,\"direction\":\"E\"},\"move_status\":\"Success\"}\n" +
+				"{\"index\":2,\"command\":\"M\",\"before\":{\"x\":1,\"y\":0,\"direction\":\"E\"},\"after\":{\"x\":1,\"y\":0,\"direction\":\"E\"},\"move_status\":\"Obstacle encountered\"}\n" +
+				"{\"version\":1,\"final_position\":[1,0],\"final_direction\":\"E\",\"status\":\"Obstacle encountered\",\"grid\":[3,3]}\n",
+			wantExit: 3,
+		},
+		{
+			name:      "Rectangular grid",
+			grid:      5,
+			obstacles: "[(10,1)]",
+			commands:  "RMMMMMMMMMLM",
+			extraArgs: []string{"--grid", "20x3"},
+			want:      "{\"version\":1,\"final_position\":[9,1],\"final_direction\":\"N\",\"status\":\"Success\",\"grid\":[20,3]}\n",
+		},
+		{
+			name:      "Rectangular grid out of bounds",
+			grid:      5,
+			obstacles: "[]",
+			commands:  "MMM",
+			extraArgs: []string{"--grid", "20x3"},
+			want:      "{\"version\":1,\"final_position\":[0,2],\"final_direction\":\"N\",\"status\":\"Out of bounds\",\"grid\":[20,3]}\n",
+			wantExit:  4,
+		},
+		{
+			name:      "Backward and U-turn",
+			grid:      5,
+			obstacles: "[(1,2),(3,3)]",
+			commands:  "MMMBURB",
+			want:      "{\"version\":1,\"final_position\":[0,2],\"final_direction\":\"W\",\"status\":\"Obstacle encountered\",\"grid\":[5,5]}\n",
+			wantExit:  3,
+		},
+		{
+			name:      "Skip blocked moves",
+			grid:      5,
+			obstacles: "[(1,2),(3,3)]",
+			commands:  "MMRMMLM",
+			extraArgs: []string{"--obstacle_policy", "skip"},
+			want: "{\"version\":1,\"final_position\":[0,3],\"final_direction\":\"N\",\"status\":\"Success\",\"grid\":[5,5],\"blocked\":[" +
+				"{\"index\":3,\"command\":\"M\",\"position\":[1,2],\"status\":\"Obstacle encountered\"}," +
+				"{\"index\":4,\"command\":\"M\",\"position\":[1,2],\"status\":\"Obstacle encountered\"}]}\n",
+		},
+		{
+			name:      "Wrap around the west edge",
+			grid:      5,
+			obstacles: "[(1,2),(3,3)]",
+			commands:  "LMM",
+			extraArgs: []string{"--topology", "wrap_x"},
+			want:      "{\"version\":1,\"final_position\":[3,0],\"final_direction\":\"W\",\"status\":\"Success\",\"grid\":[5,5]}\n",
+		},
+		{
+			name:      "Diagonal moves",
+			grid:      5,
+			obstacles: "[(1,2),(3,3)]",
+			commands:  "CMMM",
+			extraArgs: []string{"--compass", "eight"},
+			want:      "{\"version\":1,\"final_position\":[2,2],\"final_direction\":\"NE\",\"status\":\"Obstacle encountered\",\"grid\":[5,5]}\n",
+			wantExit:  3,
+		},
+		{
+			name:      "Diagonal corner cutting",
+			grid:      5,
+			obstacles: "[(1,0),(0,1)]",
+			commands:  "CMM",
+			extraArgs: []string{"--compass", "eight", "--corner_cutting"},
+			want:      "{\"version\":1,\"final_position\":[2,2],\"final_direction\":\"NE\",\"status\":\"Success\",\"grid\":[5,5]}\n",
+		},
+		{
+			name:      "Replan on a diagonal heading",
+			grid:      5,
+			obstacles: "[(1,1)]",
+			commands:  "MM",
+			extraArgs: []string{"--compass", "eight", "--obstacle_policy", "replan", "--start_direction", "NE"},
+			want: "{\"version\":1,\"final_position\":[0,0],\"final_direction\":\"N\",\"status\":\"Invalid input\",\"grid\":[5,5],\"errors\":[" +
+				"{\"code\":\"invalid_command\",\"field\":\"commands\",\"value\":\"M\",\"index\":0,\"message\":\"replan needs moves heading N, E, S or W\"}," +
+				"{\"code\":\"invalid_command\",\"field\":\"commands\",\"value\":\"M\",\"index\":1,\"message\":\"replan needs moves heading N, E, S or W\"}]}\n",
+			wantExit: 5,
+		},
+		{
+			name:      "Battery depleted",
+			grid:      100,
+			obstacles: "[]",
+			commands:  "100M",
+			extraArgs: []string{"--profile", "scout"},
+			want:      "{\"version\":1,\"final_position\":[0,80],\"final_direction\":\"N\",\"status\":\"Battery depleted\",\"grid\":[100,100],\"remaining_charge\":0}\n",
+			wantExit:  8,
+		},
+		{
+			name:      "Fog of war",
+			grid:      5,
+			obstacles: "[]",
+			commands:  "MMM",
+			extraArgs: []string{"--sensor_radius", "1"},
+			want:      "{\"version\":1,\"final_position\":[0,3],\"final_direction\":\"N\",\"status\":\"Success\",\"grid\":[5,5],\"explored\":0.36}\n",
+		},
+		{
+			name:      "Fog of war detour runs into an unseen obstacle",
+			grid:      5,
+			obstacles: "[(0,2),(1,3)]",
+			commands:  "MMMM",
+			extraArgs: []string{"--sensor_radius", "1", "--obstacle_policy", "replan"},
+			want: "{\"version\":1,\"final_position\":[1,2],\"final_direction\":\"N\",\"status\":\"Obstacle encountered\",\"grid\":[5,5],\"explored\":0.36," +
+				"\"blocked\":[{\"index\":1,\"command\":\"M\",\"position\":[0,2],\"status\":\"Obstacle encountered\",\"replan\":\"RMLMMMLMR\"}," +
+				"{\"index\":1,\"command\":\"M\",\"position\":[1,3],\"status\":\"Obstacle encountered\"}]}\n",
+			wantExit: 3,
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			cmd := exec.Command("go", "run", "../../src/main.go",
+			args := []string{
 				"--grid_size", strconv.Itoa(tc.grid),
 				"--obstacles", tc.obstacles,
 				"--commands", tc.commands,
-			)
-			out, err := cmd.CombinedOutput()
-			if err != nil {
-				t.Fatalf("failed to run: %v, output: %s", err, out)
 			}
-			got := string(out)
+			got, exitCode := runRover(t, "", append(args, tc.extraArgs...)...)
+			if got != tc.want {
+				t.Errorf("got %s, want %s", got, tc.want)
+			}
+			if exitCode != tc.wantExit {
+				t.Errorf("exit code = %d, want %d", exitCode, tc.wantExit)
+			}
+		})
+	}
+}
+
+func TestMarsRoverIntegration_InvalidCommands(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		want     string
+		wantExit int
+	}{
+		{
+			name: "Syntax error",
+			args: []string{"--grid_size", "5", "--obstacles", "[]", "--commands", "LMXMLM"},
+			want: "{\"version\":1,\"final_position\":[0,0],\"final_direction\":\"N\",\"status\":\"Invalid input\",\"grid\":[5,5],\"errors\":[" +
+				"{\"code\":\"invalid_command\",\"field\":\"commands\",\"value\":\"X\",\"index\":2,\"message\":\"unexpected character 'X'\"}]}",
+			wantExit: 12,
+		},
+		{
+			// Commands the language rejects are listed with the problems the game finds in the rest of the input
+			name: "Every problem of the input",
+			args: []string{"--grid", "5", "--obstacles", "[(9,9),(7,7)]", "--start_direction", "Q", "--commands", "MXZ"},
+			want: "{\"version\":1,\"final_position\":[0,0],\"final_direction\":\"N\",\"status\":\"Invalid input\",\"grid\":[5,5],\"errors\":[" +
+				"{\"code\":\"out_of_bounds\",\"field\":\"obstacles\",\"value\":\"(9, 9)\",\"index\":0,\"message\":\"obstacle is outside the 5x5 grid\"}," +
+				"{\"code\":\"out_of_bounds\",\"field\":\"obstacles\",\"value\":\"(7, 7)\",\"index\":1,\"message\":\"obstacle is outside the 5x5 grid\"}," +
+				"{\"code\":\"invalid_direction\",\"field\":\"direction\",\"value\":\"Q\",\"message\":\"direction must be N, E, S, W, NE, SE, SW or NW\"}," +
+				"{\"code\":\"invalid_command\",\"field\":\"commands\",\"value\":\"X\",\"index\":1,\"message\":\"unexpected character 'X'\"}," +
+				"{\"code\":\"invalid_command\",\"field\":\"commands\",\"value\":\"Z\",\"index\":2,\"message\":\"unexpected character 'Z'\"}]}",
+			wantExit: 12,
+		},
+		{
+			name: "Malformed obstacles",
+			args: []string{"--grid", "5", "--obstacles", "[(1,a)]", "--commands", "MQ", "--output", "text"},
+			want: "(0, 0) N Invalid input on a 5x5 grid, obstacles \"[(1,a)]\" invalid_format: column 5: unexpected character 'a', " +
+				"commands[1] \"Q\" invalid_command: unexpected character 'Q'",
+			wantExit: 11,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, exitCode := runRover(t, "", tc.args...)
+			if exitCode != tc.wantExit {
+				t.Errorf("exit code = %d, want %d", exitCode, tc.wantExit)
+			}
+			// The rejected result comes first, the error is logged after it
+			if line := strings.SplitN(got, "\n", 2)[0]; line != tc.want {
+				t.Errorf("got %s, want %s", line, tc.want)
+			}
+		})
+	}
+}
+
+func TestMarsRoverIntegration_CommandProgram(t *testing.T) {
+	got, _ := runRover(t, "", "--mission", "testdata/program.yaml")
+
+	want := "{\"version\":1,\"final_position\":[4,4],\"final_direction\":\"E\",\"status\":\"Success\",\"grid\":[10,10]}\n"
+	if got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestMarsRoverIntegration_Mission(t *testing.T) {
+	tests := []struct {
+		name     string
+		mission  string
+		want     string
+		wantExit int
+	}{
+		{
+			name:    "JSON mission",
+			mission: "testdata/mission.json",
+			want:    "{\"version\":1,\"final_position\":[9,1],\"final_direction\":\"N\",\"status\":\"Success\",\"grid\":[20,3]}\n",
+		},
+		{
+			name:     "YAML mission",
+			mission:  "testdata/mission.yaml",
+			want:     "{\"version\":1,\"final_position\":[0,2],\"final_direction\":\"E\",\"status\":\"Obstacle encountered\",\"grid\":[5,5]}\n",
+			wantExit: 3,
+		},
+		{
+			name:    "Fleet mission",
+			mission: "testdata/fleet.yaml",
+			want: "{\"version\":1,\"rover\":\"alpha\",\"final_position\":[2,0],\"final_direction\":\"E\",\"status\":\"Rover collision\",\"grid\":[5,5]}\n" +
+				"{\"version\":1,\"rover\":\"rover-2\",\"final_position\":[3,0],\"final_direction\":\"W\",\"status\":\"Rover collision\",\"grid\":[5,5]}\n",
+			wantExit: 7,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, exitCode := runRover(t, "", "--mission", tc.mission)
+			if got != tc.want {
+				t.Errorf("got %s, want %s", got, tc.want)
+			}
+			if exitCode != tc.wantExit {
+				t.Errorf("exit code = %d, want %d", exitCode, tc.wantExit)
+			}
+		})
+	}
+}
+
+func TestMarsRoverIntegration_InvalidMission(t *testing.T) {
+	got, exitCode := runRover(t, "", "--mission", "testdata/invalid_mission.yaml")
+	if exitCode != 13 {
+		t.Errorf("exit code = %d, want 13", exitCode)
+	}
+
+	for _, want := range []string{"grid.height", "start.direction"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected output to report %s, got %s", want, got)
+		}
+	}
+}
+
+func TestMarsRoverIntegration_Render(t *testing.T) {
+	got, _ := runRover(t, "", "--grid", "5", "--obstacles", "[(1,2),(3,3)]", "--commands", "MMRMMLM", "--render", "ascii", "--viewport", "3x3")
+
+	want := "{\"version\":1,\"final_position\":[0,2],\"final_direction\":\"E\",\"status\":\"Obstacle encountered\",\"grid\":[5,5]}\n" +
+		"3 ...\n" +
+		"2 >#.\n" +
+		"1 *..\n" +
+		"  x=0..2\n"
+	if got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestMarsRoverIntegration_Plan(t *testing.T) {
+	got, exitCode := runRover(t, "", "plan", "--grid", "5x5", "--obstacles", "[(1,0),(1,1),(1,2),(1,3)]", "--goal_x", "2", "--goal_y", "0")
+	if exitCode != 0 {
+		t.Errorf("exit code = %d, want 0", exitCode)
+	}
+
+	want := "{\"status\":\"Success\",\"commands\":\"MMMMRMMRMMMM\",\"cost\":12}\n"
+	if got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	// A plan that did not succeed is printed and fails the process like a rover status
+	got, exitCode = runRover(t, "", "plan", "--grid", "5x5", "--obstacles", "[(1,0..4)]", "--goal_x", "2", "--goal_y", "0")
+	want = "{\"status\":\"Unreachable\",\"commands\":\"\",\"cost\":0,\"reason\":\"no path from (0,0) to (2,0), explored 20 poses\"}\n"
+	if got != want || exitCode != 15 {
+		t.Errorf("got %s (exit %d), want %s (exit 15)", got, exitCode, want)
+	}
+
+	_, exitCode = runRover(t, "", "plan", "--grid", "5x5", "--goal_x", "2", "--move_cost", "0")
+	if exitCode != 5 {
+		t.Errorf("exit code = %d, want 5", exitCode)
+	}
+}
+
+func TestMarsRoverIntegration_Explore(t *testing.T) {
+	got, exitCode := runRover(t, "", "explore", "--grid", "5", "--obstacles", "[(2,0..3)]")
+	if exitCode != 0 {
+		t.Errorf("exit code = %d, want 0", exitCode)
+	}
+
+	// The rover goes around the wall to see its far side
+	want := "{\"version\":1,\"final_position\":[3,2],\"final_direction\":\"N\",\"status\":\"Success\",\"grid\":[5,5]," +
+		"\"explored\":1,\"commands\":\"MMMMRMMMMRMMMMRMRMM\",\"moves\":15,\"stop\":\"explored\"}\n"
+	if got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	// The commands replay the exploration
+	got, exitCode = runRover(t, "", "--grid_size", "5", "--obstacles", "[(2,0..3)]", "--commands", "MMMMRMMMMRMMMMRMRMM", "--sensor_radius", "1")
+	want = "{\"version\":1,\"final_position\":[3,2],\"final_direction\":\"N\",\"status\":\"Success\",\"grid\":[5,5],\"explored\":1}\n"
+	if got != want || exitCode != 0 {
+		t.Errorf("got %s (exit %d), want %s", got, exitCode, want)
+	}
+
+	// Explore writes the same records as a navigation
+	got, _ = runRover(t, "", "explore", "--grid", "5", "--obstacles", "[(2,0..3)]", "--output", "csv")
+	want = "version,rover,x,y,direction,status,width,height,remaining_charge,blocked,errors,explored,commands,moves,stop\n" +
+		"1,,3,2,N,Success,5,5,,0,0,1,MMMMRMMMMRMMMMRMRMM,15,explored\n"
+	if got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	_, exitCode = runRover(t, "", "explore", "--grid", "5", "--max_moves", "-1")
+	if exitCode != 5 {
+		t.Errorf("exit code = %d, want 5", exitCode)
+	}
+}
+
+func TestMarsRoverIntegration_Terrain(t *testing.T) {
+	got, exitCode := runRover(t, "", "--mission", "testdata/terrain.yaml")
+	if exitCode != 9 {
+		t.Errorf("exit code = %d, want 9", exitCode)
+	}
+
+	// The heavy rover pays 1.5x on rock and cannot enter sand, the scout crosses both
+	want := "{\"version\":1,\"rover\":\"hauler\",\"final_position\":[0,1],\"final_direction\":\"N\",\"status\":\"Impassable terrain\",\"grid\":[5,5],\"remaining_charge\":295.5}\n" +
+		"{\"version\":1,\"rover\":\"scout\",\"final_position\":[2,2],\"final_direction\":\"N\",\"status\":\"Success\",\"grid\":[5,5],\"remaining_charge\":38.25}\n"
+	if got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestMarsRoverIntegration_Batch(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantExit int
+	}{
+		{name: "Passes without a fail condition", args: []string{"--fail_on", ""}, wantExit: 0},
+		{name: "Fails on an invalid mission", args: nil, wantExit: 20},
+		{name: "Fails on a status", args: []string{"--fail_on", "rover_collision"}, wantExit: 20},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			args := append([]string{"batch", "--input", "testdata/batch.ndjson", "--workers", "3"}, tc.args...)
+			out, exitCode := runRover(t, "", args...)
+			if exitCode != tc.wantExit {
+				t.Errorf("exit code = %d, want %d", exitCode, tc.wantExit)
+			}
+
+			// Results keep the input order and the summary counts every rover of the fleet,
+			// a failed batch logs its error after them
+			lines := strings.Split(strings.TrimSpace(out), "\n")
+			if len(lines) < 5 {
+				t.Fatalf("expected 4 results and a summary, got %s", out)
+			}
+			for i, id := range []string{"success", "obstacle", "invalid", "fleet"} {
+				if !strings.HasPrefix(lines[i], "{\"line\":"+strconv.Itoa(i+1)+",\"id\":\""+id+"\"") {
+					t.Errorf("line %d = %s, want mission %s", i+1, lines[i], id)
+				}
+			}
+			want := "{\"summary\":{\"missions\":4,\"invalid\":1,\"statuses\":{\"Obstacle encountered\":1,\"Rover collision\":1,\"Success\":2}}}"
+			if lines[4] != want {
+				t.Errorf("summary = %s, want %s", lines[4], want)
+			}
+		})
+	}
+}
+
+func TestMarsRoverIntegration_Interactive(t *testing.T) {
+	got, _ := runRover(t, "RM\nundo\nL\nstatus\nquit\n", "--grid", "5", "--obstacles", "[(1,2)]", "--interactive", "--commands", "MM")
+
+	want := "M -> (0, 1) N Success\n" +
+		"M -> (0, 2) N Success\n" +
+		"> R -> (0, 2) E\n" +
+		"M -> (0, 2) E Obstacle encountered\n" +
+		"stopped: Obstacle encountered\n" +
+		"> (0, 2) N Success, 2 commands\n" +
+		"> L -> (0, 2) W\n" +
+		"> (0, 2) W Success, 3 commands\n" +
+		"> "
+	if got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestMarsRoverIntegration_Output(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{
+			format: "yaml",
+			want: "version: 1\nrover: alpha\nfinal_position: [2, 0]\nfinal_direction: E\nstatus: Rover collision\ngrid: [5, 5]\n---\n" +
+				"version: 1\nrover: rover-2\nfinal_position: [3, 0]\nfinal_direction: W\nstatus: Rover collision\ngrid: [5, 5]\n",
+		},
+		{
+			format: "text",
+			want:   "alpha: (2, 0) E Rover collision on a 5x5 grid\nrover-2: (3, 0) W Rover collision on a 5x5 grid\n",
+		},
+		{
+			format: "csv",
+			want: "version,rover,x,y,direction,status,width,height,remaining_charge,blocked,errors,explored,commands,moves,stop\n" +
+				"1,alpha,2,0,E,Rover collision,5,5,,0,0,,,,\n1,rover-2,3,0,W,Rover collision,5,5,,0,0,,,,\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.format, func(t *testing.T) {
+			got, _ := runRover(t, "", "--mission", "testdata/fleet.yaml", "--output", tc.format)
 			if got != tc.want {
 				t.Errorf("got %s, want %s", got, tc.want)
 			}
 		})
 	}
 }
+
+func TestMarsRoverIntegration_ExitCodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantExit int
+	}{
+		{name: "Success", args: []string{"--grid", "5", "--commands", "M"}, wantExit: 0},
+		{name: "Obstacle encountered", args: []string{"--grid", "5", "--obstacles", "[(0,1)]", "--commands", "M"}, wantExit: 3},
+		{name: "Out of bounds", args: []string{"--grid", "1", "--commands", "M"}, wantExit: 4},
+		{name: "Invalid input", args: []string{"--grid", "5", "--start_x", "9", "--commands", "M"}, wantExit: 5},
+		{name: "Start position on obstacle", args: []string{"--grid", "5", "--obstacles", "[(0,0)]", "--commands", "M"}, wantExit: 6},
+		{name: "Rover collision", args: []string{"--mission", "testdata/fleet.yaml"}, wantExit: 7},
+		{name: "Battery depleted", args: []string{"--grid", "100", "--commands", "100M", "--profile", "scout"}, wantExit: 8},
+		{name: "Impassable terrain", args: []string{"--mission", "testdata/terrain.yaml"}, wantExit: 9},
+		{name: "Invalid grid", args: []string{"--grid", "5xa", "--commands", "M"}, wantExit: 10},
+		{name: "Missing grid", args: []string{"--commands", "M"}, wantExit: 10},
+		{name: "Invalid obstacles", args: []string{"--grid", "5", "--obstacles", "(1,2)", "--commands", "M"}, wantExit: 11},
+		{name: "Non numeric obstacle", args: []string{"--grid", "5", "--obstacles", "[(1,a)]", "--commands", "M"}, wantExit: 11},
+		{name: "Invalid commands", args: []string{"--grid", "5", "--commands", "MX"}, wantExit: 12},
+		{name: "Missing commands", args: []string{"--grid", "5"}, wantExit: 12},
+		{name: "Invalid mission", args: []string{"--mission", "testdata/invalid_mission.yaml"}, wantExit: 13},
+		{name: "Missing mission file", args: []string{"--mission", "testdata/missing.yaml"}, wantExit: 13},
+		{name: "Invalid option", args: []string{"--grid", "5", "--commands", "M", "--topology", "sphere"}, wantExit: 14},
+		{name: "Invalid output format", args: []string{"--grid", "5", "--commands", "M", "--output", "xml"}, wantExit: 14},
+		{name: "Trace with csv output", args: []string{"--grid", "5", "--commands", "MMRMM", "--trace", "--output", "csv"}, wantExit: 14},
+		{name: "Unknown flag", args: []string{"--grid", "5", "--commands", "M", "--speed", "9"}, wantExit: 2},
+		{name: "Unknown plan flag", args: []string{"plan", "--speed", "9"}, wantExit: 2},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			out, exitCode := runRover(t, "", tc.args...)
+			if exitCode != tc.wantExit {
+				t.Errorf("exit code = %d, want %d, output: %s", exitCode, tc.wantExit, out)
+			}
+		})
+	}
+}
+
+func TestMarsRoverIntegration_Generate(t *testing.T) {
+	args := []string{"generate", "--grid", "30x20", "--seed", "11", "--density", "0.35", "--craters", "2",
+		"--corridors", "[(0,0),(29,0)]", "--commands", "R29M"}
+
+	first, exitCode := runRover(t, "", args...)
+	if exitCode != 0 {
+		t.Fatalf("generate exit code = %d, output: %s", exitCode, first)
+	}
+	second, _ := runRover(t, "", args...)
+	if first != second {
+		t.Errorf("generate wrote two missions for the same seed:\n%s\n%s", first, second)
+	}
+
+	// The corridor along the bottom row keeps the drive clear
+	path := filepath.Join(t.TempDir(), "generated.yaml")
+	if out, exitCode := runRover(t, "", append(args, "--out", path)...); exitCode != 0 {
+		t.Fatalf("generate exit code = %d, output: %s", exitCode, out)
+	}
+	got, exitCode := runRover(t, "", "--mission", path)
+	want := "{\"version\":1,\"final_position\":[29,0],\"final_direction\":\"E\",\"status\":\"Success\",\"grid\":[30,20]}\n"
+	if got != want || exitCode != 0 {
+		t.Errorf("got %s (exit %d), want %s", got, exitCode, want)
+	}
+
+	// A mission --mission would reject is not written
+	rejected := filepath.Join(t.TempDir(), "rejected.json")
+	if out, exitCode := runRover(t, "", "generate", "--grid", "6x4", "--start_x", "9", "--commands", "M", "--out", rejected); exitCode != 13 ||
+		!strings.Contains(out, "start: must be within the grid") {
+		t.Errorf("generate exit code = %d, output: %s, want 13 and the start error", exitCode, out)
+	}
+	if _, err := os.Stat(rejected); !os.IsNotExist(err) {
+		t.Errorf("generate wrote %s for a rejected mission", rejected)
+	}
+}